@@ -7,25 +7,42 @@ import (
 	"fmt"
 	"log/slog"
 	"net/http"
+	"net/url"
 	"os"
 	"os/signal"
+	"path/filepath"
+	"strconv"
+	"strings"
 	"syscall"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/MikeSquared-Agency/dredd/internal/anthropic"
 	"github.com/MikeSquared-Agency/dredd/internal/api"
+	"github.com/MikeSquared-Agency/dredd/internal/auth"
+	"github.com/MikeSquared-Agency/dredd/internal/autonomy"
+	"github.com/MikeSquared-Agency/dredd/internal/bus"
 	"github.com/MikeSquared-Agency/dredd/internal/backfill"
+	"github.com/MikeSquared-Agency/dredd/internal/chronicle"
 	"github.com/MikeSquared-Agency/dredd/internal/config"
+	"github.com/MikeSquared-Agency/dredd/internal/dataset"
+	"github.com/MikeSquared-Agency/dredd/internal/dedup"
+	"github.com/MikeSquared-Agency/dredd/internal/embedding"
+	"github.com/MikeSquared-Agency/dredd/internal/events"
 	"github.com/MikeSquared-Agency/dredd/internal/extractor"
 	"github.com/MikeSquared-Agency/dredd/internal/hermes"
+	"github.com/MikeSquared-Agency/dredd/internal/jobs"
 	"github.com/MikeSquared-Agency/dredd/internal/processor"
+	"github.com/MikeSquared-Agency/dredd/internal/refinement"
 	"github.com/MikeSquared-Agency/dredd/internal/slack"
 	"github.com/MikeSquared-Agency/dredd/internal/store"
+	"github.com/MikeSquared-Agency/dredd/pkg/client"
 )
 
 func main() {
-	// Route subcommands: "dredd" or "dredd serve" → service, "dredd backfill" → backfill, "dredd dedup" → dedup.
+	// Route subcommands: "dredd" or "dredd serve" → service, "dredd backfill" → backfill, "dredd dedup" → dedup
+	// ("dredd dedup restore" → undo), "dredd token" → API token management, "dredd events" → event contract tooling,
+	// "dredd export dataset" → fine-tuning dataset export, "dredd index" → vector index management.
 	if len(os.Args) > 1 && os.Args[1] == "backfill" {
 		runBackfill(os.Args[2:])
 		return
@@ -36,6 +53,26 @@ func main() {
 		return
 	}
 
+	if len(os.Args) > 1 && os.Args[1] == "token" {
+		runToken(os.Args[2:])
+		return
+	}
+
+	if len(os.Args) > 1 && os.Args[1] == "events" {
+		runEvents(os.Args[2:])
+		return
+	}
+
+	if len(os.Args) > 1 && os.Args[1] == "export" {
+		runExport(os.Args[2:])
+		return
+	}
+
+	if len(os.Args) > 1 && os.Args[1] == "index" {
+		runIndex(os.Args[2:])
+		return
+	}
+
 	// Strip "serve" if provided, then run the service.
 	if len(os.Args) > 1 && os.Args[1] == "serve" {
 		os.Args = append(os.Args[:1], os.Args[2:]...)
@@ -45,10 +82,19 @@ func main() {
 }
 
 func runDedup(args []string) {
+	if len(args) > 0 && args[0] == "restore" {
+		runDedupRestore(args[1:])
+		return
+	}
+
 	fs := flag.NewFlagSet("dedup", flag.ExitOnError)
 	threshold := fs.Float64("threshold", 0.92, "Similarity threshold (0.0-1.0)")
 	execute := fs.Bool("execute", false, "Execute deduplication (default is dry-run)")
 	table := fs.String("table", "all", "Table to deduplicate: patterns, decisions, or all")
+	neighbours := fs.Int("neighbours", dedup.DefaultNeighbours, "Nearest neighbours compared per record")
+	incremental := fs.Bool("incremental", false, "Only look for duplicates of records created since the table's last executed run")
+	server := fs.String("server", "", "Submit to a running dredd server (e.g. http://localhost:8750) instead of running locally")
+	token := fs.String("token", os.Getenv("DREDD_API_TOKEN"), "API token for --server")
 
 	if err := fs.Parse(args); err != nil {
 		fmt.Fprintf(os.Stderr, "parse flags: %v\n", err)
@@ -57,15 +103,51 @@ func runDedup(args []string) {
 
 	setupLogging("info")
 
+	// Validate threshold
+	if *threshold < 0.0 || *threshold > 1.0 {
+		slog.Error("threshold must be between 0.0 and 1.0", "threshold", *threshold)
+		os.Exit(1)
+	}
+
+	// Validate table
+	if *table != "patterns" && *table != "decisions" && *table != "all" {
+		slog.Error("table must be 'patterns', 'decisions', or 'all'", "table", *table)
+		os.Exit(1)
+	}
+
+	if *neighbours < 1 || *neighbours > 100 {
+		slog.Error("neighbours must be between 1 and 100", "neighbours", *neighbours)
+		os.Exit(1)
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer cancel()
+
+	slog.Info("dedup starting",
+		"threshold", *threshold,
+		"execute", *execute,
+		"table", *table,
+		"neighbours", *neighbours,
+		"incremental", *incremental,
+		"server", *server,
+	)
+
+	if *server != "" {
+		req := client.DedupRequest{Threshold: *threshold, Execute: *execute, Table: *table, Neighbours: *neighbours, Incremental: *incremental}
+		if err := runRemoteDedup(ctx, client.New(*server, *token), req); err != nil {
+			slog.Error("remote dedup failed", "error", err)
+			os.Exit(1)
+		}
+		slog.Info("dedup completed")
+		return
+	}
+
 	envCfg := config.Load()
 	if envCfg.DatabaseURL == "" {
 		slog.Error("DATABASE_URL is required")
 		os.Exit(1)
 	}
 
-	ctx, cancel := context.WithCancel(context.Background())
-	defer cancel()
-
 	// Database
 	db, err := store.New(ctx, envCfg.DatabaseURL)
 	if err != nil {
@@ -74,60 +156,504 @@ func runDedup(args []string) {
 	}
 	defer db.Close()
 
-	slog.Info("dedup starting",
-		"threshold", *threshold,
-		"execute", *execute,
-		"table", *table,
-	)
+	// Execute deduplication; Ctrl-C stops between clusters.
+	opts := dedup.Options{Threshold: *threshold, Neighbours: *neighbours, Incremental: *incremental, Execute: *execute}
+	results, err := db.Deduplicate(ctx, *table, opts, slog.Default(), nil)
+	for _, result := range results {
+		// Output result as JSON
+		output, merr := json.MarshalIndent(result, "", "  ")
+		if merr != nil {
+			slog.Error("failed to marshal result", "error", merr)
+			os.Exit(1)
+		}
+		fmt.Println(string(output))
+	}
+	if err != nil {
+		slog.Error("dedup failed", "error", err)
+		os.Exit(1)
+	}
 
-	// Validate threshold
-	if *threshold < 0.0 || *threshold > 1.0 {
-		slog.Error("threshold must be between 0.0 and 1.0", "threshold", *threshold)
+	slog.Info("dedup completed")
+}
+
+// runDedupRestore reverses a dedup run (--run) or the most recent merge
+// involving a record (--id), locally or against a running server.
+func runDedupRestore(args []string) {
+	fs := flag.NewFlagSet("dedup restore", flag.ExitOnError)
+	runID := fs.String("run", "", "Dedup run ID to restore")
+	recordID := fs.String("id", "", "Record ID whose most recent dedup cluster should be restored")
+	server := fs.String("server", "", "Restore through a running dredd server instead of the database")
+	token := fs.String("token", os.Getenv("DREDD_API_TOKEN"), "API token for --server")
+
+	if err := fs.Parse(args); err != nil {
+		fmt.Fprintf(os.Stderr, "parse flags: %v\n", err)
 		os.Exit(1)
 	}
 
-	// Validate table
-	if *table != "patterns" && *table != "decisions" && *table != "all" {
-		slog.Error("table must be 'patterns', 'decisions', or 'all'", "table", *table)
+	setupLogging("info")
+
+	if (*runID == "") == (*recordID == "") {
+		fmt.Fprintln(os.Stderr, "usage: dredd dedup restore (--run <run-id> | --id <record-id>) [--server <url>]")
+		os.Exit(2)
+	}
+	idStr := *runID + *recordID
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		slog.Error("invalid ID", "id", idStr, "error", err)
+		os.Exit(1)
+	}
+
+	ctx := context.Background()
+	// The server's and the client's results encode identically.
+	var result any
+
+	if *server != "" {
+		c := client.New(*server, *token)
+		if *runID != "" {
+			result, err = c.RestoreDedupRun(ctx, id)
+		} else {
+			result, err = c.RestoreDedupRecord(ctx, id)
+		}
+	} else {
+		envCfg := config.Load()
+		if envCfg.DatabaseURL == "" {
+			slog.Error("DATABASE_URL is required")
+			os.Exit(1)
+		}
+
+		db, derr := store.New(ctx, envCfg.DatabaseURL)
+		if derr != nil {
+			slog.Error("failed to connect to database", "error", derr)
+			os.Exit(1)
+		}
+		defer db.Close()
+
+		if *runID != "" {
+			result, err = db.RestoreDedupRun(ctx, id, slog.Default())
+		} else {
+			result, err = db.RestoreDedupRecord(ctx, id, slog.Default())
+		}
+	}
+	if err != nil {
+		slog.Error("dedup restore failed", "id", id, "error", err)
 		os.Exit(1)
 	}
 
-	logger := slog.Default()
+	output, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		slog.Error("failed to marshal result", "error", err)
+		os.Exit(1)
+	}
+	fmt.Println(string(output))
+}
+
+// runRemoteDedup submits a dedup job to a running server and polls it until it
+// finishes, printing the result. Cancelling ctx cancels the job on the server.
+func runRemoteDedup(ctx context.Context, c *client.Client, req client.DedupRequest) error {
+	job, err := c.SubmitDedup(context.Background(), req)
+	if err != nil {
+		return fmt.Errorf("submit job: %w", err)
+	}
+	slog.Info("dedup job submitted", "job_id", job.ID)
+
+	logProgress := func(j *client.Job) {
+		if j.Progress != nil && !j.Status.Done() {
+			slog.Info("dedup progress", "job_id", j.ID, "stage", j.Progress.Stage, "done", j.Progress.Done, "total", j.Progress.Total)
+		}
+	}
+
+	jobID := job.ID
+	job, err = c.WaitJob(ctx, jobID, 2*time.Second, logProgress)
+	if err != nil && ctx.Err() != nil {
+		slog.Info("cancelling dedup job", "job_id", jobID)
+		if _, err := c.CancelJob(context.Background(), jobID); err != nil {
+			return fmt.Errorf("cancel job: %w", err)
+		}
+		// Keep polling until the job records its final state.
+		job, err = c.WaitJob(context.Background(), jobID, time.Second, nil)
+	}
+	if err != nil {
+		return fmt.Errorf("poll job: %w", err)
+	}
 
-	// Execute deduplication
-	if *table == "patterns" || *table == "all" {
-		result, err := db.DeduplicateReasoningPatterns(ctx, *threshold, *execute, logger)
+	if job.Status != client.JobSucceeded {
+		return fmt.Errorf("job %s %s: %s", job.ID, job.Status, job.Error)
+	}
+
+	var result any
+	if err := json.Unmarshal(job.Result, &result); err != nil {
+		return fmt.Errorf("unmarshal result: %w", err)
+	}
+	output, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal result: %w", err)
+	}
+	fmt.Println(string(output))
+	return nil
+}
+
+func runToken(args []string) {
+	usage := "usage: dredd token create --name <name> --scopes <scope,...> | revoke --name <name> | list"
+	if len(args) == 0 {
+		fmt.Fprintln(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	fs := flag.NewFlagSet("token "+args[0], flag.ExitOnError)
+	name := fs.String("name", "", "Token name")
+	scopes := fs.String("scopes", auth.ScopeRead, "Comma-separated scopes: "+strings.Join(auth.Scopes, ", "))
+
+	if err := fs.Parse(args[1:]); err != nil {
+		fmt.Fprintf(os.Stderr, "parse flags: %v\n", err)
+		os.Exit(1)
+	}
+
+	setupLogging("info")
+
+	envCfg := config.Load()
+	if envCfg.DatabaseURL == "" {
+		slog.Error("DATABASE_URL is required")
+		os.Exit(1)
+	}
+
+	ctx := context.Background()
+	db, err := store.New(ctx, envCfg.DatabaseURL)
+	if err != nil {
+		slog.Error("failed to connect to database", "error", err)
+		os.Exit(1)
+	}
+	defer db.Close()
+
+	switch args[0] {
+	case "create":
+		if *name == "" {
+			fmt.Fprintln(os.Stderr, usage)
+			os.Exit(2)
+		}
+		var granted []string
+		for _, sc := range strings.Split(*scopes, ",") {
+			sc = strings.TrimSpace(sc)
+			if !auth.ValidScope(sc) {
+				slog.Error("unknown scope", "scope", sc, "valid", auth.Scopes)
+				os.Exit(1)
+			}
+			granted = append(granted, sc)
+		}
+
+		token, err := auth.GenerateToken()
 		if err != nil {
-			slog.Error("failed to deduplicate reasoning patterns", "error", err)
+			slog.Error("failed to generate token", "error", err)
+			os.Exit(1)
+		}
+		if _, err := db.CreateAPIToken(ctx, *name, auth.HashToken(token), granted); err != nil {
+			slog.Error("failed to create token", "name", *name, "error", err)
 			os.Exit(1)
 		}
+		slog.Info("token created; it is shown once and cannot be recovered", "name", *name, "scopes", granted)
+		fmt.Println(token)
 
-		// Output result as JSON
-		output, err := json.MarshalIndent(result, "", "  ")
+	case "revoke":
+		if *name == "" {
+			fmt.Fprintln(os.Stderr, usage)
+			os.Exit(2)
+		}
+		if err := db.RevokeAPIToken(ctx, *name); err != nil {
+			if store.IsNotFound(err) {
+				slog.Error("no active token with that name", "name", *name)
+			} else {
+				slog.Error("failed to revoke token", "name", *name, "error", err)
+			}
+			os.Exit(1)
+		}
+		slog.Info("token revoked", "name", *name)
+
+	case "list":
+		tokens, err := db.ListAPITokens(ctx)
 		if err != nil {
-			slog.Error("failed to marshal result", "error", err)
+			slog.Error("failed to list tokens", "error", err)
+			os.Exit(1)
+		}
+		output, err := json.MarshalIndent(tokens, "", "  ")
+		if err != nil {
+			slog.Error("failed to marshal tokens", "error", err)
 			os.Exit(1)
 		}
 		fmt.Println(string(output))
+
+	default:
+		fmt.Fprintln(os.Stderr, usage)
+		os.Exit(2)
+	}
+}
+
+// runIndex lists the vector indexes dedup scans rely on, or builds one.
+func runIndex(args []string) {
+	usage := "usage: dredd index list [--table patterns|decisions|all] | create --method hnsw|ivfflat [--table ...] [--m N] [--ef-construction N] [--lists N] [--replace]"
+	if len(args) == 0 {
+		fmt.Fprintln(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	fs := flag.NewFlagSet("index "+args[0], flag.ExitOnError)
+	table := fs.String("table", "all", "Table to index: patterns, decisions, or all")
+	method := fs.String("method", dedup.IndexHNSW, "Index method: hnsw or ivfflat")
+	m := fs.Int("m", 0, "hnsw: connections per layer (pgvector default 16)")
+	efConstruction := fs.Int("ef-construction", 0, "hnsw: build candidate list size (pgvector default 64)")
+	lists := fs.Int("lists", 0, "ivfflat: number of lists (default sized from the row count)")
+	replace := fs.Bool("replace", false, "Rebuild an existing index and drop the column's other vector indexes")
+
+	if err := fs.Parse(args[1:]); err != nil {
+		fmt.Fprintf(os.Stderr, "parse flags: %v\n", err)
+		os.Exit(1)
+	}
+
+	setupLogging("info")
+
+	var tables []string
+	switch *table {
+	case "patterns":
+		tables = []string{"reasoning_patterns"}
+	case "decisions":
+		tables = []string{"decisions"}
+	case "all":
+		tables = dedup.Tables()
+	default:
+		slog.Error("table must be 'patterns', 'decisions', or 'all'", "table", *table)
+		os.Exit(1)
+	}
+
+	envCfg := config.Load()
+	if envCfg.DatabaseURL == "" {
+		slog.Error("DATABASE_URL is required")
+		os.Exit(1)
 	}
 
-	if *table == "decisions" || *table == "all" {
-		result, err := db.DeduplicateDecisions(ctx, *threshold, *execute, logger)
+	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer cancel()
+
+	db, err := store.New(ctx, envCfg.DatabaseURL)
+	if err != nil {
+		slog.Error("failed to connect to database", "error", err)
+		os.Exit(1)
+	}
+	defer db.Close()
+
+	var output any
+	switch args[0] {
+	case "list":
+		indexes := []dedup.VectorIndex{}
+		for _, t := range tables {
+			ix, err := db.VectorIndexes(ctx, t)
+			if err != nil {
+				slog.Error("failed to list indexes", "table", t, "error", err)
+				os.Exit(1)
+			}
+			indexes = append(indexes, ix...)
+		}
+		output = indexes
+
+	case "create":
+		opts := dedup.IndexOptions{Method: *method, M: *m, EFConstruction: *efConstruction, Lists: *lists, Replace: *replace}
+		var created []*dedup.VectorIndex
+		for _, t := range tables {
+			ix, err := db.CreateVectorIndex(ctx, t, opts, slog.Default())
+			if err != nil {
+				slog.Error("failed to create index", "table", t, "error", err)
+				os.Exit(1)
+			}
+			slog.Info("vector index ready", "table", t, "index", ix.Name, "options", ix.Options)
+			created = append(created, ix)
+		}
+		output = created
+
+	default:
+		fmt.Fprintln(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	data, err := json.MarshalIndent(output, "", "  ")
+	if err != nil {
+		slog.Error("failed to marshal indexes", "error", err)
+		os.Exit(1)
+	}
+	fmt.Println(string(data))
+}
+
+func runEvents(args []string) {
+	if len(args) == 0 || args[0] != "schema" {
+		fmt.Fprintln(os.Stderr, "usage: dredd events schema [--subject <subject>] [--direction publishes|consumes]")
+		os.Exit(2)
+	}
+
+	fs := flag.NewFlagSet("events schema", flag.ExitOnError)
+	subject := fs.String("subject", "", "Only print the schema for this subject")
+	direction := fs.String("direction", "", "Only print subjects dredd publishes or consumes")
+
+	if err := fs.Parse(args[1:]); err != nil {
+		fmt.Fprintf(os.Stderr, "parse flags: %v\n", err)
+		os.Exit(1)
+	}
+
+	var output any
+	if *subject != "" {
+		c, ok := events.Lookup(*subject)
+		if !ok {
+			fmt.Fprintf(os.Stderr, "unknown subject %q\n", *subject)
+			os.Exit(1)
+		}
+		output = c.Schema()
+	} else {
+		type entry struct {
+			events.Contract
+			Schema any `json:"schema"`
+		}
+		var entries []entry
+		for _, c := range events.Contracts() {
+			if *direction != "" && string(c.Direction) != *direction {
+				continue
+			}
+			entries = append(entries, entry{Contract: c, Schema: c.Schema()})
+		}
+		output = map[string]any{
+			"schema_version": events.SchemaVersion,
+			"subjects":       entries,
+		}
+	}
+
+	data, err := json.MarshalIndent(output, "", "  ")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "marshal schema: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Println(string(data))
+}
+
+// runExport writes a fine-tuning dataset as train.jsonl and validation.jsonl
+// in --out, from the database or a running server.
+func runExport(args []string) {
+	if len(args) == 0 || args[0] != "dataset" {
+		fmt.Fprintln(os.Stderr, "usage: dredd export dataset --out <dir> [--format chat|preference] [--validation 0.1] [--seed N]")
+		os.Exit(2)
+	}
+
+	fs := flag.NewFlagSet("export dataset", flag.ExitOnError)
+	out := fs.String("out", ".", "Directory for train.jsonl and validation.jsonl")
+	format := fs.String("format", dataset.FormatChat, "Dataset format: chat or preference")
+	validation := fs.Float64("validation", 0.1, "Fraction of examples in the validation split")
+	seed := fs.Uint64("seed", 1, "Shuffle seed; the same seed and data give the same split")
+	kind := fs.String("kind", "", "Only decisions or patterns (default both)")
+	owner := fs.String("owner", "", "Only this owner UUID")
+	since := fs.String("since", "", "Only items extracted on or after this date (YYYY-MM-DD)")
+	until := fs.String("until", "", "Only items extracted before this date (YYYY-MM-DD)")
+	server := fs.String("server", "", "Export through a running dredd server instead of the database")
+	token := fs.String("token", os.Getenv("DREDD_API_TOKEN"), "API token for --server")
+
+	if err := fs.Parse(args[1:]); err != nil {
+		fmt.Fprintf(os.Stderr, "parse flags: %v\n", err)
+		os.Exit(1)
+	}
+
+	setupLogging("info")
+
+	opts := dataset.Options{Format: *format, Kind: *kind, Validation: *validation, Seed: *seed}
+	if *owner != "" {
+		id, err := uuid.Parse(*owner)
+		if err != nil {
+			slog.Error("invalid owner UUID", "owner", *owner)
+			os.Exit(1)
+		}
+		opts.Owner = id
+	}
+	for _, p := range []struct {
+		value string
+		dst   **time.Time
+	}{{*since, &opts.Since}, {*until, &opts.Until}} {
+		if p.value == "" {
+			continue
+		}
+		t, err := time.Parse("2006-01-02", p.value)
 		if err != nil {
-			slog.Error("failed to deduplicate decisions", "error", err)
+			slog.Error("invalid date, expected YYYY-MM-DD", "date", p.value)
 			os.Exit(1)
 		}
+		*p.dst = &t
+	}
+	if err := opts.Validate(); err != nil {
+		slog.Error("invalid options", "error", err)
+		os.Exit(1)
+	}
 
-		// Output result as JSON
-		output, err := json.MarshalIndent(result, "", "  ")
+	if err := os.MkdirAll(*out, 0o755); err != nil {
+		slog.Error("failed to create output directory", "error", err)
+		os.Exit(1)
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer cancel()
+
+	write := func(split string, fill func(f *os.File) error) {
+		path := filepath.Join(*out, split+".jsonl")
+		f, err := os.Create(path)
 		if err != nil {
-			slog.Error("failed to marshal result", "error", err)
+			slog.Error("failed to create file", "path", path, "error", err)
 			os.Exit(1)
 		}
-		fmt.Println(string(output))
+		if err := fill(f); err != nil {
+			f.Close()
+			slog.Error("failed to write dataset", "path", path, "error", err)
+			os.Exit(1)
+		}
+		if err := f.Close(); err != nil {
+			slog.Error("failed to write dataset", "path", path, "error", err)
+			os.Exit(1)
+		}
+		slog.Info("wrote dataset split", "path", path)
 	}
 
-	slog.Info("dedup completed")
+	if *server != "" {
+		c := client.New(*server, *token)
+		query := url.Values{
+			"format":     {opts.Format},
+			"validation": {strconv.FormatFloat(opts.Validation, 'f', -1, 64)},
+			"seed":       {strconv.FormatUint(opts.Seed, 10)},
+		}
+		for k, v := range map[string]string{"kind": *kind, "owner": *owner, "since": *since, "until": *until} {
+			if v != "" {
+				query.Set(k, v)
+			}
+		}
+		for _, split := range []string{dataset.SplitTrain, dataset.SplitValidation} {
+			query.Set("split", split)
+			write(split, func(f *os.File) error { return c.Dataset(ctx, query, f) })
+		}
+		return
+	}
+
+	envCfg := config.Load()
+	if envCfg.DatabaseURL == "" {
+		slog.Error("DATABASE_URL is required")
+		os.Exit(1)
+	}
+	if envCfg.ChronicleURL == "" {
+		slog.Error("CHRONICLE_URL is required to fetch transcripts")
+		os.Exit(1)
+	}
+
+	db, err := store.New(ctx, envCfg.DatabaseURL)
+	if err != nil {
+		slog.Error("failed to connect to database", "error", err)
+		os.Exit(1)
+	}
+	defer db.Close()
+
+	ds, err := dataset.Build(ctx, db, chronicle.New(envCfg.ChronicleURL), opts)
+	if err != nil {
+		slog.Error("failed to build dataset", "error", err)
+		os.Exit(1)
+	}
+	write(dataset.SplitTrain, func(f *os.File) error { return dataset.WriteJSONL(f, ds.Train) })
+	write(dataset.SplitValidation, func(f *os.File) error { return dataset.WriteJSONL(f, ds.Validation) })
+	slog.Info("dataset exported", "format", opts.Format, "train", len(ds.Train), "validation", len(ds.Validation), "skipped_no_transcript", ds.Skipped)
 }
 
 func runBackfill(args []string) {
@@ -273,6 +799,13 @@ func runServe() {
 	llm := anthropic.NewClient(cfg.AnthropicAPIKey, cfg.AnthropicModel)
 	slog.Info("anthropic client ready", "model", cfg.AnthropicModel)
 
+	// Embeddings are optional; without them precedent callers supply vectors.
+	var embedder api.Embedder
+	if cfg.EmbeddingURL != "" {
+		embedder = embedding.NewClient(cfg.EmbeddingURL, cfg.EmbeddingAPIKey, cfg.EmbeddingModel)
+		slog.Info("embedding client ready", "model", cfg.EmbeddingModel)
+	}
+
 	// Extractor
 	ext := extractor.New(llm, slog.Default())
 
@@ -294,60 +827,147 @@ func runServe() {
 		slog.Warn("slack not configured — running without review loop")
 	}
 
+	// Autonomy modes — per category/severity, promoted on Dredd's own trust record
+	autonomyMgr := autonomy.NewManager(db, hermesClient, slog.Default())
+	if _, err := autonomyMgr.EvaluateAll(ctx); err != nil {
+		slog.Warn("initial autonomy evaluation failed", "error", err)
+	}
+
 	// Processor — the main pipeline
+	// In-process event bus feeding the live API stream.
+	eventBus := bus.New()
+
 	proc := processor.New(db, ext, hermesClient, slackPoster, cfg.ChronicleURL, slog.Default())
+	proc.SetAutonomyManager(autonomyMgr)
+	proc.SetEventBus(eventBus)
+	if embedder != nil {
+		proc.SetEmbedder(embedder, cfg.MatchThreshold)
+	} else if cfg.MatchThreshold > 0 {
+		slog.Warn("MATCH_THRESHOLD needs EMBEDDING_URL — recurring extractions will not be matched")
+	}
 
 	// Subscribe to transcript events
-	if err := hermesClient.Subscribe("swarm.chronicle.transcript.stored", proc.HandleTranscriptStored); err != nil {
+	if err := hermesClient.Subscribe(events.SubjectTranscriptStored, proc.HandleTranscriptStored); err != nil {
 		slog.Error("failed to subscribe to transcript events", "error", err)
 		os.Exit(1)
 	}
 
 	// Subscribe to Slack reactions for the review loop
-	if err := hermesClient.Subscribe("swarm.slack.reaction", proc.HandleReaction); err != nil {
+	if err := hermesClient.Subscribe(events.SubjectSlackReaction, proc.HandleReaction); err != nil {
 		slog.Error("failed to subscribe to slack reactions", "error", err)
 		os.Exit(1)
 	}
 
 	// Subscribe to Slack interactions for gate decisions
-	if err := hermesClient.Subscribe("swarm.slack.interaction", proc.HandleGateDecision); err != nil {
+	if err := hermesClient.Subscribe(events.SubjectSlackInteraction, proc.HandleGateDecision); err != nil {
 		slog.Error("failed to subscribe to gate decisions", "error", err)
 	}
 
-	// Subscribe to gate evidence for version attribution
-	if err := hermesClient.Subscribe("swarm.dispatch.*.gate.evidence", proc.HandleGateEvidence); err != nil {
+	// Subscribe to gate evidence for shadow predictions and version attribution
+	if err := hermesClient.Subscribe(events.SubjectGateEvidence, proc.HandleGateEvidence); err != nil {
 		slog.Error("failed to subscribe to gate evidence", "error", err)
 	}
 
 	// Subscribe to task picker decisions
-	if err := hermesClient.Subscribe("swarm.slack.task.picked", proc.HandleTaskPicked); err != nil {
+	if err := hermesClient.Subscribe(events.SubjectTaskPicked, proc.HandleTaskPicked); err != nil {
 		slog.Error("failed to subscribe to task picked", "error", err)
 	}
-	if err := hermesClient.Subscribe("swarm.slack.task.regenerated", proc.HandleTaskRegenerate); err != nil {
+	if err := hermesClient.Subscribe(events.SubjectTaskRegenerated, proc.HandleTaskRegenerate); err != nil {
 		slog.Error("failed to subscribe to task regenerated", "error", err)
 	}
 
-	// HTTP API
-	srv := api.NewServer(cfg.Port, cfg.APIToken, db)
+	// Background jobs; any whose lease expired with the process running them
+	// is marked failed, leaving other replicas' jobs alone.
+	if n, err := db.AbandonJobs(ctx); err != nil {
+		slog.Warn("failed to abandon stale jobs", "error", err)
+	} else if n > 0 {
+		slog.Warn("marked interrupted jobs as failed", "count", n)
+	}
+	jobMgr := jobs.NewManager(db, slog.Default())
+
+	// With a SOUL source, refinement proposals carry a drafted edit
+	var drafter *refinement.Drafter
+	if source := refinement.NewSOULSource(cfg.SOULSource); source != nil {
+		drafter = refinement.NewDrafter(llm, source, slog.Default())
+		slog.Info("SOUL drafting enabled", "source", cfg.SOULSource)
+	}
+	var mapping *refinement.Mapping
+	if cfg.SOULMapping != "" {
+		mapping, err = refinement.LoadMapping(cfg.SOULMapping)
+		if err != nil {
+			slog.Error("failed to load SOUL mapping", "path", cfg.SOULMapping, "error", err)
+			os.Exit(1)
+		}
+		slog.Info("SOUL mapping loaded", "path", cfg.SOULMapping, "default_soul", mapping.DefaultSOUL)
+	}
 
-	// Add refinement routes
-	api.AddRefinementRoutes(srv.Router(), cfg.APIToken, db, hermesClient)
+	// Fine-tuning dataset export fetches transcripts from Chronicle
+	var transcripts dataset.Transcripts
+	if cfg.ChronicleURL != "" {
+		transcripts = chronicle.New(cfg.ChronicleURL)
+	}
+
+	// Without a static token the API is open until the first named token exists.
+	if cfg.APIToken == "" {
+		if issued, err := db.HasAPITokens(ctx); err != nil {
+			slog.Warn("failed to check API tokens", "error", err)
+		} else if !issued {
+			slog.Warn("DREDD_API_TOKEN is empty and no named API tokens exist: the API accepts unauthenticated requests")
+		}
+	}
+
+	// HTTP API
+	srv := api.New(cfg.Port, cfg.APIToken, api.Deps{
+		Store: db,
+		ReadyChecks: map[string]api.ReadyCheck{
+			"database":   db.Ping,
+			"migrations": db.CheckSchema,
+			"nats":       func(context.Context) error { return hermesClient.Ready() },
+			"anthropic":  func(context.Context) error { return llm.Ready() },
+		},
+		Jobs:        jobMgr,
+		Hermes:      hermesClient,
+		Bus:         eventBus,
+		Drafter:     drafter,
+		Mapping:     mapping,
+		Autonomy:    autonomyMgr,
+		Processor:   proc,
+		Reviewer:    proc,
+		Embedder:    embedder,
+		Transcripts: transcripts,
+	})
 	go func() {
 		if err := srv.Start(); err != nil && err != http.ErrServerClosed {
 			slog.Error("HTTP server error", "error", err)
 		}
 	}()
 
-	// Announce registration
-	if err := hermesClient.Publish("swarm.agent.dredd.registered", map[string]any{
-		"timestamp": time.Now().UTC().Format(time.RFC3339),
-		"port":      cfg.Port,
-		"mode":      "shadow",
-	}); err != nil {
+	// Announce registration with the default mode and any per-category overrides.
+	modes, err := db.ListAutonomyStates(ctx)
+	if err != nil {
+		slog.Warn("failed to load autonomy modes", "error", err)
+	}
+	registered := events.Registered{
+		SchemaVersion: events.SchemaVersion,
+		Timestamp:     time.Now().UTC(),
+		Port:          cfg.Port,
+		Mode:          string(autonomy.DefaultMode),
+		Modes:         make([]events.AutonomyMode, 0, len(modes)),
+	}
+	for _, m := range modes {
+		registered.Modes = append(registered.Modes, events.AutonomyMode{
+			Category:  m.Category,
+			Severity:  m.Severity,
+			Mode:      m.Mode,
+			Pinned:    m.Pinned,
+			UpdatedAt: m.UpdatedAt,
+		})
+	}
+	if err := hermesClient.Publish(events.SubjectRegistered, registered); err != nil {
 		slog.Warn("failed to publish registration", "error", err)
 	}
 
-	slog.Info("dredd ready — shadow mode", "port", cfg.Port)
+	slog.Info("dredd ready", "port", cfg.Port, "default_mode", autonomy.DefaultMode, "category_modes", len(modes))
 
 	// Graceful shutdown
 	sigCh := make(chan os.Signal, 1)
@@ -362,6 +982,9 @@ func runServe() {
 	if err := srv.Shutdown(shutdownCtx); err != nil {
 		slog.Error("HTTP server shutdown error", "error", err)
 	}
+	if err := jobMgr.Shutdown(shutdownCtx); err != nil {
+		slog.Error("job shutdown error", "error", err)
+	}
 
 	slog.Info("dredd stopped")
 }
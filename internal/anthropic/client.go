@@ -4,11 +4,14 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"strings"
 	"time"
+
+	"github.com/MikeSquared-Agency/dredd/internal/metrics"
 )
 
 const defaultAPIURL = "https://api.anthropic.com/v1/messages"
@@ -29,6 +32,14 @@ func NewClient(apiKey, model string) *Client {
 	}
 }
 
+// Ready returns an error unless an API key is configured.
+func (c *Client) Ready() error {
+	if c.apiKey == "" {
+		return errors.New("no API key configured")
+	}
+	return nil
+}
+
 // SetTestTransport configures the client to point at a test server URL.
 func (c *Client) SetTestTransport(testURL string) {
 	c.apiURL = testURL
@@ -115,6 +126,8 @@ func (c *Client) Complete(ctx context.Context, system string, messages []Message
 	if err := json.Unmarshal(respBody, &apiResp); err != nil {
 		return "", fmt.Errorf("unmarshal response: %w", err)
 	}
+	metrics.LLMTokens.WithLabelValues(c.model, "input").Add(float64(apiResp.Usage.InputTokens))
+	metrics.LLMTokens.WithLabelValues(c.model, "output").Add(float64(apiResp.Usage.OutputTokens))
 
 	if len(apiResp.Content) == 0 {
 		return "", fmt.Errorf("empty response content")
@@ -6,6 +6,9 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/MikeSquared-Agency/dredd/internal/metrics"
 )
 
 func TestComplete_Success(t *testing.T) {
@@ -62,6 +65,32 @@ func TestComplete_Success(t *testing.T) {
 	}
 }
 
+func TestComplete_RecordsTokenUsage(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"content":[{"type":"text","text":"ok"}],"usage":{"input_tokens":120,"output_tokens":30}}`))
+	}))
+	defer server.Close()
+
+	c := NewClient("test-key", "usage-model")
+	c.SetTestTransport(server.URL)
+
+	input := metrics.LLMTokens.WithLabelValues("usage-model", "input")
+	output := metrics.LLMTokens.WithLabelValues("usage-model", "output")
+	beforeIn, beforeOut := testutil.ToFloat64(input), testutil.ToFloat64(output)
+
+	if _, err := c.Complete(context.Background(), "", []Message{{Role: "user", Content: "hi"}}, 10); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got := testutil.ToFloat64(input) - beforeIn; got != 120 {
+		t.Errorf("expected 120 input tokens, got %v", got)
+	}
+	if got := testutil.ToFloat64(output) - beforeOut; got != 30 {
+		t.Errorf("expected 30 output tokens, got %v", got)
+	}
+}
+
 func TestComplete_OAuthToken(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		// OAuth tokens must use Bearer auth, not x-api-key.
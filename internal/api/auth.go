@@ -0,0 +1,150 @@
+package api
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/MikeSquared-Agency/dredd/internal/auth"
+	"github.com/MikeSquared-Agency/dredd/internal/store"
+)
+
+// TokenStore resolves hashed API tokens.
+type TokenStore interface {
+	LookupAPIToken(ctx context.Context, tokenHash string) (*store.APIToken, error)
+	HasAPITokens(ctx context.Context) (bool, error)
+}
+
+type ctxKey int
+
+const (
+	principalKey ctxKey = iota
+	tokensIssuedKey
+)
+
+// PrincipalFrom returns the authenticated caller of a request, or nil.
+func PrincipalFrom(ctx context.Context) *auth.Principal {
+	p, _ := ctx.Value(principalKey).(*auth.Principal)
+	return p
+}
+
+func withPrincipal(r *http.Request, p *auth.Principal) *http.Request {
+	return r.WithContext(context.WithValue(r.Context(), principalKey, p))
+}
+
+func bearerToken(r *http.Request) string {
+	h := r.Header.Get("Authorization")
+	if !strings.HasPrefix(h, "Bearer ") {
+		return ""
+	}
+	return h[len("Bearer "):]
+}
+
+// staticPrincipal is the caller holding the static DREDD_API_TOKEN.
+func staticPrincipal() *auth.Principal {
+	return &auth.Principal{Name: "static", Scopes: []string{auth.ScopeAll}}
+}
+
+// tokensIssuedTTL is how long Authenticate reuses its answer to whether named
+// tokens exist, so anonymous requests do not each query the database.
+const tokensIssuedTTL = 30 * time.Second
+
+// issuedCache remembers whether named tokens exist for tokensIssuedTTL.
+type issuedCache struct {
+	tokens TokenStore
+
+	mu        sync.Mutex
+	issued    bool
+	checkedAt time.Time
+}
+
+func (c *issuedCache) get(ctx context.Context) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !c.checkedAt.IsZero() && time.Since(c.checkedAt) < tokensIssuedTTL {
+		return c.issued
+	}
+	issued, err := c.tokens.HasAPITokens(ctx)
+	if err != nil {
+		slog.Error("failed to check API tokens", "error", err)
+		return true // fail closed, and check again next time
+	}
+	c.issued, c.checkedAt = issued, time.Now()
+	return issued
+}
+
+// Authenticate resolves the caller of every request from its Bearer token,
+// which may be the static token or a named token from tokens (nil to disable
+// named tokens). It never rejects a request: BearerAuthMiddleware and
+// RequireScope decide what an unauthenticated or under-scoped caller may do.
+func Authenticate(staticToken string, tokens TokenStore) func(http.Handler) http.Handler {
+	var cache *issuedCache
+	if tokens != nil {
+		cache = &issuedCache{tokens: tokens}
+	}
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			token := bearerToken(r)
+
+			if token != "" && staticToken != "" && auth.Equal(token, staticToken) {
+				next.ServeHTTP(w, withPrincipal(r, staticPrincipal()))
+				return
+			}
+
+			if tokens != nil {
+				if token != "" {
+					t, err := tokens.LookupAPIToken(r.Context(), auth.HashToken(token))
+					if err == nil {
+						next.ServeHTTP(w, withPrincipal(r, &auth.Principal{Name: t.Name, Scopes: t.Scopes}))
+						return
+					}
+					if !store.IsNotFound(err) {
+						slog.Error("failed to look up API token", "error", err)
+					}
+				}
+
+				// Once named tokens exist, running without a static token no
+				// longer means the API is open. With a static token the
+				// caller is rejected either way.
+				if staticToken == "" {
+					r = r.WithContext(context.WithValue(r.Context(), tokensIssuedKey, cache.get(r.Context())))
+				}
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// apiOnly applies mw to requests for the authenticated API under /api/v1,
+// leaving health, readiness, metrics and the API contract untouched so they
+// keep answering without the database.
+func apiOnly(mw func(http.Handler) http.Handler) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		wrapped := mw(next)
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if strings.HasPrefix(r.URL.Path, "/api/v1/") && r.URL.Path != "/api/v1/openapi.json" {
+				wrapped.ServeHTTP(w, r)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// RequireScope rejects callers that lack scope with 403. Requests let through
+// by development mode carry no principal and are allowed.
+func RequireScope(scope string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if p := PrincipalFrom(r.Context()); p != nil && !p.Has(scope) {
+				http.Error(w, `{"error":"token lacks scope `+scope+`"}`, http.StatusForbidden)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,149 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/jackc/pgx/v5"
+	"github.com/MikeSquared-Agency/dredd/internal/auth"
+	"github.com/MikeSquared-Agency/dredd/internal/store"
+)
+
+type fakeTokenStore struct {
+	tokens  map[string]*store.APIToken // by hash
+	lookups int
+	checks  int
+}
+
+func (f *fakeTokenStore) LookupAPIToken(ctx context.Context, tokenHash string) (*store.APIToken, error) {
+	f.lookups++
+	t, ok := f.tokens[tokenHash]
+	if !ok {
+		return nil, pgx.ErrNoRows
+	}
+	return t, nil
+}
+
+func (f *fakeTokenStore) HasAPITokens(ctx context.Context) (bool, error) {
+	f.checks++
+	return len(f.tokens) > 0, nil
+}
+
+// scopedRouter serves dedup routes behind Authenticate with a dashboard token
+// holding only the read scope.
+func scopedRouter(staticToken string) *chi.Mux {
+	tokens := &fakeTokenStore{tokens: map[string]*store.APIToken{
+		auth.HashToken("dredd_dashboard"): {Name: "dashboard", Scopes: []string{auth.ScopeRead}},
+		auth.HashToken("dredd_ops"):       {Name: "ops", Scopes: []string{auth.ScopeDedupExecute}},
+	}}
+	router := chi.NewRouter()
+	router.Use(Authenticate(staticToken, tokens))
+	AddDedupRoutes(router, staticToken, nil, newFakeJobRunner())
+	AddJobRoutes(router, staticToken, newFakeJobRunner())
+	return router
+}
+
+func serve(router http.Handler, method, path, token, body string) int {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w.Code
+}
+
+func TestScopes_ReadOnlyTokenCannotExecuteDedup(t *testing.T) {
+	router := scopedRouter("static-secret")
+
+	if code := serve(router, "POST", "/api/v1/dedup", "dredd_dashboard", `{}`); code != http.StatusForbidden {
+		t.Errorf("expected 403 for read-only token, got %d", code)
+	}
+	if code := serve(router, "POST", "/api/v1/dedup", "dredd_ops", `{}`); code != http.StatusAccepted {
+		t.Errorf("expected 202 for dedup:execute token, got %d", code)
+	}
+	if code := serve(router, "POST", "/api/v1/dedup", "static-secret", `{}`); code != http.StatusAccepted {
+		t.Errorf("expected 202 for static token, got %d", code)
+	}
+}
+
+func TestScopes_ReadRoutes(t *testing.T) {
+	router := scopedRouter("static-secret")
+
+	// Unknown job, but the scope check passes.
+	if code := serve(router, "GET", "/api/v1/jobs/nope", "dredd_dashboard", ""); code != http.StatusBadRequest {
+		t.Errorf("expected read token to reach handler, got %d", code)
+	}
+	if code := serve(router, "GET", "/api/v1/jobs/nope", "dredd_ops", ""); code != http.StatusForbidden {
+		t.Errorf("expected 403 for token without read, got %d", code)
+	}
+}
+
+func TestScopes_UnknownOrMissingToken(t *testing.T) {
+	router := scopedRouter("static-secret")
+
+	if code := serve(router, "GET", "/api/v1/jobs/nope", "dredd_revoked", ""); code != http.StatusUnauthorized {
+		t.Errorf("expected 401 for unknown token, got %d", code)
+	}
+	if code := serve(router, "GET", "/api/v1/jobs/nope", "", ""); code != http.StatusUnauthorized {
+		t.Errorf("expected 401 without token, got %d", code)
+	}
+}
+
+func TestScopes_NamedTokensCloseDevMode(t *testing.T) {
+	// No static token, but named tokens exist: anonymous callers are rejected.
+	router := scopedRouter("")
+
+	if code := serve(router, "GET", "/api/v1/jobs/nope", "", ""); code != http.StatusUnauthorized {
+		t.Errorf("expected 401 without token, got %d", code)
+	}
+	if code := serve(router, "GET", "/api/v1/jobs/nope", "dredd_dashboard", ""); code != http.StatusBadRequest {
+		t.Errorf("expected named token to be accepted, got %d", code)
+	}
+}
+
+func TestScopes_DevModeOpen(t *testing.T) {
+	router := chi.NewRouter()
+	router.Use(Authenticate("", &fakeTokenStore{}))
+	AddDedupRoutes(router, "", nil, newFakeJobRunner())
+
+	if code := serve(router, "POST", "/api/v1/dedup", "", `{}`); code != http.StatusAccepted {
+		t.Errorf("expected 202 in dev mode, got %d", code)
+	}
+}
+
+func TestAuthenticate_CachesTokensIssued(t *testing.T) {
+	tokens := &fakeTokenStore{}
+	router := chi.NewRouter()
+	router.Use(Authenticate("", tokens))
+	AddDedupRoutes(router, "", nil, newFakeJobRunner())
+
+	for i := 0; i < 3; i++ {
+		serve(router, "POST", "/api/v1/dedup", "", `{}`)
+	}
+	if tokens.checks != 1 {
+		t.Errorf("expected one token check across requests, got %d", tokens.checks)
+	}
+}
+
+func TestAuthenticate_SkipsUnauthenticatedRoutes(t *testing.T) {
+	tokens := &fakeTokenStore{}
+	router := chi.NewRouter()
+	router.Use(apiOnly(Authenticate("", tokens)))
+	AddReadyRoute(router, nil)
+	router.Get("/health", func(w http.ResponseWriter, r *http.Request) {})
+	router.Get("/api/v1/openapi.json", func(w http.ResponseWriter, r *http.Request) {})
+
+	for _, path := range []string{"/health", "/ready", "/api/v1/openapi.json"} {
+		if code := serve(router, "GET", path, "dredd_unknown", ""); code != http.StatusOK {
+			t.Errorf("%s: expected 200, got %d", path, code)
+		}
+	}
+	if tokens.lookups != 0 || tokens.checks != 0 {
+		t.Errorf("expected no token queries, got %d lookups and %d checks", tokens.lookups, tokens.checks)
+	}
+}
@@ -0,0 +1,192 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/MikeSquared-Agency/dredd/internal/auth"
+	"github.com/MikeSquared-Agency/dredd/internal/autonomy"
+	"github.com/MikeSquared-Agency/dredd/internal/store"
+)
+
+// ModeResponse answers "may Dredd decide in this category/severity?"
+type ModeResponse struct {
+	Category        string          `json:"category"`
+	Severity        string          `json:"severity"`
+	Mode            string          `json:"mode"`
+	Pinned          bool            `json:"pinned"`
+	AllowedToDecide bool            `json:"allowed_to_decide"`
+	Policy          autonomy.Policy `json:"policy"`
+}
+
+// ModeListResponse is every stored autonomy mode, and the mode of any
+// category/severity without one.
+type ModeListResponse struct {
+	DefaultMode string         `json:"default_mode"`
+	Modes       []ModeResponse `json:"modes"`
+}
+
+// TransitionListResponse is the autonomy mode change log, newest first.
+type TransitionListResponse struct {
+	Transitions []store.AutonomyTransition `json:"transitions"`
+	Count       int                        `json:"count"`
+}
+
+// OverrideRequest manually sets the autonomy mode for a category/severity.
+type OverrideRequest struct {
+	Mode   string `json:"mode"`
+	Actor  string `json:"actor"`
+	Reason string `json:"reason"`
+	Pinned *bool  `json:"pinned,omitempty"` // defaults to true
+}
+
+// AddAutonomyRoutes adds autonomy mode endpoints to an existing router
+func AddAutonomyRoutes(router chi.Router, apiToken string, store *store.Store, manager *autonomy.Manager) {
+	router.Route("/api/v1/autonomy", func(r chi.Router) {
+		r.Use(BearerAuthMiddleware(apiToken))
+
+		handler := &autonomyHandler{
+			store:   store,
+			manager: manager,
+		}
+
+		r.With(RequireScope(auth.ScopeRead)).Get("/", handler.listModes)
+		r.With(RequireScope(auth.ScopeRead)).Get("/transitions", handler.listTransitions)
+		r.With(RequireScope(auth.ScopeAutonomyWrite)).Post("/evaluate", handler.evaluate)
+		r.With(RequireScope(auth.ScopeRead)).Get("/{category}/{severity}", handler.getMode)
+		r.With(RequireScope(auth.ScopeAutonomyWrite)).Put("/{category}/{severity}", handler.overrideMode)
+	})
+}
+
+// autonomyHandler holds dependencies for autonomy endpoints
+type autonomyHandler struct {
+	store   *store.Store
+	manager *autonomy.Manager
+}
+
+// listModes handles GET /api/v1/autonomy
+func (h *autonomyHandler) listModes(w http.ResponseWriter, r *http.Request) {
+	states, err := h.store.ListAutonomyStates(r.Context())
+	if err != nil {
+		http.Error(w, fmt.Sprintf(`{"error":"list modes failed: %v"}`, err), http.StatusInternalServerError)
+		return
+	}
+
+	modes := make([]ModeResponse, 0, len(states))
+	for _, st := range states {
+		modes = append(modes, ModeResponse{
+			Category:        st.Category,
+			Severity:        st.Severity,
+			Mode:            st.Mode,
+			Pinned:          st.Pinned,
+			AllowedToDecide: autonomy.Mode(st.Mode).AllowedToDecide(),
+			Policy:          h.manager.Policies().For(st.Severity),
+		})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(ModeListResponse{
+		DefaultMode: string(autonomy.DefaultMode),
+		Modes:       modes,
+	})
+}
+
+// getMode handles GET /api/v1/autonomy/{category}/{severity}
+func (h *autonomyHandler) getMode(w http.ResponseWriter, r *http.Request) {
+	category, severity := chi.URLParam(r, "category"), chi.URLParam(r, "severity")
+
+	st, err := h.manager.State(r.Context(), category, severity)
+	if err != nil {
+		http.Error(w, fmt.Sprintf(`{"error":"get mode failed: %v"}`, err), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(ModeResponse{
+		Category:        st.Category,
+		Severity:        st.Severity,
+		Mode:            st.Mode,
+		Pinned:          st.Pinned,
+		AllowedToDecide: autonomy.Mode(st.Mode).AllowedToDecide(),
+		Policy:          h.manager.Policies().For(severity),
+	})
+}
+
+// overrideMode handles PUT /api/v1/autonomy/{category}/{severity}
+func (h *autonomyHandler) overrideMode(w http.ResponseWriter, r *http.Request) {
+	var req OverrideRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, fmt.Sprintf(`{"error":"invalid JSON: %v"}`, err), http.StatusBadRequest)
+		return
+	}
+
+	mode, err := autonomy.ParseMode(req.Mode)
+	if err != nil {
+		http.Error(w, fmt.Sprintf(`{"error":%q}`, err.Error()), http.StatusBadRequest)
+		return
+	}
+
+	category, severity := chi.URLParam(r, "category"), chi.URLParam(r, "severity")
+	if err := autonomy.ValidateSeverity(severity); err != nil {
+		http.Error(w, fmt.Sprintf(`{"error":%q}`, err.Error()), http.StatusBadRequest)
+		return
+	}
+
+	pinned := true
+	if req.Pinned != nil {
+		pinned = *req.Pinned
+	}
+
+	transition, err := h.manager.Override(r.Context(), category, severity, mode, req.Actor, req.Reason, pinned)
+	if err != nil {
+		http.Error(w, fmt.Sprintf(`{"error":"override failed: %v"}`, err), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(transition)
+}
+
+// listTransitions handles GET /api/v1/autonomy/transitions
+func (h *autonomyHandler) listTransitions(w http.ResponseWriter, r *http.Request) {
+	limit := 50
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		n, err := strconv.Atoi(limitStr)
+		if err != nil || n <= 0 {
+			http.Error(w, `{"error":"limit must be a positive integer"}`, http.StatusBadRequest)
+			return
+		}
+		limit = n
+	}
+
+	transitions, err := h.store.ListAutonomyTransitions(r.Context(),
+		r.URL.Query().Get("category"), r.URL.Query().Get("severity"), limit)
+	if err != nil {
+		http.Error(w, fmt.Sprintf(`{"error":"list transitions failed: %v"}`, err), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(TransitionListResponse{
+		Transitions: transitions,
+		Count:       len(transitions),
+	})
+}
+
+// evaluate handles POST /api/v1/autonomy/evaluate
+func (h *autonomyHandler) evaluate(w http.ResponseWriter, r *http.Request) {
+	transitions, err := h.manager.EvaluateAll(r.Context())
+	if err != nil {
+		http.Error(w, fmt.Sprintf(`{"error":"evaluation failed: %v"}`, err), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(TransitionListResponse{
+		Transitions: transitions,
+		Count:       len(transitions),
+	})
+}
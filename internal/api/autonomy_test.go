@@ -0,0 +1,50 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestOverrideMode_InvalidMode(t *testing.T) {
+	srv := NewServer(8750, "", nil)
+	AddAutonomyRoutes(srv.Router(), "", nil, nil)
+
+	req := httptest.NewRequest("PUT", "/api/v1/autonomy/gate_approval/routine", strings.NewReader(`{"mode":"yolo"}`))
+	w := httptest.NewRecorder()
+	srv.router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected 400, got %d", w.Code)
+	}
+}
+
+func TestOverrideMode_InvalidSeverity(t *testing.T) {
+	srv := NewServer(8750, "", nil)
+	AddAutonomyRoutes(srv.Router(), "", nil, nil)
+
+	req := httptest.NewRequest("PUT", "/api/v1/autonomy/gate_approval/high", strings.NewReader(`{"mode":"advise"}`))
+	w := httptest.NewRecorder()
+	srv.router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected 400, got %d", w.Code)
+	}
+	if !strings.Contains(w.Body.String(), "invalid severity") {
+		t.Errorf("expected an invalid severity error, got %s", w.Body.String())
+	}
+}
+
+func TestAutonomyRoutes_Unauthorized(t *testing.T) {
+	srv := NewServer(8750, "test-token", nil)
+	AddAutonomyRoutes(srv.Router(), "test-token", nil, nil)
+
+	req := httptest.NewRequest("GET", "/api/v1/autonomy/gate_approval/routine", nil)
+	w := httptest.NewRecorder()
+	srv.router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("expected 401, got %d", w.Code)
+	}
+}
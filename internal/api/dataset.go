@@ -0,0 +1,105 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/MikeSquared-Agency/dredd/internal/auth"
+	"github.com/MikeSquared-Agency/dredd/internal/dataset"
+	"github.com/MikeSquared-Agency/dredd/internal/store"
+)
+
+// AddDatasetRoutes adds the fine-tuning dataset export to an existing router.
+// transcripts may be nil, in which case exports are unavailable.
+func AddDatasetRoutes(router chi.Router, apiToken string, store *store.Store, transcripts dataset.Transcripts) {
+	router.Route("/api/v1/dataset", func(r chi.Router) {
+		r.Use(BearerAuthMiddleware(apiToken))
+
+		handler := &datasetHandler{store: store, transcripts: transcripts}
+
+		r.With(RequireScope(auth.ScopeRead)).Get("/", handler.export)
+	})
+}
+
+// datasetHandler holds dependencies for the dataset endpoint
+type datasetHandler struct {
+	store       *store.Store
+	transcripts dataset.Transcripts
+}
+
+// export handles GET /api/v1/dataset?format=&split=&validation=&seed=
+//
+// The response is JSONL, one example per line. Both splits are computed on
+// every request, so fetching train and then validation with the same
+// parameters and seed gives disjoint sets that cover every example.
+func (h *datasetHandler) export(w http.ResponseWriter, r *http.Request) {
+	opts, split, err := parseDatasetQuery(r)
+	if err != nil {
+		http.Error(w, fmt.Sprintf(`{"error":%q}`, err.Error()), http.StatusBadRequest)
+		return
+	}
+
+	if h.transcripts == nil {
+		http.Error(w, `{"error":"dataset export needs CHRONICLE_URL to fetch transcripts"}`, http.StatusServiceUnavailable)
+		return
+	}
+
+	ds, err := dataset.Build(r.Context(), h.store, h.transcripts, opts)
+	if err != nil {
+		http.Error(w, fmt.Sprintf(`{"error":"failed to build dataset: %v"}`, err), http.StatusInternalServerError)
+		return
+	}
+	examples, _ := ds.Split(split)
+
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	dataset.WriteJSONL(w, examples)
+}
+
+// parseDatasetQuery reads the dataset options and split from the query.
+func parseDatasetQuery(r *http.Request) (dataset.Options, string, error) {
+	q := r.URL.Query()
+
+	opts := dataset.Options{
+		Format: q.Get("format"),
+		Kind:   q.Get("kind"),
+	}
+	if opts.Format == "" {
+		opts.Format = dataset.FormatChat
+	}
+
+	split := q.Get("split")
+	if split == "" {
+		split = dataset.SplitTrain
+	}
+	if split != dataset.SplitTrain && split != dataset.SplitValidation {
+		return opts, "", fmt.Errorf("split must be %q or %q", dataset.SplitTrain, dataset.SplitValidation)
+	}
+
+	var err error
+	if v := q.Get("validation"); v != "" {
+		if opts.Validation, err = strconv.ParseFloat(v, 64); err != nil {
+			return opts, "", fmt.Errorf("validation must be a number")
+		}
+	}
+	if v := q.Get("seed"); v != "" {
+		if opts.Seed, err = strconv.ParseUint(v, 10, 64); err != nil {
+			return opts, "", fmt.Errorf("seed must be a non-negative integer")
+		}
+	}
+	if v := q.Get("owner"); v != "" {
+		if opts.Owner, err = uuid.Parse(v); err != nil {
+			return opts, "", fmt.Errorf("owner must be a UUID")
+		}
+	}
+	if opts.Since, err = parseTimeParam(q.Get("since")); err != nil {
+		return opts, "", fmt.Errorf("since: %v", err)
+	}
+	if opts.Until, err = parseTimeParam(q.Get("until")); err != nil {
+		return opts, "", fmt.Errorf("until: %v", err)
+	}
+
+	return opts, split, opts.Validate()
+}
@@ -0,0 +1,57 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestDataset_BadQuery(t *testing.T) {
+	srv := NewServer(8750, "", nil)
+	AddDatasetRoutes(srv.Router(), "", nil, nil)
+
+	for _, query := range []string{
+		"format=csv",
+		"split=test",
+		"validation=1",
+		"validation=lots",
+		"seed=-1",
+		"kind=style",
+		"owner=not-a-uuid",
+		"since=yesterday",
+	} {
+		req := httptest.NewRequest("GET", "/api/v1/dataset?"+query, nil)
+		w := httptest.NewRecorder()
+		srv.router.ServeHTTP(w, req)
+
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("%s: expected 400, got %d", query, w.Code)
+		}
+	}
+}
+
+func TestDataset_RequiresAuth(t *testing.T) {
+	srv := NewServer(8750, "test-token", nil)
+	AddDatasetRoutes(srv.Router(), "test-token", nil, nil)
+
+	req := httptest.NewRequest("GET", "/api/v1/dataset", nil)
+	w := httptest.NewRecorder()
+	srv.router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("expected 401, got %d", w.Code)
+	}
+}
+
+func TestDataset_NeedsTranscripts(t *testing.T) {
+	srv := NewServer(8750, "", nil)
+	AddDatasetRoutes(srv.Router(), "", nil, nil)
+
+	req := httptest.NewRequest("GET", "/api/v1/dataset?format=chat", nil)
+	w := httptest.NewRecorder()
+	srv.router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusServiceUnavailable {
+		t.Errorf("expected 503, got %d", w.Code)
+	}
+}
@@ -0,0 +1,192 @@
+package api
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/MikeSquared-Agency/dredd/internal/auth"
+	"github.com/MikeSquared-Agency/dredd/internal/store"
+)
+
+// maxPageSize caps the limit on paginated list endpoints.
+const maxPageSize = 200
+
+// DecisionListResponse represents a page of decisions
+type DecisionListResponse struct {
+	Decisions  []store.DecisionDetail `json:"decisions"`
+	Count      int                    `json:"count"`
+	NextCursor string                 `json:"next_cursor,omitempty"`
+}
+
+// AddDecisionRoutes adds decision query endpoints to an existing router
+func AddDecisionRoutes(router chi.Router, apiToken string, store *store.Store) {
+	router.Route("/api/v1/decisions", func(r chi.Router) {
+		r.Use(BearerAuthMiddleware(apiToken))
+
+		handler := &decisionHandler{store: store}
+
+		r.With(RequireScope(auth.ScopeRead)).Get("/", handler.listDecisions)
+		r.With(RequireScope(auth.ScopeRead)).Get("/{id}", handler.getDecision)
+	})
+}
+
+// decisionHandler holds dependencies for decision endpoints
+type decisionHandler struct {
+	store *store.Store
+}
+
+// listDecisions handles GET /api/v1/decisions
+func (h *decisionHandler) listDecisions(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+
+	f := store.DecisionFilter{
+		Domain:         q.Get("domain"),
+		Category:       q.Get("category"),
+		Severity:       q.Get("severity"),
+		ReviewStatus:   q.Get("review_status"),
+		Source:         q.Get("source"),
+		ModelID:        q.Get("model_id"),
+		Tag:            q.Get("tag"),
+		IncludeDeduped: q.Get("include_deduped") == "true",
+	}
+
+	limit, err := parseLimit(q.Get("limit"), 50)
+	if err != nil {
+		http.Error(w, fmt.Sprintf(`{"error":%q}`, err.Error()), http.StatusBadRequest)
+		return
+	}
+	f.Limit = limit
+
+	if owner := q.Get("owner"); owner != "" {
+		id, err := uuid.Parse(owner)
+		if err != nil {
+			http.Error(w, `{"error":"owner must be a UUID"}`, http.StatusBadRequest)
+			return
+		}
+		f.Owner = id
+	}
+
+	if f.Since, err = parseTimeParam(q.Get("since")); err != nil {
+		http.Error(w, fmt.Sprintf(`{"error":"invalid since timestamp: %v"}`, err), http.StatusBadRequest)
+		return
+	}
+	if f.Until, err = parseTimeParam(q.Get("until")); err != nil {
+		http.Error(w, fmt.Sprintf(`{"error":"invalid until timestamp: %v"}`, err), http.StatusBadRequest)
+		return
+	}
+
+	if cursor := q.Get("cursor"); cursor != "" {
+		c, err := decodeCursor(cursor)
+		if err != nil {
+			http.Error(w, `{"error":"invalid cursor"}`, http.StatusBadRequest)
+			return
+		}
+		f.After = c
+	}
+
+	decisions, next, err := h.store.ListDecisions(r.Context(), f)
+	if err != nil {
+		http.Error(w, fmt.Sprintf(`{"error":"list decisions failed: %v"}`, err), http.StatusInternalServerError)
+		return
+	}
+	if decisions == nil {
+		decisions = []store.DecisionDetail{}
+	}
+
+	resp := DecisionListResponse{
+		Decisions: decisions,
+		Count:     len(decisions),
+	}
+	if next != nil {
+		resp.NextCursor = encodeCursor(*next)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// getDecision handles GET /api/v1/decisions/{id}
+func (h *decisionHandler) getDecision(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, `{"error":"id must be a UUID"}`, http.StatusBadRequest)
+		return
+	}
+
+	decision, err := h.store.GetDecisionDetail(r.Context(), id)
+	if store.IsNotFound(err) {
+		http.Error(w, `{"error":"decision not found"}`, http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, fmt.Sprintf(`{"error":"get decision failed: %v"}`, err), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(decision)
+}
+
+// parseLimit parses a page size, applying def when empty and capping at maxPageSize.
+func parseLimit(s string, def int) (int, error) {
+	if s == "" {
+		return def, nil
+	}
+	n, err := strconv.Atoi(s)
+	if err != nil || n <= 0 {
+		return 0, fmt.Errorf("limit must be a positive integer")
+	}
+	if n > maxPageSize {
+		n = maxPageSize
+	}
+	return n, nil
+}
+
+// parseTimeParam parses an optional RFC3339 timestamp or YYYY-MM-DD date.
+func parseTimeParam(s string) (*time.Time, error) {
+	if s == "" {
+		return nil, nil
+	}
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return &t, nil
+	}
+	t, err := time.Parse("2006-01-02", s)
+	if err != nil {
+		return nil, fmt.Errorf("expected RFC3339 or YYYY-MM-DD, got %q", s)
+	}
+	return &t, nil
+}
+
+// encodeCursor packs a keyset position into an opaque page token.
+func encodeCursor(c store.Cursor) string {
+	raw := c.CreatedAt.UTC().Format(time.RFC3339Nano) + "|" + c.ID.String()
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// decodeCursor reverses encodeCursor.
+func decodeCursor(s string) (*store.Cursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+	ts, idStr, ok := strings.Cut(string(raw), "|")
+	if !ok {
+		return nil, fmt.Errorf("malformed cursor")
+	}
+	createdAt, err := time.Parse(time.RFC3339Nano, ts)
+	if err != nil {
+		return nil, err
+	}
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		return nil, err
+	}
+	return &store.Cursor{CreatedAt: createdAt, ID: id}, nil
+}
@@ -0,0 +1,92 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/MikeSquared-Agency/dredd/internal/store"
+)
+
+func TestCursor_RoundTrip(t *testing.T) {
+	want := store.Cursor{
+		CreatedAt: time.Date(2026, 3, 1, 12, 30, 45, 123456000, time.UTC),
+		ID:        uuid.New(),
+	}
+
+	got, err := decodeCursor(encodeCursor(want))
+	if err != nil {
+		t.Fatalf("decode failed: %v", err)
+	}
+	if !got.CreatedAt.Equal(want.CreatedAt) || got.ID != want.ID {
+		t.Errorf("round-trip mismatch: got %+v, want %+v", *got, want)
+	}
+}
+
+func TestCursor_Invalid(t *testing.T) {
+	for _, c := range []string{"not-base64!", "bm8tc2VwYXJhdG9y", "MjAyNi0wMS0wMXxub3QtYS11dWlk"} {
+		if _, err := decodeCursor(c); err == nil {
+			t.Errorf("expected error decoding %q", c)
+		}
+	}
+}
+
+func TestParseLimit(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    int
+		wantErr bool
+	}{
+		{"", 50, false},
+		{"10", 10, false},
+		{"1000", maxPageSize, false},
+		{"0", 0, true},
+		{"abc", 0, true},
+	}
+
+	for _, tt := range tests {
+		got, err := parseLimit(tt.in, 50)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseLimit(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("parseLimit(%q) = %d, want %d", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestListDecisions_BadParams(t *testing.T) {
+	srv := NewServer(8750, "", nil)
+	AddDecisionRoutes(srv.Router(), "", nil)
+
+	for _, path := range []string{
+		"/api/v1/decisions?limit=-1",
+		"/api/v1/decisions?owner=mike",
+		"/api/v1/decisions?since=yesterday",
+		"/api/v1/decisions?cursor=garbage",
+	} {
+		req := httptest.NewRequest("GET", path, nil)
+		w := httptest.NewRecorder()
+		srv.router.ServeHTTP(w, req)
+
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("%s: expected 400, got %d", path, w.Code)
+		}
+	}
+}
+
+func TestGetDecision_InvalidID(t *testing.T) {
+	srv := NewServer(8750, "", nil)
+	AddDecisionRoutes(srv.Router(), "", nil)
+
+	req := httptest.NewRequest("GET", "/api/v1/decisions/not-a-uuid", nil)
+	w := httptest.NewRecorder()
+	srv.router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected 400, got %d", w.Code)
+	}
+}
@@ -0,0 +1,211 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/MikeSquared-Agency/dredd/internal/auth"
+	"github.com/MikeSquared-Agency/dredd/internal/dedup"
+	"github.com/MikeSquared-Agency/dredd/internal/jobs"
+	"github.com/MikeSquared-Agency/dredd/internal/store"
+)
+
+// JobKindDedup is the job kind of dedup runs.
+const JobKindDedup = "dedup"
+
+type DedupRequest struct {
+	Threshold   float64 `json:"threshold"`
+	Execute     bool    `json:"execute"`
+	Table       string  `json:"table"`
+	Neighbours  int     `json:"neighbours,omitempty"`  // nearest neighbours compared per record, 0 for the default 10, max 100
+	Incremental bool    `json:"incremental,omitempty"` // only records created since the table's last executed run
+}
+
+// maxDedupNeighbours caps DedupRequest.Neighbours.
+const maxDedupNeighbours = 100
+
+// DedupRestoreRequest names a deduped record (or survivor) whose most recent
+// merge should be reversed.
+type DedupRestoreRequest struct {
+	ID string `json:"id"`
+}
+
+// DedupRunListResponse represents a list of executed dedup runs
+type DedupRunListResponse struct {
+	Runs []dedup.Run `json:"runs"`
+}
+
+// AddDedupRoutes adds dedup submission, run history and restore routes to an
+// existing router. Dedup runs as a background job; poll /api/v1/jobs/{id} for
+// progress and results.
+func AddDedupRoutes(router chi.Router, apiToken string, store *store.Store, runner JobRunner) {
+	router.Route("/api/v1/dedup", func(r chi.Router) {
+		r.Use(BearerAuthMiddleware(apiToken))
+
+		handler := &dedupHandler{store: store, runner: runner}
+
+		r.With(RequireScope(auth.ScopeDedupExecute)).Post("/", handler.submit)
+		r.With(RequireScope(auth.ScopeDedupExecute)).Post("/restore", handler.restoreRecord)
+		r.With(RequireScope(auth.ScopeRead)).Get("/runs", handler.listRuns)
+		r.With(RequireScope(auth.ScopeRead)).Get("/runs/{id}", handler.getRun)
+		r.With(RequireScope(auth.ScopeDedupExecute)).Post("/runs/{id}/restore", handler.restoreRun)
+	})
+}
+
+// dedupHandler holds dependencies for the dedup handler
+type dedupHandler struct {
+	store  *store.Store
+	runner JobRunner
+}
+
+// submit handles POST /api/v1/dedup
+func (h *dedupHandler) submit(w http.ResponseWriter, r *http.Request) {
+	var req DedupRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, fmt.Sprintf(`{"error":"invalid JSON: %v"}`, err), http.StatusBadRequest)
+		return
+	}
+
+	// Set defaults
+	if req.Threshold == 0 {
+		req.Threshold = 0.92
+	}
+	if req.Table == "" {
+		req.Table = "all"
+	}
+
+	// Validate threshold
+	if req.Threshold < 0.0 || req.Threshold > 1.0 {
+		http.Error(w, `{"error":"threshold must be between 0.0 and 1.0"}`, http.StatusBadRequest)
+		return
+	}
+
+	// Validate table
+	if req.Table != "patterns" && req.Table != "decisions" && req.Table != "all" {
+		http.Error(w, `{"error":"table must be 'patterns', 'decisions', or 'all'"}`, http.StatusBadRequest)
+		return
+	}
+
+	if req.Neighbours < 0 || req.Neighbours > maxDedupNeighbours {
+		http.Error(w, fmt.Sprintf(`{"error":"neighbours must be between 1 and %d, or 0 for the default"}`, maxDedupNeighbours), http.StatusBadRequest)
+		return
+	}
+
+	job, err := h.runner.Submit(r.Context(), JobKindDedup, req, func(ctx context.Context, report jobs.ReportFunc) (any, error) {
+		opts := dedup.Options{Threshold: req.Threshold, Neighbours: req.Neighbours, Incremental: req.Incremental, Execute: req.Execute}
+		results, err := h.store.Deduplicate(ctx, req.Table, opts, slog.Default(), dedup.ProgressFunc(report))
+		if err != nil {
+			return nil, err
+		}
+		// A single table yields its result directly; "all" yields an array.
+		if len(results) == 1 {
+			return results[0], nil
+		}
+		return results, nil
+	})
+	if err != nil {
+		slog.Error("failed to submit dedup job", "error", err)
+		http.Error(w, fmt.Sprintf(`{"error":"failed to submit dedup job: %v"}`, err), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Location", "/api/v1/jobs/"+job.ID.String())
+	w.WriteHeader(http.StatusAccepted)
+	json.NewEncoder(w).Encode(job)
+}
+
+// listRuns handles GET /api/v1/dedup/runs
+func (h *dedupHandler) listRuns(w http.ResponseWriter, r *http.Request) {
+	limit, err := parseLimit(r.URL.Query().Get("limit"), 50)
+	if err != nil {
+		http.Error(w, fmt.Sprintf(`{"error":"%v"}`, err), http.StatusBadRequest)
+		return
+	}
+
+	runs, err := h.store.ListDedupRuns(r.Context(), limit)
+	if err != nil {
+		http.Error(w, fmt.Sprintf(`{"error":"failed to list dedup runs: %v"}`, err), http.StatusInternalServerError)
+		return
+	}
+	if runs == nil {
+		runs = []dedup.Run{}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(DedupRunListResponse{Runs: runs})
+}
+
+// getRun handles GET /api/v1/dedup/runs/{id}
+func (h *dedupHandler) getRun(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, `{"error":"invalid run ID"}`, http.StatusBadRequest)
+		return
+	}
+
+	run, err := h.store.GetDedupRun(r.Context(), id)
+	if err != nil {
+		if store.IsNotFound(err) {
+			http.Error(w, `{"error":"dedup run not found"}`, http.StatusNotFound)
+			return
+		}
+		http.Error(w, fmt.Sprintf(`{"error":"failed to get dedup run: %v"}`, err), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(run)
+}
+
+// restoreRun handles POST /api/v1/dedup/runs/{id}/restore
+func (h *dedupHandler) restoreRun(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, `{"error":"invalid run ID"}`, http.StatusBadRequest)
+		return
+	}
+
+	result, err := h.store.RestoreDedupRun(r.Context(), id, slog.Default())
+	writeRestoreResult(w, result, err, "dedup run not found")
+}
+
+// restoreRecord handles POST /api/v1/dedup/restore
+func (h *dedupHandler) restoreRecord(w http.ResponseWriter, r *http.Request) {
+	var req DedupRestoreRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, fmt.Sprintf(`{"error":"invalid JSON: %v"}`, err), http.StatusBadRequest)
+		return
+	}
+	id, err := uuid.Parse(req.ID)
+	if err != nil {
+		http.Error(w, `{"error":"id must be a UUID"}`, http.StatusBadRequest)
+		return
+	}
+
+	result, err := h.store.RestoreDedupRecord(r.Context(), id, slog.Default())
+	writeRestoreResult(w, result, err, "record is not part of any unrestored dedup cluster")
+}
+
+func writeRestoreResult(w http.ResponseWriter, result *dedup.RestoreResult, err error, notFound string) {
+	if err != nil {
+		switch {
+		case store.IsNotFound(err):
+			http.Error(w, fmt.Sprintf(`{"error":"%s"}`, notFound), http.StatusNotFound)
+		case errors.Is(err, dedup.ErrRestoreConflict):
+			http.Error(w, fmt.Sprintf(`{"error":"%v"}`, err), http.StatusConflict)
+		default:
+			http.Error(w, fmt.Sprintf(`{"error":"failed to restore: %v"}`, err), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(result)
+}
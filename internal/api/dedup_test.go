@@ -0,0 +1,108 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/MikeSquared-Agency/dredd/internal/jobs"
+)
+
+func TestDedup_SubmitsJob(t *testing.T) {
+	runner := newFakeJobRunner()
+	srv := NewServer(8750, "", nil)
+	AddDedupRoutes(srv.Router(), "", nil, runner)
+
+	req := httptest.NewRequest("POST", "/api/v1/dedup", strings.NewReader(`{"table":"patterns","execute":true}`))
+	w := httptest.NewRecorder()
+	srv.router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusAccepted {
+		t.Fatalf("expected 202, got %d: %s", w.Code, w.Body.String())
+	}
+	var job jobs.Job
+	if err := json.NewDecoder(w.Body).Decode(&job); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if loc := w.Header().Get("Location"); loc != "/api/v1/jobs/"+job.ID.String() {
+		t.Errorf("unexpected Location %q", loc)
+	}
+	if runner.kind != JobKindDedup {
+		t.Errorf("expected kind dedup, got %q", runner.kind)
+	}
+	params := runner.params.(DedupRequest)
+	if params.Threshold != 0.92 || params.Table != "patterns" || !params.Execute {
+		t.Errorf("unexpected params %+v", params)
+	}
+}
+
+func TestDedup_Validation(t *testing.T) {
+	for _, body := range []string{`{"threshold":1.5}`, `{"table":"styles"}`, `{"neighbours":-1}`, `{"neighbours":500}`, `not json`} {
+		srv := NewServer(8750, "", nil)
+		AddDedupRoutes(srv.Router(), "", nil, newFakeJobRunner())
+
+		req := httptest.NewRequest("POST", "/api/v1/dedup", strings.NewReader(body))
+		w := httptest.NewRecorder()
+		srv.router.ServeHTTP(w, req)
+
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("%s: expected 400, got %d", body, w.Code)
+		}
+	}
+}
+
+func TestDedupRestore_Validation(t *testing.T) {
+	tests := []struct {
+		name string
+		path string
+		body string
+	}{
+		{"bad run id", "/api/v1/dedup/runs/nope/restore", ""},
+		{"missing record id", "/api/v1/dedup/restore", `{}`},
+		{"bad record id", "/api/v1/dedup/restore", `{"id":"nope"}`},
+		{"bad json", "/api/v1/dedup/restore", `{`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := NewServer(8750, "", nil)
+			AddDedupRoutes(srv.Router(), "", nil, newFakeJobRunner())
+
+			req := httptest.NewRequest("POST", tt.path, strings.NewReader(tt.body))
+			w := httptest.NewRecorder()
+			srv.router.ServeHTTP(w, req)
+
+			if w.Code != http.StatusBadRequest {
+				t.Errorf("expected 400, got %d", w.Code)
+			}
+		})
+	}
+}
+
+func TestDedupRuns_InvalidID(t *testing.T) {
+	srv := NewServer(8750, "", nil)
+	AddDedupRoutes(srv.Router(), "", nil, newFakeJobRunner())
+
+	req := httptest.NewRequest("GET", "/api/v1/dedup/runs/nope", nil)
+	w := httptest.NewRecorder()
+	srv.router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected 400, got %d", w.Code)
+	}
+}
+
+func TestDedup_DefaultNeighbours(t *testing.T) {
+	srv := NewServer(8750, "", nil)
+	AddDedupRoutes(srv.Router(), "", nil, newFakeJobRunner())
+
+	req := httptest.NewRequest("POST", "/api/v1/dedup", strings.NewReader(`{"neighbours":0}`))
+	w := httptest.NewRecorder()
+	srv.router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusAccepted {
+		t.Errorf("expected 0 to mean the default and be accepted, got %d", w.Code)
+	}
+}
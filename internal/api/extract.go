@@ -0,0 +1,102 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/MikeSquared-Agency/dredd/internal/auth"
+	"github.com/MikeSquared-Agency/dredd/internal/extractor"
+	"github.com/MikeSquared-Agency/dredd/internal/processor"
+)
+
+// TranscriptProcessor runs the extraction pipeline on a single transcript.
+type TranscriptProcessor interface {
+	Process(ctx context.Context, evt extractor.TranscriptEvent, ownerUUID uuid.UUID, opts processor.ProcessOptions) (*processor.ProcessResult, error)
+}
+
+// ExtractRequest represents the request payload for ad-hoc extraction
+type ExtractRequest struct {
+	Transcript  string `json:"transcript,omitempty"` // raw transcript text
+	SessionID   string `json:"session_id,omitempty"` // Chronicle session to fetch instead
+	OwnerUUID   string `json:"owner_uuid"`
+	SessionRef  string `json:"session_ref"`
+	Title       string `json:"title,omitempty"`
+	Surface     string `json:"surface,omitempty"`
+	Duration    string `json:"duration,omitempty"`
+	ModelID     string `json:"model_id,omitempty"`
+	ModelTier   string `json:"model_tier,omitempty"`
+	Persist     bool   `json:"persist"`       // write extractions to the database
+	PostToSlack bool   `json:"post_to_slack"` // post a review thread; requires persist
+}
+
+// AddExtractRoutes adds the ad-hoc extraction endpoint to an existing router
+func AddExtractRoutes(router chi.Router, apiToken string, proc TranscriptProcessor) {
+	router.Route("/api/v1/extract", func(r chi.Router) {
+		r.Use(BearerAuthMiddleware(apiToken))
+
+		handler := &extractHandler{proc: proc}
+
+		r.With(RequireScope(auth.ScopeExtract)).Post("/", handler.extract)
+	})
+}
+
+// extractHandler holds dependencies for the extraction endpoint
+type extractHandler struct {
+	proc TranscriptProcessor
+}
+
+// extract handles POST /api/v1/extract
+func (h *extractHandler) extract(w http.ResponseWriter, r *http.Request) {
+	var req ExtractRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, fmt.Sprintf(`{"error":"invalid JSON: %v"}`, err), http.StatusBadRequest)
+		return
+	}
+
+	if strings.TrimSpace(req.Transcript) == "" && req.SessionID == "" {
+		http.Error(w, `{"error":"transcript or session_id is required"}`, http.StatusBadRequest)
+		return
+	}
+	if req.SessionRef == "" {
+		http.Error(w, `{"error":"session_ref is required"}`, http.StatusBadRequest)
+		return
+	}
+	ownerUUID, err := uuid.Parse(req.OwnerUUID)
+	if err != nil {
+		http.Error(w, `{"error":"owner_uuid must be a UUID"}`, http.StatusBadRequest)
+		return
+	}
+	if req.PostToSlack && !req.Persist {
+		http.Error(w, `{"error":"post_to_slack requires persist"}`, http.StatusBadRequest)
+		return
+	}
+
+	evt := extractor.TranscriptEvent{
+		SessionID:  req.SessionID,
+		OwnerUUID:  req.OwnerUUID,
+		SessionRef: req.SessionRef,
+		Title:      req.Title,
+		Duration:   req.Duration,
+		Surface:    req.Surface,
+		Transcript: req.Transcript,
+		ModelID:    req.ModelID,
+		ModelTier:  req.ModelTier,
+	}
+
+	res, err := h.proc.Process(r.Context(), evt, ownerUUID, processor.ProcessOptions{
+		Persist:     req.Persist,
+		PostToSlack: req.PostToSlack,
+	})
+	if err != nil {
+		http.Error(w, fmt.Sprintf(`{"error":"extraction failed: %v"}`, err), http.StatusBadGateway)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(res)
+}
@@ -0,0 +1,114 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/MikeSquared-Agency/dredd/internal/extractor"
+	"github.com/MikeSquared-Agency/dredd/internal/processor"
+)
+
+type fakeProcessor struct {
+	evt  extractor.TranscriptEvent
+	opts processor.ProcessOptions
+	err  error
+}
+
+func (f *fakeProcessor) Process(ctx context.Context, evt extractor.TranscriptEvent, ownerUUID uuid.UUID, opts processor.ProcessOptions) (*processor.ProcessResult, error) {
+	f.evt, f.opts = evt, opts
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &processor.ProcessResult{
+		Result: &extractor.ExtractionResult{
+			SessionRef: evt.SessionRef,
+			OwnerUUID:  ownerUUID,
+			Decisions:  []extractor.DecisionEpisode{{Domain: "infra", Summary: "Ship on Friday"}},
+		},
+	}, nil
+}
+
+const extractOwner = "9f6ed519-5763-4e30-9c2f-5580e0c57703"
+
+func TestExtract_DryRun(t *testing.T) {
+	proc := &fakeProcessor{}
+	srv := NewServer(8750, "", nil)
+	AddExtractRoutes(srv.Router(), "", proc)
+
+	body := `{"transcript":"Mike: ship it","owner_uuid":"` + extractOwner + `","session_ref":"test-1"}`
+	req := httptest.NewRequest("POST", "/api/v1/extract", strings.NewReader(body))
+	w := httptest.NewRecorder()
+	srv.router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if proc.opts.Persist || proc.opts.PostToSlack {
+		t.Errorf("expected dry run, got %+v", proc.opts)
+	}
+	if proc.evt.Transcript != "Mike: ship it" {
+		t.Errorf("expected transcript to be forwarded, got %q", proc.evt.Transcript)
+	}
+
+	var resp struct {
+		Result struct {
+			SessionRef string `json:"session_ref"`
+			Decisions  []struct {
+				Summary string `json:"summary"`
+			} `json:"decisions"`
+		} `json:"result"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if resp.Result.SessionRef != "test-1" || len(resp.Result.Decisions) != 1 {
+		t.Errorf("unexpected result: %+v", resp.Result)
+	}
+}
+
+func TestExtract_Validation(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+	}{
+		{"no transcript", `{"owner_uuid":"` + extractOwner + `","session_ref":"s"}`},
+		{"no session ref", `{"transcript":"x","owner_uuid":"` + extractOwner + `"}`},
+		{"bad owner", `{"transcript":"x","owner_uuid":"mike","session_ref":"s"}`},
+		{"slack without persist", `{"transcript":"x","owner_uuid":"` + extractOwner + `","session_ref":"s","post_to_slack":true}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := NewServer(8750, "", nil)
+			AddExtractRoutes(srv.Router(), "", &fakeProcessor{})
+
+			req := httptest.NewRequest("POST", "/api/v1/extract", strings.NewReader(tt.body))
+			w := httptest.NewRecorder()
+			srv.router.ServeHTTP(w, req)
+
+			if w.Code != http.StatusBadRequest {
+				t.Errorf("expected 400, got %d", w.Code)
+			}
+		})
+	}
+}
+
+func TestExtract_ProcessorError(t *testing.T) {
+	srv := NewServer(8750, "", nil)
+	AddExtractRoutes(srv.Router(), "", &fakeProcessor{err: errors.New("llm down")})
+
+	body := `{"session_id":"abc","owner_uuid":"` + extractOwner + `","session_ref":"s","persist":true}`
+	req := httptest.NewRequest("POST", "/api/v1/extract", strings.NewReader(body))
+	w := httptest.NewRecorder()
+	srv.router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusBadGateway {
+		t.Errorf("expected 502, got %d", w.Code)
+	}
+}
@@ -0,0 +1,94 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/MikeSquared-Agency/dredd/internal/auth"
+	"github.com/MikeSquared-Agency/dredd/internal/jobs"
+	"github.com/MikeSquared-Agency/dredd/internal/store"
+)
+
+// JobRunner submits and tracks background jobs.
+type JobRunner interface {
+	Submit(ctx context.Context, kind string, params any, fn jobs.Func) (*jobs.Job, error)
+	Get(ctx context.Context, id uuid.UUID) (*jobs.Job, error)
+	Cancel(ctx context.Context, id uuid.UUID) error
+}
+
+// AddJobRoutes adds background job status and cancellation routes to an existing router
+func AddJobRoutes(router chi.Router, apiToken string, runner JobRunner) {
+	router.Route("/api/v1/jobs", func(r chi.Router) {
+		r.Use(BearerAuthMiddleware(apiToken))
+
+		handler := &jobHandler{runner: runner}
+
+		r.With(RequireScope(auth.ScopeRead)).Get("/{id}", handler.get)
+		r.With(RequireScope(auth.ScopeDedupExecute)).Delete("/{id}", handler.cancel)
+	})
+}
+
+// jobHandler holds dependencies for job handlers
+type jobHandler struct {
+	runner JobRunner
+}
+
+// get handles GET /api/v1/jobs/{id}
+func (h *jobHandler) get(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, `{"error":"invalid job ID"}`, http.StatusBadRequest)
+		return
+	}
+
+	job, err := h.runner.Get(r.Context(), id)
+	if err != nil {
+		if store.IsNotFound(err) {
+			http.Error(w, `{"error":"job not found"}`, http.StatusNotFound)
+			return
+		}
+		http.Error(w, fmt.Sprintf(`{"error":"failed to get job: %v"}`, err), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(job)
+}
+
+// cancel handles DELETE /api/v1/jobs/{id}
+func (h *jobHandler) cancel(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, `{"error":"invalid job ID"}`, http.StatusBadRequest)
+		return
+	}
+
+	if err := h.runner.Cancel(r.Context(), id); err != nil {
+		if !errors.Is(err, jobs.ErrNotRunning) {
+			http.Error(w, fmt.Sprintf(`{"error":"failed to cancel job: %v"}`, err), http.StatusInternalServerError)
+			return
+		}
+		// Distinguish a finished job from an unknown one.
+		if _, gerr := h.runner.Get(r.Context(), id); store.IsNotFound(gerr) {
+			http.Error(w, `{"error":"job not found"}`, http.StatusNotFound)
+			return
+		}
+		http.Error(w, `{"error":"job is not running"}`, http.StatusConflict)
+		return
+	}
+
+	job, err := h.runner.Get(r.Context(), id)
+	if err != nil {
+		http.Error(w, fmt.Sprintf(`{"error":"failed to get job: %v"}`, err), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+	json.NewEncoder(w).Encode(job)
+}
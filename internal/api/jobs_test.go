@@ -0,0 +1,100 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/MikeSquared-Agency/dredd/internal/jobs"
+)
+
+type fakeJobRunner struct {
+	jobs    map[uuid.UUID]*jobs.Job
+	kind    string
+	params  any
+	running map[uuid.UUID]bool
+}
+
+func newFakeJobRunner() *fakeJobRunner {
+	return &fakeJobRunner{jobs: make(map[uuid.UUID]*jobs.Job), running: make(map[uuid.UUID]bool)}
+}
+
+func (f *fakeJobRunner) Submit(ctx context.Context, kind string, params any, fn jobs.Func) (*jobs.Job, error) {
+	f.kind, f.params = kind, params
+	job := &jobs.Job{ID: uuid.New(), Kind: kind, Status: jobs.StatusQueued, CreatedAt: time.Now()}
+	f.jobs[job.ID] = job
+	f.running[job.ID] = true
+	return job, nil
+}
+
+func (f *fakeJobRunner) Get(ctx context.Context, id uuid.UUID) (*jobs.Job, error) {
+	job, ok := f.jobs[id]
+	if !ok {
+		return nil, pgx.ErrNoRows
+	}
+	return job, nil
+}
+
+func (f *fakeJobRunner) Cancel(ctx context.Context, id uuid.UUID) error {
+	if !f.running[id] {
+		return jobs.ErrNotRunning
+	}
+	f.running[id] = false
+	f.jobs[id].Status = jobs.StatusCancelled
+	return nil
+}
+
+func TestJobs_GetAndCancel(t *testing.T) {
+	runner := newFakeJobRunner()
+	job, _ := runner.Submit(context.Background(), JobKindDedup, nil, nil)
+
+	srv := NewServer(8750, "", nil)
+	AddJobRoutes(srv.Router(), "", runner)
+
+	req := httptest.NewRequest("GET", "/api/v1/jobs/"+job.ID.String(), nil)
+	w := httptest.NewRecorder()
+	srv.router.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+
+	req = httptest.NewRequest("DELETE", "/api/v1/jobs/"+job.ID.String(), nil)
+	w = httptest.NewRecorder()
+	srv.router.ServeHTTP(w, req)
+	if w.Code != http.StatusAccepted {
+		t.Fatalf("expected 202, got %d", w.Code)
+	}
+
+	// A second cancel finds the job already finished.
+	req = httptest.NewRequest("DELETE", "/api/v1/jobs/"+job.ID.String(), nil)
+	w = httptest.NewRecorder()
+	srv.router.ServeHTTP(w, req)
+	if w.Code != http.StatusConflict {
+		t.Errorf("expected 409, got %d", w.Code)
+	}
+}
+
+func TestJobs_NotFound(t *testing.T) {
+	srv := NewServer(8750, "", nil)
+	AddJobRoutes(srv.Router(), "", newFakeJobRunner())
+
+	for _, method := range []string{"GET", "DELETE"} {
+		req := httptest.NewRequest(method, "/api/v1/jobs/"+uuid.New().String(), nil)
+		w := httptest.NewRecorder()
+		srv.router.ServeHTTP(w, req)
+		if w.Code != http.StatusNotFound {
+			t.Errorf("%s: expected 404, got %d", method, w.Code)
+		}
+	}
+
+	req := httptest.NewRequest("GET", "/api/v1/jobs/nope", nil)
+	w := httptest.NewRecorder()
+	srv.router.ServeHTTP(w, req)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 for invalid ID, got %d", w.Code)
+	}
+}
@@ -0,0 +1,240 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/MikeSquared-Agency/dredd/internal/auth"
+	"github.com/MikeSquared-Agency/dredd/internal/bus"
+	"github.com/MikeSquared-Agency/dredd/internal/dataset"
+	"github.com/MikeSquared-Agency/dredd/internal/dedup"
+	"github.com/MikeSquared-Agency/dredd/internal/jobs"
+	"github.com/MikeSquared-Agency/dredd/internal/jsonschema"
+	"github.com/MikeSquared-Agency/dredd/internal/processor"
+	"github.com/MikeSquared-Agency/dredd/internal/store"
+)
+
+// APIVersion is the version of the HTTP contract published in the OpenAPI
+// document. Bump it when a route or payload changes incompatibly.
+const APIVersion = "1.0.0"
+
+// Param is a query parameter of an Operation.
+type Param struct {
+	Name        string
+	Type        string // string | integer | number | boolean
+	Description string
+}
+
+// Operation documents one API route. TestOpenAPI_MatchesRoutes keeps this
+// table in step with the routes registered on the router.
+type Operation struct {
+	ID       string
+	Method   string
+	Path     string // path template, e.g. /api/v1/decisions/{id}
+	Summary  string
+	Scope    string // required token scope; empty for unauthenticated routes
+	Query    []Param
+	Request  any // request body type, nil for none
+	Response any    // success response body type
+	Status   int    // success status; 0 means 200
+	Content  string // success media type; empty means application/json
+}
+
+var (
+	limitParam  = Param{"limit", "integer", "maximum number of results"}
+	cursorParam = Param{"cursor", "string", "next_cursor from the previous page"}
+	sinceParam  = Param{"since", "string", "RFC3339 timestamp or YYYY-MM-DD, inclusive"}
+	untilParam  = Param{"until", "string", "RFC3339 timestamp or YYYY-MM-DD, exclusive"}
+)
+
+// Operations is every documented route.
+var Operations = []Operation{
+	{ID: "health", Method: "GET", Path: "/health", Summary: "Liveness check", Response: HealthResponse{}},
+	{ID: "ready", Method: "GET", Path: "/ready", Summary: "Readiness of each dependency; 503 with the same body when any check fails", Response: ReadyResponse{}},
+	{ID: "openapi", Method: "GET", Path: "/api/v1/openapi.json", Summary: "This OpenAPI document", Response: map[string]any{}},
+	{ID: "status", Method: "GET", Path: "/api/v1/dredd/status", Summary: "Agent status and default autonomy mode", Scope: auth.ScopeRead, Response: StatusResponse{}},
+
+	{ID: "submitDedup", Method: "POST", Path: "/api/v1/dedup", Summary: "Start a dedup job", Scope: auth.ScopeDedupExecute, Request: DedupRequest{}, Response: jobs.Job{}, Status: http.StatusAccepted},
+	{ID: "restoreDedupRecord", Method: "POST", Path: "/api/v1/dedup/restore", Summary: "Reverse the most recent dedup merge involving a record", Scope: auth.ScopeDedupExecute, Request: DedupRestoreRequest{}, Response: dedup.RestoreResult{}},
+	{ID: "listDedupRuns", Method: "GET", Path: "/api/v1/dedup/runs", Summary: "List executed dedup runs", Scope: auth.ScopeRead, Query: []Param{limitParam}, Response: DedupRunListResponse{}},
+	{ID: "getDedupRun", Method: "GET", Path: "/api/v1/dedup/runs/{id}", Summary: "Get a dedup run with its clusters", Scope: auth.ScopeRead, Response: dedup.Run{}},
+	{ID: "restoreDedupRun", Method: "POST", Path: "/api/v1/dedup/runs/{id}/restore", Summary: "Reverse every merge of a dedup run", Scope: auth.ScopeDedupExecute, Response: dedup.RestoreResult{}},
+	{ID: "getJob", Method: "GET", Path: "/api/v1/jobs/{id}", Summary: "Get a background job's status, progress and result", Scope: auth.ScopeRead, Response: jobs.Job{}},
+	{ID: "cancelJob", Method: "DELETE", Path: "/api/v1/jobs/{id}", Summary: "Cancel a running job", Scope: auth.ScopeDedupExecute, Response: jobs.Job{}, Status: http.StatusAccepted},
+
+	{ID: "stream", Method: "GET", Path: "/api/v1/stream", Summary: "Server-sent events of pipeline activity; each message's data is an Event", Scope: auth.ScopeRead, Query: []Param{
+		{"owner", "string", "only events for this owner UUID"}, {"kind", "string", "comma-separated event kinds: " + strings.Join(bus.Kinds, ", ")},
+	}, Response: bus.Event{}, Content: "text/event-stream"},
+	{ID: "exportDataset", Method: "GET", Path: "/api/v1/dataset", Summary: "Export reviewed extractions as a JSONL fine-tuning dataset, one Example per line", Scope: auth.ScopeRead, Query: []Param{
+		{"format", "string", "chat (default) or preference"}, {"split", "string", "train (default) or validation"},
+		{"validation", "number", "fraction of examples in the validation split"}, {"seed", "integer", "shuffle seed"},
+		{"kind", "string", "decision or pattern; both when empty"}, {"owner", "string", "owner UUID"}, sinceParam, untilParam,
+	}, Response: dataset.Example{}, Content: "application/x-ndjson"},
+
+	{ID: "scanRefinements", Method: "POST", Path: "/api/v1/refinements/scan", Summary: "Find pattern clusters and publish refinement proposals", Scope: auth.ScopeRefinementPublish, Request: ScanRequest{}, Response: ScanResponse{}},
+	{ID: "scanRefinementsDryRun", Method: "GET", Path: "/api/v1/refinements/scan", Summary: "Find pattern clusters without publishing", Scope: auth.ScopeRead, Query: []Param{
+		{"since", "string", "RFC3339 timestamp"}, {"soul_slug", "string", "target SOUL, default from the SOUL mapping"}, {"threshold", "number", "similarity threshold"},
+		{"min_size", "integer", "fewest patterns per cluster, default 2"},
+	}, Response: ScanResponse{}},
+	{ID: "listProposals", Method: "GET", Path: "/api/v1/refinements/proposals", Summary: "List refinement proposals, newest first", Scope: auth.ScopeRead, Query: []Param{
+		{"status", "string", "comma-separated statuses: proposed, accepted, rejected, applied"}, limitParam,
+	}, Response: ProposalListResponse{}},
+	{ID: "getProposal", Method: "GET", Path: "/api/v1/refinements/proposals/{id}", Summary: "Get a refinement proposal", Scope: auth.ScopeRead, Response: store.RefinementProposal{}},
+	{ID: "acceptProposal", Method: "POST", Path: "/api/v1/refinements/proposals/{id}/accept", Summary: "Accept a proposed refinement", Scope: auth.ScopeReview, Request: ProposalReviewRequest{}, Response: store.RefinementProposal{}},
+	{ID: "rejectProposal", Method: "POST", Path: "/api/v1/refinements/proposals/{id}/reject", Summary: "Reject a proposed or accepted refinement; matching clusters are no longer proposed", Scope: auth.ScopeReview, Request: ProposalReviewRequest{}, Response: store.RefinementProposal{}},
+	{ID: "applyProposal", Method: "POST", Path: "/api/v1/refinements/proposals/{id}/apply", Summary: "Mark an accepted refinement as applied to its SOUL", Scope: auth.ScopeReview, Request: ProposalReviewRequest{}, Response: store.RefinementProposal{}},
+
+	{ID: "listModes", Method: "GET", Path: "/api/v1/autonomy", Summary: "List stored autonomy modes", Scope: auth.ScopeRead, Response: ModeListResponse{}},
+	{ID: "listTransitions", Method: "GET", Path: "/api/v1/autonomy/transitions", Summary: "List autonomy mode transitions", Scope: auth.ScopeRead, Query: []Param{
+		{"category", "string", ""}, {"severity", "string", ""}, limitParam,
+	}, Response: TransitionListResponse{}},
+	{ID: "evaluateAutonomy", Method: "POST", Path: "/api/v1/autonomy/evaluate", Summary: "Re-evaluate every autonomy mode against trust", Scope: auth.ScopeAutonomyWrite, Response: TransitionListResponse{}},
+	{ID: "getMode", Method: "GET", Path: "/api/v1/autonomy/{category}/{severity}", Summary: "Get the autonomy mode of a category and severity", Scope: auth.ScopeRead, Response: ModeResponse{}},
+	{ID: "overrideMode", Method: "PUT", Path: "/api/v1/autonomy/{category}/{severity}", Summary: "Override an autonomy mode", Scope: auth.ScopeAutonomyWrite, Request: OverrideRequest{}, Response: store.AutonomyTransition{}},
+
+	{ID: "shadowAgreement", Method: "GET", Path: "/api/v1/shadow/agreement", Summary: "Shadow-mode agreement with gate outcomes", Scope: auth.ScopeRead, Query: []Param{
+		{"since", "string", "RFC3339 timestamp"},
+	}, Response: AgreementResponse{}},
+	{ID: "listPredictions", Method: "GET", Path: "/api/v1/shadow/predictions", Summary: "List shadow-mode gate predictions", Scope: auth.ScopeRead, Query: []Param{
+		{"item_id", "string", ""}, {"stage", "string", ""}, {"resolved", "boolean", "only resolved predictions"}, limitParam,
+	}, Response: PredictionListResponse{}},
+
+	{ID: "listDecisions", Method: "GET", Path: "/api/v1/decisions", Summary: "List decisions, newest first", Scope: auth.ScopeRead, Query: []Param{
+		{"domain", "string", ""}, {"category", "string", ""}, {"severity", "string", ""}, {"review_status", "string", ""},
+		{"source", "string", ""}, {"model_id", "string", ""}, {"tag", "string", ""}, {"owner", "string", "owner UUID"},
+		sinceParam, untilParam, {"include_deduped", "boolean", ""}, limitParam, cursorParam,
+	}, Response: DecisionListResponse{}},
+	{ID: "getDecision", Method: "GET", Path: "/api/v1/decisions/{id}", Summary: "Get a decision with its context, options, reasoning and outcomes", Scope: auth.ScopeRead, Response: store.DecisionDetail{}},
+
+	{ID: "extract", Method: "POST", Path: "/api/v1/extract", Summary: "Run extraction on a transcript", Scope: auth.ScopeExtract, Request: ExtractRequest{}, Response: processor.ProcessResult{}},
+
+	{ID: "trustLeaderboard", Method: "GET", Path: "/api/v1/trust", Summary: "Trust records ranked by current score", Scope: auth.ScopeRead, Query: []Param{
+		{"category", "string", ""}, {"severity", "string", ""}, {"min_decisions", "integer", ""}, limitParam,
+	}, Response: TrustLeaderboardResponse{}},
+	{ID: "getAgentTrust", Method: "GET", Path: "/api/v1/trust/{agent_id}", Summary: "Every trust record of an agent", Scope: auth.ScopeRead, Response: AgentTrustResponse{}},
+	{ID: "trustHistory", Method: "GET", Path: "/api/v1/trust/{agent_id}/history", Summary: "Trust score changes of an agent, newest first", Scope: auth.ScopeRead, Query: []Param{
+		{"category", "string", ""}, {"severity", "string", ""}, sinceParam, limitParam,
+	}, Response: TrustHistoryResponse{}},
+
+	{ID: "submitReview", Method: "POST", Path: "/api/v1/reviews", Summary: "Confirm, reject or skip a decision or pattern", Scope: auth.ScopeReview, Request: ReviewRequest{}, Response: ReviewResponse{}},
+	{ID: "listReviews", Method: "GET", Path: "/api/v1/reviews", Summary: "Review history of a decision or pattern", Scope: auth.ScopeRead, Query: []Param{
+		{"kind", "string", "decision | pattern"}, {"id", "string", "decision or pattern UUID"},
+	}, Response: ReviewListResponse{}},
+
+	{ID: "listPatterns", Method: "GET", Path: "/api/v1/patterns", Summary: "List reasoning patterns, newest first", Scope: auth.ScopeRead, Query: []Param{
+		{"pattern_type", "string", ""}, {"review_status", "string", ""}, {"tag", "string", ""}, {"owner", "string", "owner UUID"},
+		{"min_confidence", "number", ""}, {"max_confidence", "number", ""}, sinceParam, untilParam,
+		{"include_deduped", "boolean", ""}, limitParam, cursorParam,
+	}, Response: PatternListResponse{}},
+	{ID: "searchPatterns", Method: "POST", Path: "/api/v1/patterns/search", Summary: "Semantic search over reasoning patterns", Scope: auth.ScopeRead, Request: PatternSearchRequest{}, Response: PatternSearchResponse{}},
+	{ID: "getPattern", Method: "GET", Path: "/api/v1/patterns/{id}", Summary: "Get a reasoning pattern", Scope: auth.ScopeRead, Response: store.PatternRow{}},
+
+	{ID: "searchPrecedents", Method: "POST", Path: "/api/v1/precedents", Summary: "Find confirmed decisions similar to a situation", Scope: auth.ScopeRead, Request: PrecedentRequest{}, Response: PrecedentResponse{}},
+}
+
+var pathParamRe = regexp.MustCompile(`\{([a-z_]+)\}`)
+
+// OpenAPI returns the OpenAPI 3.1 document describing Operations. Request and
+// response bodies are derived from the Go types the handlers encode.
+func OpenAPI() map[string]any {
+	schemas := map[string]any{
+		"ErrorResponse": jsonschema.For(ErrorResponse{}),
+	}
+	ref := func(v any) map[string]any {
+		name := reflect.TypeOf(v).Name()
+		if name == "" {
+			return map[string]any{"type": "object"}
+		}
+		schemas[name] = jsonschema.For(v)
+		return map[string]any{"$ref": "#/components/schemas/" + name}
+	}
+	errorBody := map[string]any{
+		"description": "error",
+		"content":     map[string]any{"application/json": map[string]any{"schema": ref(ErrorResponse{})}},
+	}
+
+	paths := map[string]map[string]any{}
+	for _, op := range Operations {
+		var params []map[string]any
+		for _, m := range pathParamRe.FindAllStringSubmatch(op.Path, -1) {
+			params = append(params, map[string]any{
+				"name": m[1], "in": "path", "required": true, "schema": map[string]any{"type": "string"},
+			})
+		}
+		for _, q := range op.Query {
+			p := map[string]any{"name": q.Name, "in": "query", "schema": map[string]any{"type": q.Type}}
+			if q.Description != "" {
+				p["description"] = q.Description
+			}
+			params = append(params, p)
+		}
+
+		status := op.Status
+		if status == 0 {
+			status = http.StatusOK
+		}
+		content := op.Content
+		if content == "" {
+			content = "application/json"
+		}
+		o := map[string]any{
+			"operationId": op.ID,
+			"summary":     op.Summary,
+			"responses": map[string]any{
+				strconv.Itoa(status): map[string]any{
+					"description": http.StatusText(status),
+					"content":     map[string]any{content: map[string]any{"schema": ref(op.Response)}},
+				},
+				"default": errorBody,
+			},
+		}
+		if len(params) > 0 {
+			o["parameters"] = params
+		}
+		if op.Request != nil {
+			o["requestBody"] = map[string]any{
+				"required": true,
+				"content":  map[string]any{"application/json": map[string]any{"schema": ref(op.Request)}},
+			}
+		}
+		if op.Scope == "" {
+			o["security"] = []any{}
+		} else {
+			o["security"] = []any{map[string]any{"bearerAuth": []string{op.Scope}}}
+			o["x-required-scope"] = op.Scope
+		}
+
+		if paths[op.Path] == nil {
+			paths[op.Path] = map[string]any{}
+		}
+		paths[op.Path][strings.ToLower(op.Method)] = o
+	}
+
+	return map[string]any{
+		"openapi":           "3.1.0",
+		"jsonSchemaDialect": jsonschema.Draft,
+		"info": map[string]any{
+			"title":       "dredd",
+			"version":     APIVersion,
+			"description": "Decision and reasoning-pattern extraction, review, trust and autonomy API. Bearer tokens carry scopes: " + strings.Join(auth.Scopes, ", ") + ".",
+		},
+		"paths": paths,
+		"components": map[string]any{
+			"schemas": schemas,
+			"securitySchemes": map[string]any{
+				"bearerAuth": map[string]any{"type": "http", "scheme": "bearer"},
+			},
+		},
+		"security": []any{map[string]any{"bearerAuth": []string{}}},
+	}
+}
+
+// openapi handles GET /api/v1/openapi.json
+func (s *Server) openapi(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(OpenAPI())
+}
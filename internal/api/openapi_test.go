@@ -0,0 +1,111 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/MikeSquared-Agency/dredd/internal/bus"
+)
+
+// fullServer builds the server the way main does, with fakes where a route
+// group needs a dependency to register.
+func fullServer() *Server {
+	return New(8750, "test-token", Deps{
+		Jobs:      newFakeJobRunner(),
+		Bus:       bus.New(),
+		Processor: &fakeProcessor{},
+		Reviewer:  &fakeReviewer{},
+	})
+}
+
+func TestOpenAPI_MatchesRoutes(t *testing.T) {
+	srv := fullServer()
+
+	registered := map[string]bool{}
+	err := chi.Walk(srv.Router(), func(method, route string, _ http.Handler, _ ...func(http.Handler) http.Handler) error {
+		if route != "/" {
+			route = strings.TrimSuffix(route, "/")
+		}
+		registered[method+" "+route] = true
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("walk routes: %v", err)
+	}
+
+	documented := map[string]bool{}
+	for _, op := range Operations {
+		key := op.Method + " " + op.Path
+		if documented[key] {
+			t.Errorf("%s documented twice", key)
+		}
+		documented[key] = true
+		if !registered[key] {
+			t.Errorf("%s is documented but not routed", key)
+		}
+	}
+
+	var undocumented []string
+	for key := range registered {
+		if key == "GET /metrics" {
+			continue // Prometheus exposition, not part of the JSON API
+		}
+		if !documented[key] {
+			undocumented = append(undocumented, key)
+		}
+	}
+	sort.Strings(undocumented)
+	for _, key := range undocumented {
+		t.Errorf("%s is routed but missing from Operations", key)
+	}
+}
+
+func TestOpenAPI_UniqueOperationIDs(t *testing.T) {
+	seen := map[string]bool{}
+	for _, op := range Operations {
+		if op.ID == "" || seen[op.ID] {
+			t.Errorf("operation %s %s has missing or duplicate ID %q", op.Method, op.Path, op.ID)
+		}
+		seen[op.ID] = true
+	}
+}
+
+func TestOpenAPI_Served(t *testing.T) {
+	srv := fullServer()
+
+	// The contract is public, even when auth is configured.
+	req := httptest.NewRequest("GET", "/api/v1/openapi.json", nil)
+	w := httptest.NewRecorder()
+	srv.router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+
+	var doc struct {
+		OpenAPI    string                                `json:"openapi"`
+		Paths      map[string]map[string]json.RawMessage `json:"paths"`
+		Components struct {
+			Schemas map[string]struct {
+				Properties map[string]any `json:"properties"`
+			} `json:"schemas"`
+		} `json:"components"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&doc); err != nil {
+		t.Fatalf("decode document: %v", err)
+	}
+	if doc.OpenAPI != "3.1.0" {
+		t.Errorf("expected openapi 3.1.0, got %q", doc.OpenAPI)
+	}
+	if _, ok := doc.Paths["/api/v1/dedup"]["post"]; !ok {
+		t.Error("expected POST /api/v1/dedup in paths")
+	}
+	if _, ok := doc.Components.Schemas["DedupRequest"].Properties["threshold"]; !ok {
+		t.Error("expected DedupRequest schema with threshold property")
+	}
+}
@@ -0,0 +1,238 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/MikeSquared-Agency/dredd/internal/auth"
+	"github.com/MikeSquared-Agency/dredd/internal/embedding"
+	"github.com/MikeSquared-Agency/dredd/internal/store"
+)
+
+// PatternListResponse represents a page of reasoning patterns
+type PatternListResponse struct {
+	Patterns   []store.PatternRow `json:"patterns"`
+	Count      int                `json:"count"`
+	NextCursor string             `json:"next_cursor,omitempty"`
+}
+
+// PatternSearchRequest represents the request payload for semantic pattern search
+type PatternSearchRequest struct {
+	Query         string    `json:"query"`
+	Embedding     []float32 `json:"embedding,omitempty"` // skip server-side embedding
+	PatternType   string    `json:"pattern_type,omitempty"`
+	ReviewStatus  string    `json:"review_status,omitempty"`
+	Tag           string    `json:"tag,omitempty"`
+	MinConfidence *float64  `json:"min_confidence,omitempty"`
+	Limit         int       `json:"limit,omitempty"`          // default 10, max 200
+	MinSimilarity float64   `json:"min_similarity,omitempty"` // 0-1 cosine similarity
+}
+
+// PatternSearchResponse represents semantic search results, most similar first
+type PatternSearchResponse struct {
+	Patterns []store.PatternRow `json:"patterns"`
+	Count    int                `json:"count"`
+}
+
+// AddPatternRoutes adds reasoning pattern browse and search endpoints to an
+// existing router. embedder may be nil, in which case search callers must
+// supply their own embedding.
+func AddPatternRoutes(router chi.Router, apiToken string, store *store.Store, embedder Embedder) {
+	router.Route("/api/v1/patterns", func(r chi.Router) {
+		r.Use(BearerAuthMiddleware(apiToken))
+
+		handler := &patternHandler{
+			store:    store,
+			embedder: embedder,
+		}
+
+		r.With(RequireScope(auth.ScopeRead)).Get("/", handler.listPatterns)
+		r.With(RequireScope(auth.ScopeRead)).Post("/search", handler.searchPatterns)
+		r.With(RequireScope(auth.ScopeRead)).Get("/{id}", handler.getPattern)
+	})
+}
+
+// patternHandler holds dependencies for pattern endpoints
+type patternHandler struct {
+	store    *store.Store
+	embedder Embedder
+}
+
+// listPatterns handles GET /api/v1/patterns
+func (h *patternHandler) listPatterns(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+
+	f := store.PatternFilter{
+		PatternType:    q.Get("pattern_type"),
+		ReviewStatus:   q.Get("review_status"),
+		Tag:            q.Get("tag"),
+		IncludeDeduped: q.Get("include_deduped") == "true",
+	}
+
+	var err error
+	if f.Limit, err = parseLimit(q.Get("limit"), 50); err != nil {
+		http.Error(w, fmt.Sprintf(`{"error":%q}`, err.Error()), http.StatusBadRequest)
+		return
+	}
+	if f.MinConfidence, err = parseConfidenceParam(q.Get("min_confidence")); err != nil {
+		http.Error(w, fmt.Sprintf(`{"error":"invalid min_confidence: %v"}`, err), http.StatusBadRequest)
+		return
+	}
+	if f.MaxConfidence, err = parseConfidenceParam(q.Get("max_confidence")); err != nil {
+		http.Error(w, fmt.Sprintf(`{"error":"invalid max_confidence: %v"}`, err), http.StatusBadRequest)
+		return
+	}
+
+	if owner := q.Get("owner"); owner != "" {
+		id, err := uuid.Parse(owner)
+		if err != nil {
+			http.Error(w, `{"error":"owner must be a UUID"}`, http.StatusBadRequest)
+			return
+		}
+		f.Owner = id
+	}
+
+	if f.Since, err = parseTimeParam(q.Get("since")); err != nil {
+		http.Error(w, fmt.Sprintf(`{"error":"invalid since timestamp: %v"}`, err), http.StatusBadRequest)
+		return
+	}
+	if f.Until, err = parseTimeParam(q.Get("until")); err != nil {
+		http.Error(w, fmt.Sprintf(`{"error":"invalid until timestamp: %v"}`, err), http.StatusBadRequest)
+		return
+	}
+
+	if cursor := q.Get("cursor"); cursor != "" {
+		c, err := decodeCursor(cursor)
+		if err != nil {
+			http.Error(w, `{"error":"invalid cursor"}`, http.StatusBadRequest)
+			return
+		}
+		f.After = c
+	}
+
+	patterns, next, err := h.store.ListPatterns(r.Context(), f)
+	if err != nil {
+		http.Error(w, fmt.Sprintf(`{"error":"list patterns failed: %v"}`, err), http.StatusInternalServerError)
+		return
+	}
+	if patterns == nil {
+		patterns = []store.PatternRow{}
+	}
+
+	resp := PatternListResponse{
+		Patterns: patterns,
+		Count:    len(patterns),
+	}
+	if next != nil {
+		resp.NextCursor = encodeCursor(*next)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// getPattern handles GET /api/v1/patterns/{id}
+func (h *patternHandler) getPattern(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, `{"error":"id must be a UUID"}`, http.StatusBadRequest)
+		return
+	}
+
+	pattern, err := h.store.GetPatternByID(r.Context(), id)
+	if store.IsNotFound(err) {
+		http.Error(w, `{"error":"pattern not found"}`, http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, fmt.Sprintf(`{"error":"get pattern failed: %v"}`, err), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(pattern)
+}
+
+// searchPatterns handles POST /api/v1/patterns/search
+func (h *patternHandler) searchPatterns(w http.ResponseWriter, r *http.Request) {
+	var req PatternSearchRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, fmt.Sprintf(`{"error":"invalid JSON: %v"}`, err), http.StatusBadRequest)
+		return
+	}
+
+	if strings.TrimSpace(req.Query) == "" && len(req.Embedding) == 0 {
+		http.Error(w, `{"error":"query or embedding is required"}`, http.StatusBadRequest)
+		return
+	}
+	if req.Limit < 0 {
+		http.Error(w, `{"error":"limit must be a positive integer"}`, http.StatusBadRequest)
+		return
+	}
+	if req.Limit == 0 {
+		req.Limit = 10
+	}
+	if req.Limit > maxPageSize {
+		req.Limit = maxPageSize
+	}
+	if req.MinSimilarity < 0 || req.MinSimilarity > 1 {
+		http.Error(w, `{"error":"min_similarity must be between 0 and 1"}`, http.StatusBadRequest)
+		return
+	}
+
+	vec := req.Embedding
+	if len(vec) == 0 {
+		if h.embedder == nil {
+			http.Error(w, `{"error":"embedding service not configured; supply an embedding"}`, http.StatusServiceUnavailable)
+			return
+		}
+		var err error
+		vec, err = h.embedder.Embed(r.Context(), req.Query)
+		if err != nil {
+			http.Error(w, fmt.Sprintf(`{"error":"embed query failed: %v"}`, err), http.StatusBadGateway)
+			return
+		}
+	}
+	if len(vec) != embedding.Dimensions {
+		http.Error(w, fmt.Sprintf(`{"error":"embedding must have %d dimensions, got %d"}`, embedding.Dimensions, len(vec)), http.StatusBadRequest)
+		return
+	}
+
+	patterns, err := h.store.SearchPatterns(r.Context(), vec, req.MinSimilarity, store.PatternFilter{
+		PatternType:   req.PatternType,
+		ReviewStatus:  req.ReviewStatus,
+		Tag:           req.Tag,
+		MinConfidence: req.MinConfidence,
+		Limit:         req.Limit,
+	})
+	if err != nil {
+		http.Error(w, fmt.Sprintf(`{"error":"pattern search failed: %v"}`, err), http.StatusInternalServerError)
+		return
+	}
+	if patterns == nil {
+		patterns = []store.PatternRow{}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(PatternSearchResponse{
+		Patterns: patterns,
+		Count:    len(patterns),
+	})
+}
+
+// parseConfidenceParam parses an optional confidence bound in [0, 1].
+func parseConfidenceParam(s string) (*float64, error) {
+	if s == "" {
+		return nil, nil
+	}
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil || v < 0 || v > 1 {
+		return nil, fmt.Errorf("must be a number between 0 and 1")
+	}
+	return &v, nil
+}
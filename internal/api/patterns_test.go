@@ -0,0 +1,57 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestListPatterns_BadParams(t *testing.T) {
+	srv := NewServer(8750, "", nil)
+	AddPatternRoutes(srv.Router(), "", nil, nil)
+
+	for _, path := range []string{
+		"/api/v1/patterns?limit=0",
+		"/api/v1/patterns?min_confidence=1.5",
+		"/api/v1/patterns?max_confidence=high",
+		"/api/v1/patterns?until=tomorrow",
+		"/api/v1/patterns?cursor=garbage",
+	} {
+		req := httptest.NewRequest("GET", path, nil)
+		w := httptest.NewRecorder()
+		srv.router.ServeHTTP(w, req)
+
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("%s: expected 400, got %d", path, w.Code)
+		}
+	}
+}
+
+func TestSearchPatterns_Validation(t *testing.T) {
+	srv := NewServer(8750, "", nil)
+	AddPatternRoutes(srv.Router(), "", nil, nil)
+
+	tests := []struct {
+		name string
+		body string
+		want int
+	}{
+		{"missing query", `{}`, http.StatusBadRequest},
+		{"bad similarity", `{"query":"pushback on scope","min_similarity":2}`, http.StatusBadRequest},
+		{"no embedder", `{"query":"pushback on scope"}`, http.StatusServiceUnavailable},
+		{"wrong dimensions", `{"embedding":[0.1,0.2]}`, http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("POST", "/api/v1/patterns/search", strings.NewReader(tt.body))
+			w := httptest.NewRecorder()
+			srv.router.ServeHTTP(w, req)
+
+			if w.Code != tt.want {
+				t.Errorf("expected %d, got %d", tt.want, w.Code)
+			}
+		})
+	}
+}
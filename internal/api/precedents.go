@@ -0,0 +1,125 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/MikeSquared-Agency/dredd/internal/auth"
+	"github.com/MikeSquared-Agency/dredd/internal/embedding"
+	"github.com/MikeSquared-Agency/dredd/internal/store"
+)
+
+// maxPrecedents caps how many decisions a single precedent query returns.
+const maxPrecedents = 50
+
+// Embedder turns text into a vector for similarity search.
+type Embedder interface {
+	Embed(ctx context.Context, text string) ([]float32, error)
+}
+
+// PrecedentRequest represents the request payload for precedent retrieval
+type PrecedentRequest struct {
+	Situation     string    `json:"situation"`
+	Embedding     []float32 `json:"embedding,omitempty"` // skip server-side embedding
+	Domain        string    `json:"domain,omitempty"`
+	Category      string    `json:"category,omitempty"`
+	Limit         int       `json:"limit,omitempty"`          // default 5, max 50
+	MinSimilarity float64   `json:"min_similarity,omitempty"` // 0-1 cosine similarity
+}
+
+// PrecedentResponse represents the response from precedent retrieval
+type PrecedentResponse struct {
+	Precedents []store.DecisionDetail `json:"precedents"`
+	Count      int                    `json:"count"`
+}
+
+// AddPrecedentRoutes adds precedent retrieval endpoints to an existing router.
+// embedder may be nil, in which case callers must supply their own embedding.
+func AddPrecedentRoutes(router chi.Router, apiToken string, store *store.Store, embedder Embedder) {
+	router.Route("/api/v1/precedents", func(r chi.Router) {
+		r.Use(BearerAuthMiddleware(apiToken))
+
+		handler := &precedentHandler{
+			store:    store,
+			embedder: embedder,
+		}
+
+		r.With(RequireScope(auth.ScopeRead)).Post("/", handler.search)
+	})
+}
+
+// precedentHandler holds dependencies for precedent endpoints
+type precedentHandler struct {
+	store    *store.Store
+	embedder Embedder
+}
+
+// search handles POST /api/v1/precedents
+func (h *precedentHandler) search(w http.ResponseWriter, r *http.Request) {
+	var req PrecedentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, fmt.Sprintf(`{"error":"invalid JSON: %v"}`, err), http.StatusBadRequest)
+		return
+	}
+
+	if strings.TrimSpace(req.Situation) == "" && len(req.Embedding) == 0 {
+		http.Error(w, `{"error":"situation or embedding is required"}`, http.StatusBadRequest)
+		return
+	}
+	if req.Limit < 0 {
+		http.Error(w, `{"error":"limit must be a positive integer"}`, http.StatusBadRequest)
+		return
+	}
+	if req.Limit == 0 {
+		req.Limit = 5
+	}
+	if req.Limit > maxPrecedents {
+		req.Limit = maxPrecedents
+	}
+	if req.MinSimilarity < 0 || req.MinSimilarity > 1 {
+		http.Error(w, `{"error":"min_similarity must be between 0 and 1"}`, http.StatusBadRequest)
+		return
+	}
+
+	vec := req.Embedding
+	if len(vec) == 0 {
+		if h.embedder == nil {
+			http.Error(w, `{"error":"embedding service not configured; supply an embedding"}`, http.StatusServiceUnavailable)
+			return
+		}
+		var err error
+		vec, err = h.embedder.Embed(r.Context(), req.Situation)
+		if err != nil {
+			http.Error(w, fmt.Sprintf(`{"error":"embed situation failed: %v"}`, err), http.StatusBadGateway)
+			return
+		}
+	}
+	if len(vec) != embedding.Dimensions {
+		http.Error(w, fmt.Sprintf(`{"error":"embedding must have %d dimensions, got %d"}`, embedding.Dimensions, len(vec)), http.StatusBadRequest)
+		return
+	}
+
+	precedents, err := h.store.SearchPrecedents(r.Context(), vec, store.PrecedentFilter{
+		Domain:        req.Domain,
+		Category:      req.Category,
+		Limit:         req.Limit,
+		MinSimilarity: req.MinSimilarity,
+	})
+	if err != nil {
+		http.Error(w, fmt.Sprintf(`{"error":"precedent search failed: %v"}`, err), http.StatusInternalServerError)
+		return
+	}
+	if precedents == nil {
+		precedents = []store.DecisionDetail{}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(PrecedentResponse{
+		Precedents: precedents,
+		Count:      len(precedents),
+	})
+}
@@ -0,0 +1,71 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type fakeEmbedder struct {
+	vec []float32
+	err error
+}
+
+func (f fakeEmbedder) Embed(ctx context.Context, text string) ([]float32, error) {
+	return f.vec, f.err
+}
+
+func TestPrecedents_MissingSituation(t *testing.T) {
+	srv := NewServer(8750, "", nil)
+	AddPrecedentRoutes(srv.Router(), "", nil, nil)
+
+	req := httptest.NewRequest("POST", "/api/v1/precedents", strings.NewReader(`{"domain":"infra"}`))
+	w := httptest.NewRecorder()
+	srv.router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected 400, got %d", w.Code)
+	}
+}
+
+func TestPrecedents_NoEmbedder(t *testing.T) {
+	srv := NewServer(8750, "", nil)
+	AddPrecedentRoutes(srv.Router(), "", nil, nil)
+
+	req := httptest.NewRequest("POST", "/api/v1/precedents", strings.NewReader(`{"situation":"should we ship on friday"}`))
+	w := httptest.NewRecorder()
+	srv.router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusServiceUnavailable {
+		t.Errorf("expected 503, got %d", w.Code)
+	}
+}
+
+func TestPrecedents_EmbedderError(t *testing.T) {
+	srv := NewServer(8750, "", nil)
+	AddPrecedentRoutes(srv.Router(), "", nil, fakeEmbedder{err: errors.New("boom")})
+
+	req := httptest.NewRequest("POST", "/api/v1/precedents", strings.NewReader(`{"situation":"should we ship on friday"}`))
+	w := httptest.NewRecorder()
+	srv.router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusBadGateway {
+		t.Errorf("expected 502, got %d", w.Code)
+	}
+}
+
+func TestPrecedents_WrongDimensions(t *testing.T) {
+	srv := NewServer(8750, "", nil)
+	AddPrecedentRoutes(srv.Router(), "", nil, nil)
+
+	req := httptest.NewRequest("POST", "/api/v1/precedents", strings.NewReader(`{"embedding":[0.1,0.2,0.3]}`))
+	w := httptest.NewRecorder()
+	srv.router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected 400, got %d", w.Code)
+	}
+}
@@ -0,0 +1,80 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// readyTimeout bounds each readiness check so a hung dependency reports
+// failed rather than stalling the probe.
+const readyTimeout = 3 * time.Second
+
+// ReadyCheck reports whether one dependency is usable; nil means ready.
+type ReadyCheck func(ctx context.Context) error
+
+// ReadyResponse is the body of GET /ready.
+type ReadyResponse struct {
+	Status string                 `json:"status"` // ready | not_ready
+	Checks map[string]CheckResult `json:"checks"`
+}
+
+// CheckResult is the outcome of one readiness check.
+type CheckResult struct {
+	Status    string `json:"status"` // ok | failed
+	Error     string `json:"error,omitempty"`
+	LatencyMS int64  `json:"latency_ms"`
+}
+
+// AddReadyRoute adds GET /ready, which runs every check and answers 200 if
+// all pass and 503 otherwise, with a per-dependency breakdown. Unlike /health
+// it is meant for orchestrators deciding whether to route traffic here.
+// Unauthenticated.
+func AddReadyRoute(router chi.Router, checks map[string]ReadyCheck) {
+	router.Get("/ready", func(w http.ResponseWriter, r *http.Request) {
+		resp := runChecks(r.Context(), checks)
+
+		w.Header().Set("Content-Type", "application/json")
+		if resp.Status != "ready" {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+		json.NewEncoder(w).Encode(resp)
+	})
+}
+
+// runChecks runs the checks concurrently, each under readyTimeout.
+func runChecks(ctx context.Context, checks map[string]ReadyCheck) ReadyResponse {
+	resp := ReadyResponse{Status: "ready", Checks: make(map[string]CheckResult, len(checks))}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for name, check := range checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ctx, cancel := context.WithTimeout(ctx, readyTimeout)
+			defer cancel()
+
+			start := time.Now()
+			err := check(ctx)
+			res := CheckResult{Status: "ok", LatencyMS: time.Since(start).Milliseconds()}
+			if err != nil {
+				res.Status = "failed"
+				res.Error = err.Error()
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			resp.Checks[name] = res
+			if err != nil {
+				resp.Status = "not_ready"
+			}
+		}()
+	}
+	wg.Wait()
+	return resp
+}
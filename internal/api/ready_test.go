@@ -0,0 +1,86 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func readyServer(checks map[string]ReadyCheck) *Server {
+	srv := NewServer(8750, "some-secret", nil)
+	AddReadyRoute(srv.Router(), checks)
+	return srv
+}
+
+func TestReady_AllChecksPass(t *testing.T) {
+	srv := readyServer(map[string]ReadyCheck{
+		"database": func(context.Context) error { return nil },
+		"nats":     func(context.Context) error { return nil },
+	})
+
+	req := httptest.NewRequest("GET", "/ready", nil)
+	w := httptest.NewRecorder()
+	srv.router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200 without auth header, got %d", w.Code)
+	}
+	var resp ReadyResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if resp.Status != "ready" {
+		t.Errorf("expected ready, got %q", resp.Status)
+	}
+	if len(resp.Checks) != 2 || resp.Checks["database"].Status != "ok" || resp.Checks["nats"].Status != "ok" {
+		t.Errorf("unexpected checks: %+v", resp.Checks)
+	}
+}
+
+func TestReady_FailedCheck(t *testing.T) {
+	srv := readyServer(map[string]ReadyCheck{
+		"database":   func(context.Context) error { return nil },
+		"migrations": func(context.Context) error { return errors.New("schema version 13, want 14") },
+	})
+
+	req := httptest.NewRequest("GET", "/ready", nil)
+	w := httptest.NewRecorder()
+	srv.router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusServiceUnavailable {
+		t.Fatalf("expected 503, got %d", w.Code)
+	}
+	var resp ReadyResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if resp.Status != "not_ready" {
+		t.Errorf("expected not_ready, got %q", resp.Status)
+	}
+	if resp.Checks["database"].Status != "ok" {
+		t.Errorf("expected database ok, got %+v", resp.Checks["database"])
+	}
+	got := resp.Checks["migrations"]
+	if got.Status != "failed" || got.Error != "schema version 13, want 14" {
+		t.Errorf("unexpected migrations result: %+v", got)
+	}
+}
+
+func TestReady_CheckTimesOut(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	resp := runChecks(ctx, map[string]ReadyCheck{
+		"nats": func(ctx context.Context) error {
+			<-ctx.Done()
+			return ctx.Err()
+		},
+	})
+
+	if resp.Status != "not_ready" || resp.Checks["nats"].Status != "failed" {
+		t.Errorf("expected a failed check once the context is done, got %+v", resp)
+	}
+}
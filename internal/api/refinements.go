@@ -3,13 +3,18 @@ package api
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/MikeSquared-Agency/dredd/internal/auth"
+	"github.com/MikeSquared-Agency/dredd/internal/bus"
 	"github.com/MikeSquared-Agency/dredd/internal/hermes"
 	"github.com/MikeSquared-Agency/dredd/internal/refinement"
 	"github.com/MikeSquared-Agency/dredd/internal/store"
@@ -18,38 +23,72 @@ import (
 // ScanRequest represents the request payload for refinement scans
 type ScanRequest struct {
 	Since     *string  `json:"since,omitempty"`     // ISO timestamp
-	SOULSlug  *string  `json:"soul_slug,omitempty"` // Target SOUL slug
+	SOULSlug  *string  `json:"soul_slug,omitempty"` // Target SOUL slug, default from the SOUL mapping
 	Threshold *float64 `json:"threshold,omitempty"` // Similarity threshold
+	MinSize   *int     `json:"min_size,omitempty"`  // Fewest patterns per cluster
 	DryRun    bool     `json:"dry_run"`             // Don't publish, just return results
 }
 
 // ScanResponse represents the response from refinement scans
 type ScanResponse struct {
-	Clusters []refinement.PatternCluster `json:"clusters"`
-	Count    int                         `json:"count"`
-	DryRun   bool                        `json:"dry_run"`
+	Clusters   []refinement.PatternCluster `json:"clusters"`
+	Count      int                         `json:"count"`
+	Suppressed int                         `json:"suppressed"` // clusters matching an open or rejected proposal
+	DryRun     bool                        `json:"dry_run"`
 }
 
-// AddRefinementRoutes adds refinement endpoints to an existing router
-func AddRefinementRoutes(router chi.Router, apiToken string, store *store.Store, hermes *hermes.Client) {
+// ProposalReviewRequest accepts, rejects or marks a refinement proposal applied
+type ProposalReviewRequest struct {
+	Reviewer string `json:"reviewer,omitempty"`
+	Note     string `json:"note,omitempty"`
+}
+
+// ProposalListResponse represents refinement proposals, newest first
+type ProposalListResponse struct {
+	Proposals []store.RefinementProposal `json:"proposals"`
+	Count     int                        `json:"count"`
+}
+
+// errUnknownSOUL is returned by performScan for a SOUL with no section mapping.
+var errUnknownSOUL = errors.New("no section mapping for SOUL")
+
+// AddRefinementRoutes adds refinement endpoints to an existing router. drafter
+// may be nil, in which case proposals carry a summary instead of a drafted
+// edit; mapping may be nil for the built-in section rules.
+func AddRefinementRoutes(router chi.Router, apiToken string, store *store.Store, hermes *hermes.Client, eventBus *bus.Bus, drafter *refinement.Drafter, mapping *refinement.Mapping) {
+	if mapping == nil {
+		mapping = refinement.DefaultMapping()
+	}
+
 	router.Route("/api/v1/refinements", func(r chi.Router) {
 		r.Use(BearerAuthMiddleware(apiToken))
 		
 		// Create handlers with dependencies
 		handler := &refinementHandler{
-			store:  store,
-			hermes: hermes,
+			store:   store,
+			hermes:  hermes,
+			bus:     eventBus,
+			drafter: drafter,
+			mapping: mapping,
 		}
 		
-		r.Post("/scan", handler.scanRefinements)
-		r.Get("/scan", handler.scanRefinementsDryRun)
+		r.With(RequireScope(auth.ScopeRefinementPublish)).Post("/scan", handler.scanRefinements)
+		r.With(RequireScope(auth.ScopeRead)).Get("/scan", handler.scanRefinementsDryRun)
+		r.With(RequireScope(auth.ScopeRead)).Get("/proposals", handler.listProposals)
+		r.With(RequireScope(auth.ScopeRead)).Get("/proposals/{id}", handler.getProposal)
+		r.With(RequireScope(auth.ScopeReview)).Post("/proposals/{id}/accept", handler.acceptProposal)
+		r.With(RequireScope(auth.ScopeReview)).Post("/proposals/{id}/reject", handler.rejectProposal)
+		r.With(RequireScope(auth.ScopeReview)).Post("/proposals/{id}/apply", handler.applyProposal)
 	})
 }
 
 // refinementHandler holds dependencies for refinement endpoints
 type refinementHandler struct {
-	store  *store.Store
-	hermes *hermes.Client
+	store   *store.Store
+	hermes  *hermes.Client
+	bus     *bus.Bus
+	drafter *refinement.Drafter
+	mapping *refinement.Mapping
 }
 
 // scanRefinements handles POST /api/v1/refinements/scan
@@ -60,24 +99,42 @@ func (h *refinementHandler) scanRefinements(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
-	clusters, err := h.performScan(r.Context(), &req)
+	clusters, suppressed, err := h.performScan(r.Context(), &req)
 	if err != nil {
-		http.Error(w, fmt.Sprintf(`{"error":"scan failed: %v"}`, err), http.StatusInternalServerError)
+		writeScanError(w, err)
 		return
 	}
 
-	// If not dry run, publish refinement proposals
+	// If not dry run, store and publish refinement proposals
 	if !req.DryRun && len(clusters) > 0 {
-		publisher := refinement.NewPublisher(h.hermes)
-		soulSlug := "kai-soul" // Default SOUL slug
-		if req.SOULSlug != nil {
-			soulSlug = *req.SOULSlug
-		}
+		publisher := refinement.NewPublisher(h.hermes, h.bus)
 
-		for _, cluster := range clusters {
-			if err := publisher.PublishRefinementProposal(cluster, soulSlug); err != nil {
-				// Log error but don't fail the request
+		for i, cluster := range clusters {
+			if h.drafter != nil {
+				draft, err := h.drafter.Draft(r.Context(), cluster, cluster.SOULSlug)
+				if err != nil {
+					// Fall back to the summary; the cluster is still worth proposing.
+					slog.Warn("failed to draft SOUL edit",
+						"pattern_type", cluster.PatternType,
+						"section", cluster.SOULSection,
+						"error", err)
+				} else {
+					clusters[i].Draft = draft
+				}
+			}
+
+			proposal, err := h.store.CreateRefinementProposal(r.Context(), refinement.Proposal(clusters[i], cluster.SOULSlug))
+			if err != nil {
+				http.Error(w, fmt.Sprintf(`{"error":"failed to store proposal: %v"}`, err), http.StatusInternalServerError)
+				return
+			}
+			clusters[i].ProposalID = proposal.ID.String()
+
+			if err := publisher.PublishRefinementProposal(clusters[i], cluster.SOULSlug); err != nil {
+				// Log error but don't fail the request; the stored proposal
+				// stays reviewable through the API.
 				slog.Warn("failed to publish refinement proposal",
+					"proposal_id", proposal.ID,
 					"pattern_type", cluster.PatternType,
 					"cluster_size", cluster.Count,
 					"error", err)
@@ -86,9 +143,10 @@ func (h *refinementHandler) scanRefinements(w http.ResponseWriter, r *http.Reque
 	}
 
 	response := ScanResponse{
-		Clusters: clusters,
-		Count:    len(clusters),
-		DryRun:   req.DryRun,
+		Clusters:   clusters,
+		Count:      len(clusters),
+		Suppressed: suppressed,
+		DryRun:     req.DryRun,
 	}
 
 	w.Header().Set("Content-Type", "application/json")
@@ -117,31 +175,53 @@ func (h *refinementHandler) scanRefinementsDryRun(w http.ResponseWriter, r *http
 		req.Threshold = &threshold
 	}
 
-	clusters, err := h.performScan(r.Context(), &req)
+	if minSizeStr := r.URL.Query().Get("min_size"); minSizeStr != "" {
+		minSize, err := strconv.Atoi(minSizeStr)
+		if err != nil || minSize < 1 {
+			http.Error(w, `{"error":"min_size must be a positive integer"}`, http.StatusBadRequest)
+			return
+		}
+		req.MinSize = &minSize
+	}
+
+	clusters, suppressed, err := h.performScan(r.Context(), &req)
 	if err != nil {
-		http.Error(w, fmt.Sprintf(`{"error":"scan failed: %v"}`, err), http.StatusInternalServerError)
+		writeScanError(w, err)
 		return
 	}
 
 	response := ScanResponse{
-		Clusters: clusters,
-		Count:    len(clusters),
-		DryRun:   true,
+		Clusters:   clusters,
+		Count:      len(clusters),
+		Suppressed: suppressed,
+		DryRun:     true,
 	}
 
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(response)
 }
 
-// performScan executes the pattern detection and clustering logic
-func (h *refinementHandler) performScan(ctx context.Context, req *ScanRequest) ([]refinement.PatternCluster, error) {
+// performScan executes the pattern detection and clustering logic, targets
+// each cluster at every section it maps to in the SOUL, and drops targets
+// that match an open or rejected proposal. It returns the remaining targets
+// and how many were dropped.
+func (h *refinementHandler) performScan(ctx context.Context, req *ScanRequest) ([]refinement.PatternCluster, int, error) {
+	soulSlug := h.mapping.DefaultSOUL
+	if req.SOULSlug != nil && *req.SOULSlug != "" {
+		soulSlug = *req.SOULSlug
+	}
+	mapper, ok := h.mapping.For(soulSlug)
+	if !ok {
+		return nil, 0, fmt.Errorf("%w %q", errUnknownSOUL, soulSlug)
+	}
+
 	detector := refinement.NewDetector(h.store)
 
 	var since *time.Time
 	if req.Since != nil {
 		t, err := time.Parse(time.RFC3339, *req.Since)
 		if err != nil {
-			return nil, fmt.Errorf("invalid since timestamp: %w", err)
+			return nil, 0, fmt.Errorf("invalid since timestamp: %w", err)
 		}
 		since = &t
 	}
@@ -151,5 +231,146 @@ func (h *refinementHandler) performScan(ctx context.Context, req *ScanRequest) (
 		threshold = *req.Threshold
 	}
 
-	return detector.FindClusters(ctx, since, threshold)
+	minSize := refinement.DefaultMinClusterSize
+	if req.MinSize != nil {
+		minSize = *req.MinSize
+	}
+
+	clusters, err := detector.FindClusters(ctx, since, threshold, minSize)
+	if err != nil {
+		return nil, 0, err
+	}
+	clusters = mapper.Target(clusters, soulSlug)
+
+	proposals, err := h.store.ListRefinementProposals(ctx, refinement.SuppressingStatuses, 0)
+	if err != nil {
+		return nil, 0, err
+	}
+	clusters, suppressed := refinement.Suppress(clusters, proposals)
+	return clusters, suppressed, nil
+}
+
+// writeScanError reports a failed scan, as a bad request for an unmapped SOUL
+// or one too large to cluster.
+func writeScanError(w http.ResponseWriter, err error) {
+	if errors.Is(err, errUnknownSOUL) || errors.Is(err, refinement.ErrTooManyPatterns) {
+		http.Error(w, fmt.Sprintf(`{"error":%q}`, err.Error()), http.StatusBadRequest)
+		return
+	}
+	http.Error(w, fmt.Sprintf(`{"error":"scan failed: %v"}`, err), http.StatusInternalServerError)
+}
+
+// listProposals handles GET /api/v1/refinements/proposals?status=
+func (h *refinementHandler) listProposals(w http.ResponseWriter, r *http.Request) {
+	var statuses []string
+	for _, param := range r.URL.Query()["status"] {
+		for _, status := range strings.Split(param, ",") {
+			switch status {
+			case store.ProposalProposed, store.ProposalAccepted, store.ProposalRejected, store.ProposalApplied:
+				statuses = append(statuses, status)
+			default:
+				http.Error(w, `{"error":"status must be proposed, accepted, rejected or applied"}`, http.StatusBadRequest)
+				return
+			}
+		}
+	}
+	limit, err := parseLimit(r.URL.Query().Get("limit"), 50)
+	if err != nil {
+		http.Error(w, fmt.Sprintf(`{"error":%q}`, err.Error()), http.StatusBadRequest)
+		return
+	}
+
+	proposals, err := h.store.ListRefinementProposals(r.Context(), statuses, limit)
+	if err != nil {
+		http.Error(w, fmt.Sprintf(`{"error":"failed to list proposals: %v"}`, err), http.StatusInternalServerError)
+		return
+	}
+	if proposals == nil {
+		proposals = []store.RefinementProposal{}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(ProposalListResponse{Proposals: proposals, Count: len(proposals)})
+}
+
+// getProposal handles GET /api/v1/refinements/proposals/{id}
+func (h *refinementHandler) getProposal(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, `{"error":"invalid proposal ID"}`, http.StatusBadRequest)
+		return
+	}
+
+	proposal, err := h.store.GetRefinementProposal(r.Context(), id)
+	if err != nil {
+		if store.IsNotFound(err) {
+			http.Error(w, `{"error":"proposal not found"}`, http.StatusNotFound)
+			return
+		}
+		http.Error(w, fmt.Sprintf(`{"error":"failed to get proposal: %v"}`, err), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(proposal)
+}
+
+// acceptProposal handles POST /api/v1/refinements/proposals/{id}/accept
+func (h *refinementHandler) acceptProposal(w http.ResponseWriter, r *http.Request) {
+	h.setProposalStatus(w, r, store.ProposalAccepted)
+}
+
+// rejectProposal handles POST /api/v1/refinements/proposals/{id}/reject
+func (h *refinementHandler) rejectProposal(w http.ResponseWriter, r *http.Request) {
+	h.setProposalStatus(w, r, store.ProposalRejected)
+}
+
+// applyProposal handles POST /api/v1/refinements/proposals/{id}/apply, marking
+// an accepted proposal as applied to its SOUL
+func (h *refinementHandler) applyProposal(w http.ResponseWriter, r *http.Request) {
+	h.setProposalStatus(w, r, store.ProposalApplied)
+}
+
+// setProposalStatus moves the proposal in the URL to status
+func (h *refinementHandler) setProposalStatus(w http.ResponseWriter, r *http.Request, status string) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, `{"error":"invalid proposal ID"}`, http.StatusBadRequest)
+		return
+	}
+
+	var req ProposalReviewRequest
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, fmt.Sprintf(`{"error":"invalid JSON: %v"}`, err), http.StatusBadRequest)
+			return
+		}
+	}
+	if p := PrincipalFrom(r.Context()); req.Reviewer == "" && p != nil && p.Name != "static" {
+		req.Reviewer = p.Name
+	}
+
+	proposal, err := h.store.SetRefinementProposalStatus(r.Context(), id, status, req.Reviewer, req.Note)
+	if err != nil {
+		switch {
+		case store.IsNotFound(err):
+			http.Error(w, `{"error":"proposal not found"}`, http.StatusNotFound)
+		case errors.Is(err, store.ErrProposalTransition):
+			http.Error(w, fmt.Sprintf(`{"error":"%v"}`, err), http.StatusConflict)
+		default:
+			http.Error(w, fmt.Sprintf(`{"error":"failed to update proposal: %v"}`, err), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	h.bus.Publish(bus.KindReviewApplied, uuid.Nil, bus.ReviewApplied{
+		Kind:     "refinement",
+		ID:       proposal.ID,
+		Verdict:  proposal.Status,
+		Reviewer: proposal.Reviewer,
+		Channel:  "api",
+	})
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(proposal)
 }
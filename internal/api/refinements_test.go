@@ -0,0 +1,71 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/MikeSquared-Agency/dredd/internal/refinement"
+)
+
+func TestRefinementProposals_BadRequest(t *testing.T) {
+	srv := NewServer(8750, "", nil)
+	AddRefinementRoutes(srv.Router(), "", nil, nil, nil, nil, nil)
+
+	for _, tc := range []struct{ method, path string }{
+		{"GET", "/api/v1/refinements/proposals?status=pending"},
+		{"GET", "/api/v1/refinements/proposals?limit=0"},
+		{"GET", "/api/v1/refinements/proposals/not-a-uuid"},
+		{"POST", "/api/v1/refinements/proposals/not-a-uuid/accept"},
+		{"POST", "/api/v1/refinements/proposals/not-a-uuid/reject"},
+		{"POST", "/api/v1/refinements/proposals/not-a-uuid/apply"},
+	} {
+		req := httptest.NewRequest(tc.method, tc.path, nil)
+		w := httptest.NewRecorder()
+		srv.router.ServeHTTP(w, req)
+
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("%s %s: expected 400, got %d", tc.method, tc.path, w.Code)
+		}
+	}
+}
+
+func TestRefinementProposals_RequiresAuth(t *testing.T) {
+	srv := NewServer(8750, "test-token", nil)
+	AddRefinementRoutes(srv.Router(), "test-token", nil, nil, nil, nil, nil)
+
+	req := httptest.NewRequest("POST", "/api/v1/refinements/proposals/00000000-0000-0000-0000-000000000000/accept", nil)
+	w := httptest.NewRecorder()
+	srv.router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("expected 401, got %d", w.Code)
+	}
+}
+
+func TestRefinementScan_UnknownSOUL(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "mapping.json")
+	if err := os.WriteFile(path, []byte(`{"souls": {"ops-soul": {"pattern_types": {"correction": ["runbooks"]}}}}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	mapping, err := refinement.LoadMapping(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	srv := NewServer(8750, "", nil)
+	AddRefinementRoutes(srv.Router(), "", nil, nil, nil, nil, mapping)
+
+	// The default SOUL is kai-soul, which the mapping does not cover.
+	for _, path := range []string{"/api/v1/refinements/scan", "/api/v1/refinements/scan?soul_slug=other-soul"} {
+		req := httptest.NewRequest("GET", path, nil)
+		w := httptest.NewRecorder()
+		srv.router.ServeHTTP(w, req)
+
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("GET %s: expected 400, got %d", path, w.Code)
+		}
+	}
+}
@@ -0,0 +1,136 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/MikeSquared-Agency/dredd/internal/auth"
+	"github.com/MikeSquared-Agency/dredd/internal/store"
+)
+
+// Reviewer applies review verdicts with the same effects as a Slack reaction.
+type Reviewer interface {
+	Review(ctx context.Context, kind string, id uuid.UUID, verdict, note, reviewer string) error
+}
+
+// ReviewRequest represents the request payload for a review verdict
+type ReviewRequest struct {
+	Kind     string `json:"kind"`    // decision | pattern
+	ID       string `json:"id"`      // stored decision or pattern ID
+	Verdict  string `json:"verdict"` // confirmed | rejected | skipped
+	Note     string `json:"note,omitempty"`
+	Reviewer string `json:"reviewer,omitempty"`
+}
+
+// ReviewResponse acknowledges a recorded review verdict
+type ReviewResponse struct {
+	Kind    string    `json:"kind"`
+	ID      uuid.UUID `json:"id"`
+	Verdict string    `json:"verdict"`
+	Status  string    `json:"status"` // recorded
+}
+
+// ReviewListResponse represents the review history of one item
+type ReviewListResponse struct {
+	Reviews []store.ReviewEntry `json:"reviews"`
+	Count   int                 `json:"count"`
+}
+
+// AddReviewRoutes adds review endpoints to an existing router
+func AddReviewRoutes(router chi.Router, apiToken string, store *store.Store, reviewer Reviewer) {
+	router.Route("/api/v1/reviews", func(r chi.Router) {
+		r.Use(BearerAuthMiddleware(apiToken))
+
+		handler := &reviewHandler{
+			store:    store,
+			reviewer: reviewer,
+		}
+
+		r.With(RequireScope(auth.ScopeReview)).Post("/", handler.submitReview)
+		r.With(RequireScope(auth.ScopeRead)).Get("/", handler.listReviews)
+	})
+}
+
+// reviewHandler holds dependencies for review endpoints
+type reviewHandler struct {
+	store    *store.Store
+	reviewer Reviewer
+}
+
+// submitReview handles POST /api/v1/reviews
+func (h *reviewHandler) submitReview(w http.ResponseWriter, r *http.Request) {
+	var req ReviewRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, fmt.Sprintf(`{"error":"invalid JSON: %v"}`, err), http.StatusBadRequest)
+		return
+	}
+
+	if req.Kind != "decision" && req.Kind != "pattern" {
+		http.Error(w, `{"error":"kind must be 'decision' or 'pattern'"}`, http.StatusBadRequest)
+		return
+	}
+	if req.Verdict != "confirmed" && req.Verdict != "rejected" && req.Verdict != "skipped" {
+		http.Error(w, `{"error":"verdict must be 'confirmed', 'rejected', or 'skipped'"}`, http.StatusBadRequest)
+		return
+	}
+	id, err := uuid.Parse(req.ID)
+	if err != nil {
+		http.Error(w, `{"error":"id must be a UUID"}`, http.StatusBadRequest)
+		return
+	}
+
+	// Attribute the review to the named token unless the caller says otherwise.
+	if p := PrincipalFrom(r.Context()); req.Reviewer == "" && p != nil && p.Name != "static" {
+		req.Reviewer = p.Name
+	}
+
+	if err := h.reviewer.Review(r.Context(), req.Kind, id, req.Verdict, req.Note, req.Reviewer); err != nil {
+		if store.IsNotFound(err) {
+			http.Error(w, fmt.Sprintf(`{"error":"%s not found"}`, req.Kind), http.StatusNotFound)
+			return
+		}
+		http.Error(w, fmt.Sprintf(`{"error":"review failed: %v"}`, err), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(ReviewResponse{
+		Kind:    req.Kind,
+		ID:      id,
+		Verdict: req.Verdict,
+		Status:  "recorded",
+	})
+}
+
+// listReviews handles GET /api/v1/reviews?kind=&id=
+func (h *reviewHandler) listReviews(w http.ResponseWriter, r *http.Request) {
+	kind := r.URL.Query().Get("kind")
+	if kind != "decision" && kind != "pattern" {
+		http.Error(w, `{"error":"kind must be 'decision' or 'pattern'"}`, http.StatusBadRequest)
+		return
+	}
+	id, err := uuid.Parse(r.URL.Query().Get("id"))
+	if err != nil {
+		http.Error(w, `{"error":"id must be a UUID"}`, http.StatusBadRequest)
+		return
+	}
+
+	reviews, err := h.store.ListReviews(r.Context(), kind, id)
+	if err != nil {
+		http.Error(w, fmt.Sprintf(`{"error":"list reviews failed: %v"}`, err), http.StatusInternalServerError)
+		return
+	}
+	if reviews == nil {
+		reviews = []store.ReviewEntry{}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(ReviewListResponse{
+		Reviews: reviews,
+		Count:   len(reviews),
+	})
+}
@@ -0,0 +1,113 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+type fakeReviewer struct {
+	calls []string
+	err   error
+}
+
+func (f *fakeReviewer) Review(ctx context.Context, kind string, id uuid.UUID, verdict, note, reviewer string) error {
+	f.calls = append(f.calls, fmt.Sprintf("%s/%s/%s/%s/%s", kind, id, verdict, note, reviewer))
+	return f.err
+}
+
+func TestSubmitReview(t *testing.T) {
+	id := uuid.New()
+	rev := &fakeReviewer{}
+	srv := NewServer(8750, "", nil)
+	AddReviewRoutes(srv.Router(), "", nil, rev)
+
+	body := fmt.Sprintf(`{"kind":"decision","id":%q,"verdict":"rejected","note":"wrong call","reviewer":"mike"}`, id)
+	req := httptest.NewRequest("POST", "/api/v1/reviews", strings.NewReader(body))
+	w := httptest.NewRecorder()
+	srv.router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	want := fmt.Sprintf("decision/%s/rejected/wrong call/mike", id)
+	if len(rev.calls) != 1 || rev.calls[0] != want {
+		t.Errorf("expected call %q, got %v", want, rev.calls)
+	}
+
+	var resp map[string]any
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if resp["status"] != "recorded" {
+		t.Errorf("expected status recorded, got %v", resp["status"])
+	}
+}
+
+func TestSubmitReview_Validation(t *testing.T) {
+	id := uuid.New().String()
+	tests := []struct {
+		name string
+		body string
+	}{
+		{"bad kind", fmt.Sprintf(`{"kind":"style","id":%q,"verdict":"confirmed"}`, id)},
+		{"bad verdict", fmt.Sprintf(`{"kind":"decision","id":%q,"verdict":"maybe"}`, id)},
+		{"bad id", `{"kind":"pattern","id":"abc","verdict":"confirmed"}`},
+		{"bad json", `{`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rev := &fakeReviewer{}
+			srv := NewServer(8750, "", nil)
+			AddReviewRoutes(srv.Router(), "", nil, rev)
+
+			req := httptest.NewRequest("POST", "/api/v1/reviews", strings.NewReader(tt.body))
+			w := httptest.NewRecorder()
+			srv.router.ServeHTTP(w, req)
+
+			if w.Code != http.StatusBadRequest {
+				t.Errorf("expected 400, got %d", w.Code)
+			}
+			if len(rev.calls) != 0 {
+				t.Errorf("expected no review calls, got %v", rev.calls)
+			}
+		})
+	}
+}
+
+func TestSubmitReview_NotFound(t *testing.T) {
+	srv := NewServer(8750, "", nil)
+	AddReviewRoutes(srv.Router(), "", nil, &fakeReviewer{err: pgx.ErrNoRows})
+
+	body := fmt.Sprintf(`{"kind":"pattern","id":%q,"verdict":"confirmed"}`, uuid.New())
+	req := httptest.NewRequest("POST", "/api/v1/reviews", strings.NewReader(body))
+	w := httptest.NewRecorder()
+	srv.router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected 404, got %d", w.Code)
+	}
+}
+
+func TestSubmitReview_StoreError(t *testing.T) {
+	srv := NewServer(8750, "", nil)
+	AddReviewRoutes(srv.Router(), "", nil, &fakeReviewer{err: errors.New("update decision review: connection refused")})
+
+	body := fmt.Sprintf(`{"kind":"decision","id":%q,"verdict":"confirmed"}`, uuid.New())
+	req := httptest.NewRequest("POST", "/api/v1/reviews", strings.NewReader(body))
+	w := httptest.NewRecorder()
+	srv.router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusInternalServerError {
+		t.Errorf("expected 500 when the verdict is not stored, got %d", w.Code)
+	}
+}
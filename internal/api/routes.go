@@ -0,0 +1,66 @@
+package api
+
+import (
+	"github.com/MikeSquared-Agency/dredd/internal/autonomy"
+	"github.com/MikeSquared-Agency/dredd/internal/bus"
+	"github.com/MikeSquared-Agency/dredd/internal/dataset"
+	"github.com/MikeSquared-Agency/dredd/internal/hermes"
+	"github.com/MikeSquared-Agency/dredd/internal/refinement"
+	"github.com/MikeSquared-Agency/dredd/internal/store"
+)
+
+// Deps are what the route groups serve from. A nil dependency leaves its
+// routes registered, answering the way each Add*Routes documents.
+type Deps struct {
+	Store       *store.Store
+	ReadyChecks map[string]ReadyCheck
+	Jobs        JobRunner
+	Hermes      *hermes.Client
+	Bus         *bus.Bus
+	Drafter     *refinement.Drafter // proposals carry a drafted SOUL edit when set
+	Mapping     *refinement.Mapping
+	Autonomy    *autonomy.Manager
+	Processor   TranscriptProcessor
+	Reviewer    Reviewer
+	Embedder    Embedder
+	Transcripts dataset.Transcripts // needed by dataset export
+}
+
+// New creates a server with every route group registered, so the binary and
+// the contract tests route the same API.
+func New(port int, apiToken string, d Deps) *Server {
+	srv := NewServer(port, apiToken, d.Store)
+	router := srv.Router()
+
+	// Readiness — per-dependency checks for orchestrators.
+	AddReadyRoute(router, d.ReadyChecks)
+
+	// Dedup runs and the background jobs that execute them
+	AddDedupRoutes(router, apiToken, d.Store, d.Jobs)
+	AddJobRoutes(router, apiToken, d.Jobs)
+
+	// SOUL refinement proposals
+	AddRefinementRoutes(router, apiToken, d.Store, d.Hermes, d.Bus, d.Drafter, d.Mapping)
+
+	// Autonomy modes and shadow-mode predictions
+	AddAutonomyRoutes(router, apiToken, d.Store, d.Autonomy)
+	AddShadowRoutes(router, apiToken, d.Store)
+
+	// Decision queries and ad-hoc extraction
+	AddDecisionRoutes(router, apiToken, d.Store)
+	AddExtractRoutes(router, apiToken, d.Processor)
+
+	// Trust inspection and reviews (same effects as Slack reactions)
+	AddTrustRoutes(router, apiToken, d.Store)
+	AddReviewRoutes(router, apiToken, d.Store, d.Reviewer)
+
+	// Reasoning pattern browse and search, and precedent retrieval
+	AddPatternRoutes(router, apiToken, d.Store, d.Embedder)
+	AddPrecedentRoutes(router, apiToken, d.Store, d.Embedder)
+
+	// Live event stream and fine-tuning dataset export
+	AddStreamRoutes(router, apiToken, d.Bus)
+	AddDatasetRoutes(router, apiToken, d.Store, d.Transcripts)
+
+	return srv
+}
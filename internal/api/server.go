@@ -5,11 +5,14 @@ import (
 	"encoding/json"
 	"fmt"
 	"log/slog"
+	"net"
 	"net/http"
-	"strings"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
+	"github.com/MikeSquared-Agency/dredd/internal/auth"
+	"github.com/MikeSquared-Agency/dredd/internal/autonomy"
+	"github.com/MikeSquared-Agency/dredd/internal/metrics"
 	"github.com/MikeSquared-Agency/dredd/internal/store"
 )
 
@@ -20,10 +23,20 @@ type Server struct {
 	store      *store.Store
 }
 
-type DedupRequest struct {
-	Threshold float64 `json:"threshold"`
-	Execute   bool    `json:"execute"`
-	Table     string  `json:"table"`
+// HealthResponse is the body of GET /health.
+type HealthResponse struct {
+	Status string `json:"status"`
+}
+
+// StatusResponse is the body of GET /api/v1/dredd/status.
+type StatusResponse struct {
+	Agent  string `json:"agent"`
+	Status string `json:"status"` // default autonomy mode; per-category modes: /api/v1/autonomy
+}
+
+// ErrorResponse is the body of every error response.
+type ErrorResponse struct {
+	Error string `json:"error"`
 }
 
 func NewServer(port int, apiToken string, db *store.Store) *Server {
@@ -31,6 +44,13 @@ func NewServer(port int, apiToken string, db *store.Store) *Server {
 	router.Use(middleware.Logger)
 	router.Use(middleware.Recoverer)
 
+	// Resolve named API tokens when a database is available.
+	var tokens TokenStore
+	if db != nil {
+		tokens = db
+	}
+	router.Use(apiOnly(Authenticate(apiToken, tokens)))
+
 	s := &Server{
 		router: router,
 		port:   port,
@@ -40,33 +60,43 @@ func NewServer(port int, apiToken string, db *store.Store) *Server {
 	// Health endpoint — unauthenticated (used by load balancers).
 	router.Get("/health", s.health)
 
+	// API contract — unauthenticated so clients can discover it.
+	router.Get("/api/v1/openapi.json", s.openapi)
+
+	// Prometheus metrics — unauthenticated for scrapers; not part of the JSON API.
+	router.Method(http.MethodGet, "/metrics", metrics.Handler())
+
 	// API routes — protected by Bearer token auth.
 	router.Route("/api/v1", func(r chi.Router) {
 		r.Use(BearerAuthMiddleware(apiToken))
-		r.Get("/dredd/status", s.status)
-		r.Post("/dedup", s.dedup)
+		r.With(RequireScope(auth.ScopeRead)).Get("/dredd/status", s.status)
 	})
 
 	return s
 }
 
-// BearerAuthMiddleware validates the Authorization header against a static
-// Bearer token. If the token is empty, all requests are allowed (so the
-// service can run without auth during development).
+// BearerAuthMiddleware requires an authenticated caller: one resolved by
+// Authenticate, or one presenting the static Bearer token. If the static token
+// is empty and no named tokens have been issued, all requests are allowed (so
+// the service can run without auth during development).
 func BearerAuthMiddleware(token string) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			if token == "" {
-				// No token configured — allow all requests.
+			if PrincipalFrom(r.Context()) != nil {
 				next.ServeHTTP(w, r)
 				return
 			}
-			auth := r.Header.Get("Authorization")
-			if !strings.HasPrefix(auth, "Bearer ") || auth[7:] != token {
-				http.Error(w, `{"error":"unauthorized"}`, http.StatusUnauthorized)
+			if token == "" {
+				if issued, _ := r.Context().Value(tokensIssuedKey).(bool); !issued {
+					// No token configured — allow all requests.
+					next.ServeHTTP(w, r)
+					return
+				}
+			} else if bearer := bearerToken(r); bearer != "" && auth.Equal(bearer, token) {
+				next.ServeHTTP(w, withPrincipal(r, staticPrincipal()))
 				return
 			}
-			next.ServeHTTP(w, r)
+			http.Error(w, `{"error":"unauthorized"}`, http.StatusUnauthorized)
 		})
 	}
 }
@@ -74,10 +104,15 @@ func BearerAuthMiddleware(token string) func(http.Handler) http.Handler {
 func (s *Server) Start() error {
 	addr := fmt.Sprintf(":%d", s.port)
 	slog.Info("API server starting", "addr", addr)
+	// Request contexts are cancelled on Shutdown so long-lived streams end
+	// instead of holding the drain open until its deadline.
+	baseCtx, cancel := context.WithCancel(context.Background())
 	s.httpServer = &http.Server{
-		Addr:    addr,
-		Handler: s.router,
+		Addr:        addr,
+		Handler:     s.router,
+		BaseContext: func(net.Listener) context.Context { return baseCtx },
 	}
+	s.httpServer.RegisterOnShutdown(cancel)
 	return s.httpServer.ListenAndServe()
 }
 
@@ -92,84 +127,18 @@ func (s *Server) Shutdown(ctx context.Context) error {
 func (s *Server) health(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
-	json.NewEncoder(w).Encode(map[string]string{"status": "ok"})
+	json.NewEncoder(w).Encode(HealthResponse{Status: "ok"})
 }
 
 func (s *Server) status(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
-	json.NewEncoder(w).Encode(map[string]string{
-		"agent":  "dredd",
-		"status": "shadow", // Phase 1: shadow mode
+	json.NewEncoder(w).Encode(StatusResponse{
+		Agent:  "dredd",
+		Status: string(autonomy.DefaultMode),
 	})
 }
 
-func (s *Server) dedup(w http.ResponseWriter, r *http.Request) {
-	var req DedupRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, fmt.Sprintf(`{"error":"invalid JSON: %v"}`, err), http.StatusBadRequest)
-		return
-	}
-
-	// Set defaults
-	if req.Threshold == 0 {
-		req.Threshold = 0.92
-	}
-	if req.Table == "" {
-		req.Table = "all"
-	}
-
-	// Validate threshold
-	if req.Threshold < 0.0 || req.Threshold > 1.0 {
-		http.Error(w, `{"error":"threshold must be between 0.0 and 1.0"}`, http.StatusBadRequest)
-		return
-	}
-
-	// Validate table
-	if req.Table != "patterns" && req.Table != "decisions" && req.Table != "all" {
-		http.Error(w, `{"error":"table must be 'patterns', 'decisions', or 'all'"}`, http.StatusBadRequest)
-		return
-	}
-
-	ctx := r.Context()
-	logger := slog.Default()
-
-	// Collect results
-	var results []interface{}
-
-	// Execute deduplication
-	if req.Table == "patterns" || req.Table == "all" {
-		result, err := s.store.DeduplicateReasoningPatterns(ctx, req.Threshold, req.Execute, logger)
-		if err != nil {
-			slog.Error("failed to deduplicate reasoning patterns", "error", err)
-			http.Error(w, fmt.Sprintf(`{"error":"failed to deduplicate reasoning patterns: %v"}`, err), http.StatusInternalServerError)
-			return
-		}
-		results = append(results, result)
-	}
-
-	if req.Table == "decisions" || req.Table == "all" {
-		result, err := s.store.DeduplicateDecisions(ctx, req.Threshold, req.Execute, logger)
-		if err != nil {
-			slog.Error("failed to deduplicate decisions", "error", err)
-			http.Error(w, fmt.Sprintf(`{"error":"failed to deduplicate decisions: %v"}`, err), http.StatusInternalServerError)
-			return
-		}
-		results = append(results, result)
-	}
-
-	// Return results
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusOK)
-	
-	// If single table, return the result directly; if all, return array
-	if len(results) == 1 {
-		json.NewEncoder(w).Encode(results[0])
-	} else {
-		json.NewEncoder(w).Encode(results)
-	}
-}
-
 // Router returns the internal router for adding additional routes
 func (s *Server) Router() *chi.Mux {
 	return s.router
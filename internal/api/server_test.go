@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 )
 
@@ -40,6 +41,23 @@ func TestHealthEndpoint_NoAuthRequired(t *testing.T) {
 	}
 }
 
+func TestMetricsEndpoint_NoAuthRequired(t *testing.T) {
+	srv := NewServer(8750, "some-secret", nil)
+
+	req := httptest.NewRequest("GET", "/metrics", nil)
+	w := httptest.NewRecorder()
+	srv.router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200 without auth header, got %d", w.Code)
+	}
+	for _, name := range []string{"dredd_transcripts_received_total", "dredd_extraction_duration_seconds", "go_goroutines"} {
+		if !strings.Contains(w.Body.String(), name) {
+			t.Errorf("expected %s in metrics output", name)
+		}
+	}
+}
+
 func TestStatusEndpoint_WithValidToken(t *testing.T) {
 	srv := NewServer(8750, "test-token", nil)
 
@@ -0,0 +1,109 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/MikeSquared-Agency/dredd/internal/auth"
+	"github.com/MikeSquared-Agency/dredd/internal/store"
+)
+
+// AddShadowRoutes adds shadow-mode prediction endpoints to an existing router
+type AgreementResponse struct {
+	Stages        []store.AgreementStat `json:"stages"`
+	Resolved      int                   `json:"resolved"`
+	Agreed        int                   `json:"agreed"`
+	AgreementRate float64               `json:"agreement_rate"`
+}
+
+type PredictionListResponse struct {
+	Predictions []store.GatePrediction `json:"predictions"`
+	Count       int                    `json:"count"`
+}
+
+func AddShadowRoutes(router chi.Router, apiToken string, store *store.Store) {
+	router.Route("/api/v1/shadow", func(r chi.Router) {
+		r.Use(BearerAuthMiddleware(apiToken))
+
+		handler := &shadowHandler{store: store}
+
+		r.With(RequireScope(auth.ScopeRead)).Get("/agreement", handler.agreement)
+		r.With(RequireScope(auth.ScopeRead)).Get("/predictions", handler.listPredictions)
+	})
+}
+
+// shadowHandler holds dependencies for shadow-mode endpoints
+type shadowHandler struct {
+	store *store.Store
+}
+
+// agreement handles GET /api/v1/shadow/agreement
+func (h *shadowHandler) agreement(w http.ResponseWriter, r *http.Request) {
+	var since *time.Time
+	if sinceStr := r.URL.Query().Get("since"); sinceStr != "" {
+		t, err := time.Parse(time.RFC3339, sinceStr)
+		if err != nil {
+			http.Error(w, fmt.Sprintf(`{"error":"invalid since timestamp: %v"}`, err), http.StatusBadRequest)
+			return
+		}
+		since = &t
+	}
+
+	stats, err := h.store.GateAgreement(r.Context(), since)
+	if err != nil {
+		http.Error(w, fmt.Sprintf(`{"error":"agreement query failed: %v"}`, err), http.StatusInternalServerError)
+		return
+	}
+
+	// Overall rate across every stage, weighted by resolved predictions.
+	var resolved, agreed int
+	for _, s := range stats {
+		resolved += s.Resolved
+		agreed += s.Agreed
+	}
+	var overall float64
+	if resolved > 0 {
+		overall = float64(agreed) / float64(resolved)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(AgreementResponse{
+		Stages:        stats,
+		Resolved:      resolved,
+		Agreed:        agreed,
+		AgreementRate: overall,
+	})
+}
+
+// listPredictions handles GET /api/v1/shadow/predictions
+func (h *shadowHandler) listPredictions(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+
+	limit := 50
+	if limitStr := q.Get("limit"); limitStr != "" {
+		n, err := strconv.Atoi(limitStr)
+		if err != nil || n <= 0 {
+			http.Error(w, `{"error":"limit must be a positive integer"}`, http.StatusBadRequest)
+			return
+		}
+		limit = n
+	}
+
+	resolvedOnly := q.Get("resolved") == "true"
+
+	predictions, err := h.store.ListGatePredictions(r.Context(), q.Get("item_id"), q.Get("stage"), resolvedOnly, limit)
+	if err != nil {
+		http.Error(w, fmt.Sprintf(`{"error":"list predictions failed: %v"}`, err), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(PredictionListResponse{
+		Predictions: predictions,
+		Count:       len(predictions),
+	})
+}
@@ -0,0 +1,96 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/MikeSquared-Agency/dredd/internal/auth"
+	"github.com/MikeSquared-Agency/dredd/internal/bus"
+)
+
+// streamHeartbeat is how often an idle stream sends a comment line, keeping
+// proxies from closing the connection.
+const streamHeartbeat = 15 * time.Second
+
+// AddStreamRoutes adds the server-sent event stream to an existing router
+func AddStreamRoutes(router chi.Router, apiToken string, eventBus *bus.Bus) {
+	router.Route("/api/v1/stream", func(r chi.Router) {
+		r.Use(BearerAuthMiddleware(apiToken))
+
+		handler := &streamHandler{bus: eventBus}
+
+		r.With(RequireScope(auth.ScopeRead)).Get("/", handler.stream)
+	})
+}
+
+// streamHandler holds dependencies for the stream endpoint
+type streamHandler struct {
+	bus *bus.Bus
+}
+
+// stream handles GET /api/v1/stream?owner=&kind=
+//
+// Each event is sent as an SSE message whose id is the event ID, whose event
+// name is its kind, and whose data is the JSON-encoded bus.Event. kind may be
+// repeated or comma-separated.
+func (h *streamHandler) stream(w http.ResponseWriter, r *http.Request) {
+	var filter bus.Filter
+	if owner := r.URL.Query().Get("owner"); owner != "" {
+		id, err := uuid.Parse(owner)
+		if err != nil {
+			http.Error(w, `{"error":"owner must be a UUID"}`, http.StatusBadRequest)
+			return
+		}
+		filter.OwnerUUID = id.String()
+	}
+	for _, param := range r.URL.Query()["kind"] {
+		for _, kind := range strings.Split(param, ",") {
+			if !bus.ValidKind(kind) {
+				http.Error(w, fmt.Sprintf(`{"error":"kind must be one of: %s"}`, strings.Join(bus.Kinds, ", ")), http.StatusBadRequest)
+				return
+			}
+			filter.Kinds = append(filter.Kinds, kind)
+		}
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, `{"error":"streaming unsupported"}`, http.StatusInternalServerError)
+		return
+	}
+
+	events, unsubscribe := h.bus.Subscribe(filter)
+	defer unsubscribe()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	fmt.Fprint(w, ": connected\n\n")
+	flusher.Flush()
+
+	heartbeat := time.NewTicker(streamHeartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-heartbeat.C:
+			fmt.Fprint(w, ": ping\n\n")
+		case evt := <-events:
+			data, err := json.Marshal(evt)
+			if err != nil {
+				continue
+			}
+			fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", evt.ID, evt.Kind, data)
+		}
+		flusher.Flush()
+	}
+}
@@ -0,0 +1,98 @@
+package api
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/MikeSquared-Agency/dredd/internal/bus"
+)
+
+func TestStream_DeliversFilteredEvents(t *testing.T) {
+	b := bus.New()
+	srv := NewServer(8750, "", nil)
+	AddStreamRoutes(srv.Router(), "", b)
+	ts := httptest.NewServer(srv.router)
+	defer ts.Close()
+
+	owner := uuid.New()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	req, _ := http.NewRequestWithContext(ctx, "GET", ts.URL+"/api/v1/stream?owner="+owner.String()+"&kind=review.applied", nil)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected 200, got %d", resp.StatusCode)
+	}
+	if ct := resp.Header.Get("Content-Type"); ct != "text/event-stream" {
+		t.Errorf("expected text/event-stream, got %q", ct)
+	}
+
+	lines := bufio.NewScanner(resp.Body)
+	if !lines.Scan() || lines.Text() != ": connected" {
+		t.Fatalf("expected connected comment, got %q", lines.Text())
+	}
+	lines.Scan() // blank line ending the comment
+
+	b.Publish(bus.KindDecisionStored, owner, nil)     // wrong kind
+	b.Publish(bus.KindReviewApplied, uuid.New(), nil) // wrong owner
+	b.Publish(bus.KindReviewApplied, owner, bus.ReviewApplied{Kind: "decision", Verdict: "confirmed"})
+
+	var got []string
+	for lines.Scan() && lines.Text() != "" {
+		got = append(got, lines.Text())
+	}
+	if len(got) != 3 || got[0] != "id: 3" || got[1] != "event: review.applied" || !strings.HasPrefix(got[2], "data: ") {
+		t.Fatalf("unexpected message: %q", got)
+	}
+
+	var evt struct {
+		Kind      string            `json:"kind"`
+		OwnerUUID string            `json:"owner_uuid"`
+		Data      bus.ReviewApplied `json:"data"`
+	}
+	if err := json.Unmarshal([]byte(strings.TrimPrefix(got[2], "data: ")), &evt); err != nil {
+		t.Fatalf("failed to decode event: %v", err)
+	}
+	if evt.OwnerUUID != owner.String() || evt.Data.Verdict != "confirmed" {
+		t.Errorf("unexpected event: %+v", evt)
+	}
+}
+
+func TestStream_BadFilter(t *testing.T) {
+	srv := NewServer(8750, "", nil)
+	AddStreamRoutes(srv.Router(), "", bus.New())
+
+	for _, query := range []string{"owner=not-a-uuid", "kind=decision.stored,bogus"} {
+		req := httptest.NewRequest("GET", "/api/v1/stream?"+query, nil)
+		w := httptest.NewRecorder()
+		srv.router.ServeHTTP(w, req)
+
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("%s: expected 400, got %d", query, w.Code)
+		}
+	}
+}
+
+func TestStream_RequiresAuth(t *testing.T) {
+	srv := NewServer(8750, "test-token", nil)
+	AddStreamRoutes(srv.Router(), "test-token", bus.New())
+
+	req := httptest.NewRequest("GET", "/api/v1/stream", nil)
+	w := httptest.NewRecorder()
+	srv.router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("expected 401, got %d", w.Code)
+	}
+}
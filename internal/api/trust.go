@@ -0,0 +1,185 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/MikeSquared-Agency/dredd/internal/auth"
+	"github.com/MikeSquared-Agency/dredd/internal/store"
+	"github.com/MikeSquared-Agency/dredd/internal/trust"
+)
+
+// TrustView is a stored trust record with its decay-adjusted current score.
+type TrustView struct {
+	store.TrustRecord
+	CurrentScore float64 `json:"current_score"`
+}
+
+// AgentTrustResponse is every trust record for one agent.
+type AgentTrustResponse struct {
+	AgentID        string      `json:"agent_id"`
+	CurrentScore   float64     `json:"current_score"` // decision-weighted across records
+	TotalDecisions int         `json:"total_decisions"`
+	Records        []TrustView `json:"records"`
+}
+
+// TrustLeaderboardResponse is trust records ranked by current score.
+type TrustLeaderboardResponse struct {
+	Leaderboard []TrustView `json:"leaderboard"`
+	Count       int         `json:"count"`
+}
+
+// TrustHistoryResponse is the score change log of one agent, newest first.
+type TrustHistoryResponse struct {
+	AgentID string                    `json:"agent_id"`
+	History []store.TrustHistoryEntry `json:"history"`
+	Count   int                       `json:"count"`
+}
+
+// AddTrustRoutes adds trust inspection endpoints to an existing router
+func AddTrustRoutes(router chi.Router, apiToken string, store *store.Store) {
+	router.Route("/api/v1/trust", func(r chi.Router) {
+		r.Use(BearerAuthMiddleware(apiToken))
+
+		handler := &trustHandler{store: store, now: time.Now}
+
+		r.With(RequireScope(auth.ScopeRead)).Get("/", handler.leaderboard)
+		r.With(RequireScope(auth.ScopeRead)).Get("/{agent_id}", handler.getAgentTrust)
+		r.With(RequireScope(auth.ScopeRead)).Get("/{agent_id}/history", handler.history)
+	})
+}
+
+// trustHandler holds dependencies for trust endpoints
+type trustHandler struct {
+	store *store.Store
+	now   func() time.Time
+}
+
+func (h *trustHandler) view(rec store.TrustRecord) TrustView {
+	return TrustView{
+		TrustRecord:  rec,
+		CurrentScore: trust.CurrentScore(rec.TrustScore, rec.DecayRate, rec.LastSignalAt, h.now()),
+	}
+}
+
+// leaderboard handles GET /api/v1/trust
+func (h *trustHandler) leaderboard(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+
+	limit, err := parseLimit(q.Get("limit"), 50)
+	if err != nil {
+		http.Error(w, fmt.Sprintf(`{"error":%q}`, err.Error()), http.StatusBadRequest)
+		return
+	}
+
+	minDecisions := 0
+	if s := q.Get("min_decisions"); s != "" {
+		n, err := strconv.Atoi(s)
+		if err != nil || n < 0 {
+			http.Error(w, `{"error":"min_decisions must be a non-negative integer"}`, http.StatusBadRequest)
+			return
+		}
+		minDecisions = n
+	}
+
+	records, err := h.store.ListTrust(r.Context(), q.Get("category"), q.Get("severity"), minDecisions)
+	if err != nil {
+		http.Error(w, fmt.Sprintf(`{"error":"list trust failed: %v"}`, err), http.StatusInternalServerError)
+		return
+	}
+
+	// Rank by the decayed score so stale agents drop down the board.
+	board := make([]TrustView, 0, len(records))
+	for _, rec := range records {
+		board = append(board, h.view(rec))
+	}
+	sort.SliceStable(board, func(i, j int) bool {
+		return board[i].CurrentScore > board[j].CurrentScore
+	})
+	if len(board) > limit {
+		board = board[:limit]
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(TrustLeaderboardResponse{
+		Leaderboard: board,
+		Count:       len(board),
+	})
+}
+
+// getAgentTrust handles GET /api/v1/trust/{agent_id}
+func (h *trustHandler) getAgentTrust(w http.ResponseWriter, r *http.Request) {
+	agentID := chi.URLParam(r, "agent_id")
+
+	records, err := h.store.ListTrustByAgent(r.Context(), agentID)
+	if err != nil {
+		http.Error(w, fmt.Sprintf(`{"error":"get trust failed: %v"}`, err), http.StatusInternalServerError)
+		return
+	}
+	if len(records) == 0 {
+		http.Error(w, `{"error":"no trust records for agent"}`, http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(h.agentTrust(agentID, records))
+}
+
+// agentTrust summarises an agent's records, weighting each current score by
+// the number of decisions behind it.
+func (h *trustHandler) agentTrust(agentID string, records []store.TrustRecord) AgentTrustResponse {
+	resp := AgentTrustResponse{
+		AgentID: agentID,
+		Records: make([]TrustView, 0, len(records)),
+	}
+
+	var weighted float64
+	for _, rec := range records {
+		v := h.view(rec)
+		resp.Records = append(resp.Records, v)
+		resp.TotalDecisions += rec.TotalDecisions
+		weighted += v.CurrentScore * float64(rec.TotalDecisions)
+	}
+	if resp.TotalDecisions > 0 {
+		resp.CurrentScore = weighted / float64(resp.TotalDecisions)
+	}
+	return resp
+}
+
+// history handles GET /api/v1/trust/{agent_id}/history
+func (h *trustHandler) history(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+
+	limit, err := parseLimit(q.Get("limit"), 100)
+	if err != nil {
+		http.Error(w, fmt.Sprintf(`{"error":%q}`, err.Error()), http.StatusBadRequest)
+		return
+	}
+	since, err := parseTimeParam(q.Get("since"))
+	if err != nil {
+		http.Error(w, fmt.Sprintf(`{"error":"invalid since timestamp: %v"}`, err), http.StatusBadRequest)
+		return
+	}
+
+	entries, err := h.store.ListTrustHistory(r.Context(), chi.URLParam(r, "agent_id"),
+		q.Get("category"), q.Get("severity"), since, limit)
+	if err != nil {
+		http.Error(w, fmt.Sprintf(`{"error":"trust history failed: %v"}`, err), http.StatusInternalServerError)
+		return
+	}
+	if entries == nil {
+		entries = []store.TrustHistoryEntry{}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(TrustHistoryResponse{
+		AgentID: chi.URLParam(r, "agent_id"),
+		History: entries,
+		Count:   len(entries),
+	})
+}
@@ -0,0 +1,68 @@
+package api
+
+import (
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/MikeSquared-Agency/dredd/internal/store"
+)
+
+func TestAgentTrust_WeightsByDecisions(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	fresh := now.Add(-time.Hour)
+	h := &trustHandler{now: func() time.Time { return now }}
+
+	resp := h.agentTrust("developer", []store.TrustRecord{
+		{AgentID: "developer", Category: "architecture", Severity: "routine", TrustScore: 0.9, TotalDecisions: 30, DecayRate: 0.01, LastSignalAt: &fresh},
+		{AgentID: "developer", Category: "security", Severity: "critical", TrustScore: 0.3, TotalDecisions: 10, DecayRate: 0.01, LastSignalAt: &fresh},
+	})
+
+	if resp.TotalDecisions != 40 {
+		t.Errorf("expected 40 decisions, got %d", resp.TotalDecisions)
+	}
+	if want := (0.9*30 + 0.3*10) / 40; math.Abs(resp.CurrentScore-want) > 1e-9 {
+		t.Errorf("expected weighted score %f, got %f", want, resp.CurrentScore)
+	}
+	if len(resp.Records) != 2 {
+		t.Errorf("expected 2 records, got %d", len(resp.Records))
+	}
+}
+
+func TestAgentTrust_AppliesDecay(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	stale := now.Add(-10 * 24 * time.Hour)
+	h := &trustHandler{now: func() time.Time { return now }}
+
+	resp := h.agentTrust("reviewer", []store.TrustRecord{
+		{AgentID: "reviewer", TrustScore: 1.0, TotalDecisions: 5, DecayRate: 0.01, LastSignalAt: &stale},
+	})
+
+	if got := resp.Records[0].CurrentScore; got >= 1.0 || got < 0.9 {
+		t.Errorf("expected decayed score just above 0.9, got %f", got)
+	}
+	if resp.Records[0].TrustScore != 1.0 {
+		t.Errorf("expected stored score to be preserved, got %f", resp.Records[0].TrustScore)
+	}
+}
+
+func TestTrustLeaderboard_BadParams(t *testing.T) {
+	srv := NewServer(8750, "", nil)
+	AddTrustRoutes(srv.Router(), "", nil)
+
+	for _, path := range []string{
+		"/api/v1/trust?limit=zero",
+		"/api/v1/trust?min_decisions=-1",
+		"/api/v1/trust/developer/history?since=last-week",
+	} {
+		req := httptest.NewRequest("GET", path, nil)
+		w := httptest.NewRecorder()
+		srv.router.ServeHTTP(w, req)
+
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("%s: expected 400, got %d", path, w.Code)
+		}
+	}
+}
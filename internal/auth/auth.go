@@ -0,0 +1,69 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"slices"
+)
+
+// Scopes grant access to groups of API routes.
+const (
+	ScopeAll               = "*" // every scope; held by the static DREDD_API_TOKEN
+	ScopeRead              = "read"
+	ScopeReview            = "review"
+	ScopeExtract           = "extract"
+	ScopeDedupExecute      = "dedup:execute"
+	ScopeRefinementPublish = "refinement:publish"
+	ScopeAutonomyWrite     = "autonomy:write"
+)
+
+// Scopes lists every grantable scope.
+var Scopes = []string{ScopeAll, ScopeRead, ScopeReview, ScopeExtract, ScopeDedupExecute, ScopeRefinementPublish, ScopeAutonomyWrite}
+
+// TokenPrefix marks dredd API tokens so they are recognisable in logs and
+// secret scanners.
+const TokenPrefix = "dredd_"
+
+// Principal is the authenticated caller of a request.
+type Principal struct {
+	Name   string
+	Scopes []string
+}
+
+// Has reports whether p holds scope, directly or through ScopeAll.
+func (p *Principal) Has(scope string) bool {
+	return slices.Contains(p.Scopes, ScopeAll) || slices.Contains(p.Scopes, scope)
+}
+
+// ValidScope reports whether s is a known scope.
+func ValidScope(s string) bool {
+	return slices.Contains(Scopes, s)
+}
+
+// GenerateToken returns a new random token. Only its hash should be stored.
+func GenerateToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("generate token: %w", err)
+	}
+	return TokenPrefix + base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// HashToken returns the hex SHA-256 of token, the form tokens are stored and
+// looked up in. Tokens are high-entropy, so an unsalted hash is sufficient.
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// Equal compares two secrets in constant time.
+func Equal(a, b string) bool {
+	// Compare digests so the comparison time does not depend on the length of
+	// the expected secret either.
+	ha, hb := sha256.Sum256([]byte(a)), sha256.Sum256([]byte(b))
+	return subtle.ConstantTimeCompare(ha[:], hb[:]) == 1
+}
@@ -0,0 +1,57 @@
+package auth
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGenerateToken(t *testing.T) {
+	a, err := GenerateToken()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	b, _ := GenerateToken()
+
+	if !strings.HasPrefix(a, TokenPrefix) {
+		t.Errorf("expected prefix %q, got %q", TokenPrefix, a)
+	}
+	if a == b {
+		t.Error("expected distinct tokens")
+	}
+	if HashToken(a) == HashToken(b) || HashToken(a) != HashToken(a) {
+		t.Error("expected stable, distinct hashes")
+	}
+	if len(HashToken(a)) != 64 {
+		t.Errorf("expected 64 hex chars, got %d", len(HashToken(a)))
+	}
+}
+
+func TestPrincipalHas(t *testing.T) {
+	reader := &Principal{Name: "dashboard", Scopes: []string{ScopeRead}}
+	if !reader.Has(ScopeRead) {
+		t.Error("expected read scope")
+	}
+	if reader.Has(ScopeDedupExecute) {
+		t.Error("read-only token must not have dedup:execute")
+	}
+
+	admin := &Principal{Name: "static", Scopes: []string{ScopeAll}}
+	if !admin.Has(ScopeDedupExecute) || !admin.Has(ScopeReview) {
+		t.Error("expected * to grant every scope")
+	}
+}
+
+func TestEqual(t *testing.T) {
+	if !Equal("secret", "secret") {
+		t.Error("expected equal secrets to match")
+	}
+	if Equal("secret", "secret2") || Equal("", "secret") {
+		t.Error("expected different secrets not to match")
+	}
+}
+
+func TestValidScope(t *testing.T) {
+	if !ValidScope("dedup:execute") || ValidScope("dedup") {
+		t.Error("unexpected scope validation result")
+	}
+}
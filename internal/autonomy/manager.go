@@ -0,0 +1,194 @@
+package autonomy
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/MikeSquared-Agency/dredd/internal/events"
+	"github.com/MikeSquared-Agency/dredd/internal/hermes"
+	"github.com/MikeSquared-Agency/dredd/internal/store"
+)
+
+// SubjectModeChanged is the NATS subject for autonomy mode transitions.
+const SubjectModeChanged = events.SubjectAutonomyChanged
+
+// Manager evaluates, persists, and announces autonomy mode transitions.
+type Manager struct {
+	store    *store.Store
+	hermes   *hermes.Client
+	policies Policies
+	logger   *slog.Logger
+}
+
+// NewManager creates a manager using the default promotion policies.
+func NewManager(s *store.Store, h *hermes.Client, logger *slog.Logger) *Manager {
+	return &Manager{
+		store:    s,
+		hermes:   h,
+		policies: DefaultPolicies(),
+		logger:   logger,
+	}
+}
+
+// Policies returns the promotion gates in effect.
+func (m *Manager) Policies() Policies {
+	return m.policies
+}
+
+// State returns the current mode for a category/severity, or the default mode
+// if none has been recorded.
+func (m *Manager) State(ctx context.Context, category, severity string) (*store.AutonomyState, error) {
+	st, err := m.store.GetAutonomyState(ctx, category, severity)
+	if store.IsNotFound(err) {
+		return &store.AutonomyState{Category: category, Severity: severity, Mode: string(DefaultMode)}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get autonomy state: %w", err)
+	}
+	return st, nil
+}
+
+// Evaluate checks Dredd's trust record for a category/severity against the
+// promotion policy and records a transition if the mode should change.
+// Pinned (manually overridden) modes are left alone. Returns nil if unchanged.
+func (m *Manager) Evaluate(ctx context.Context, category, severity string) (*store.AutonomyTransition, error) {
+	st, err := m.State(ctx, category, severity)
+	if err != nil {
+		return nil, err
+	}
+	if st.Pinned {
+		return nil, nil
+	}
+
+	var score float64
+	var total int
+	rec, err := m.store.GetTrust(ctx, AgentID, category, severity)
+	if err != nil && !store.IsNotFound(err) {
+		return nil, fmt.Errorf("get trust: %w", err)
+	}
+	if rec != nil {
+		score, total = rec.TrustScore, rec.TotalDecisions
+	}
+
+	current := Mode(st.Mode)
+	next := m.policies.For(severity).Next(current, score, total)
+	if next == current {
+		return nil, nil
+	}
+
+	trigger := "promotion"
+	if next.level() < current.level() {
+		trigger = "demotion"
+	}
+
+	t := store.AutonomyTransition{
+		ID:             uuid.New(),
+		Category:       category,
+		Severity:       severity,
+		FromMode:       string(current),
+		ToMode:         string(next),
+		Trigger:        trigger,
+		Actor:          AgentID,
+		Reason:         fmt.Sprintf("trust %.2f over %d decisions", score, total),
+		TrustScore:     score,
+		TotalDecisions: total,
+		CreatedAt:      time.Now().UTC(),
+	}
+	if err := m.apply(ctx, t, false); err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+// EvaluateAll evaluates every category/severity Dredd has a trust record for.
+func (m *Manager) EvaluateAll(ctx context.Context) ([]store.AutonomyTransition, error) {
+	records, err := m.store.ListTrustByAgent(ctx, AgentID)
+	if err != nil {
+		return nil, fmt.Errorf("list trust: %w", err)
+	}
+
+	var transitions []store.AutonomyTransition
+	for _, rec := range records {
+		t, err := m.Evaluate(ctx, rec.Category, rec.Severity)
+		if err != nil {
+			m.logger.Error("autonomy evaluation failed", "category", rec.Category, "severity", rec.Severity, "error", err)
+			continue
+		}
+		if t != nil {
+			transitions = append(transitions, *t)
+		}
+	}
+	return transitions, nil
+}
+
+// Override sets a mode manually. A pinned override is exempt from automatic
+// evaluation until it is overridden again without pinning.
+func (m *Manager) Override(ctx context.Context, category, severity string, mode Mode, actor, reason string, pinned bool) (*store.AutonomyTransition, error) {
+	st, err := m.State(ctx, category, severity)
+	if err != nil {
+		return nil, err
+	}
+	if actor == "" {
+		actor = "api"
+	}
+
+	t := store.AutonomyTransition{
+		ID:        uuid.New(),
+		Category:  category,
+		Severity:  severity,
+		FromMode:  st.Mode,
+		ToMode:    string(mode),
+		Trigger:   "override",
+		Actor:     actor,
+		Reason:    reason,
+		CreatedAt: time.Now().UTC(),
+	}
+	if rec, err := m.store.GetTrust(ctx, AgentID, category, severity); err == nil {
+		t.TrustScore, t.TotalDecisions = rec.TrustScore, rec.TotalDecisions
+	}
+
+	if err := m.apply(ctx, t, pinned); err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+// apply persists a transition and publishes it over NATS.
+func (m *Manager) apply(ctx context.Context, t store.AutonomyTransition, pinned bool) error {
+	if err := m.store.RecordAutonomyTransition(ctx, t, pinned); err != nil {
+		return fmt.Errorf("record transition: %w", err)
+	}
+
+	m.logger.Info("autonomy mode changed",
+		"category", t.Category,
+		"severity", t.Severity,
+		"from", t.FromMode,
+		"to", t.ToMode,
+		"trigger", t.Trigger,
+		"actor", t.Actor,
+	)
+
+	if m.hermes == nil {
+		return nil
+	}
+	if err := m.hermes.Publish(SubjectModeChanged, events.AutonomyChanged{
+		SchemaVersion:  events.SchemaVersion,
+		Category:       t.Category,
+		Severity:       t.Severity,
+		FromMode:       t.FromMode,
+		ToMode:         t.ToMode,
+		Trigger:        t.Trigger,
+		Actor:          t.Actor,
+		Reason:         t.Reason,
+		Pinned:         pinned,
+		TrustScore:     t.TrustScore,
+		TotalDecisions: t.TotalDecisions,
+		Timestamp:      t.CreatedAt,
+	}); err != nil {
+		m.logger.Error("failed to publish autonomy transition", "error", err)
+	}
+	return nil
+}
@@ -0,0 +1,148 @@
+package autonomy
+
+import "fmt"
+
+// Mode is Dredd's level of authority for a category/severity pair.
+type Mode string
+
+const (
+	// ModeShadow observes and records, but never acts or advises.
+	ModeShadow Mode = "shadow"
+	// ModeAdvise surfaces recommendations; a human still decides.
+	ModeAdvise Mode = "advise"
+	// ModeAutonomous decides on its own.
+	ModeAutonomous Mode = "autonomous"
+)
+
+// DefaultMode applies to any category/severity without a stored mode.
+const DefaultMode = ModeShadow
+
+// AgentID is the agent_trust identity under which Dredd's own track record is scored.
+const AgentID = "dredd"
+
+// ParseMode validates a mode string.
+func ParseMode(s string) (Mode, error) {
+	switch Mode(s) {
+	case ModeShadow, ModeAdvise, ModeAutonomous:
+		return Mode(s), nil
+	default:
+		return "", fmt.Errorf("invalid mode %q: must be shadow, advise, or autonomous", s)
+	}
+}
+
+// ValidateSeverity checks that s is a severity decisions are recorded under.
+func ValidateSeverity(s string) error {
+	switch s {
+	case "routine", "significant", "critical":
+		return nil
+	default:
+		return fmt.Errorf("invalid severity %q: must be routine, significant, or critical", s)
+	}
+}
+
+// level orders modes from least to most authority.
+func (m Mode) level() int {
+	switch m {
+	case ModeAdvise:
+		return 1
+	case ModeAutonomous:
+		return 2
+	default:
+		return 0
+	}
+}
+
+// modeAt is the inverse of level.
+func modeAt(level int) Mode {
+	switch level {
+	case 1:
+		return ModeAdvise
+	case 2:
+		return ModeAutonomous
+	default:
+		return ModeShadow
+	}
+}
+
+// AllowedToDecide reports whether Dredd may act without a human in this mode.
+func (m Mode) AllowedToDecide() bool {
+	return m == ModeAutonomous
+}
+
+// Gate is the trust threshold a category/severity must clear to hold a mode.
+type Gate struct {
+	MinTrust     float64 `json:"min_trust"`
+	MinDecisions int     `json:"min_decisions"`
+}
+
+// Policy holds the promotion gates for a single severity.
+// A zero Autonomous gate means autonomous mode is never granted automatically.
+type Policy struct {
+	Advise     Gate `json:"advise"`
+	Autonomous Gate `json:"autonomous"`
+}
+
+// Policies maps severity to its promotion policy.
+type Policies map[string]Policy
+
+// DefaultPolicies returns the built-in gates. Higher severities need more
+// evidence, and critical decisions are never promoted to autonomous automatically.
+func DefaultPolicies() Policies {
+	return Policies{
+		"routine": {
+			Advise:     Gate{MinTrust: 0.6, MinDecisions: 20},
+			Autonomous: Gate{MinTrust: 0.85, MinDecisions: 50},
+		},
+		"significant": {
+			Advise:     Gate{MinTrust: 0.7, MinDecisions: 30},
+			Autonomous: Gate{MinTrust: 0.9, MinDecisions: 100},
+		},
+		"critical": {
+			Advise: Gate{MinTrust: 0.8, MinDecisions: 50},
+		},
+	}
+}
+
+// For returns the policy for a severity, falling back to the routine policy.
+func (p Policies) For(severity string) Policy {
+	if pol, ok := p[severity]; ok {
+		return pol
+	}
+	return p["routine"]
+}
+
+// met reports whether a trust score and decision count clear the gate.
+// A zero gate is never met.
+func (g Gate) met(score float64, total int) bool {
+	if g.MinTrust == 0 && g.MinDecisions == 0 {
+		return false
+	}
+	return score >= g.MinTrust && total >= g.MinDecisions
+}
+
+// Qualified returns the highest mode the given trust score and decision count clear.
+func (p Policy) Qualified(score float64, total int) Mode {
+	switch {
+	case p.Autonomous.met(score, total):
+		return ModeAutonomous
+	case p.Advise.met(score, total):
+		return ModeAdvise
+	default:
+		return ModeShadow
+	}
+}
+
+// Next returns the mode that follows current given the latest trust numbers.
+// Promotion moves one step at a time so every mode is held before the next;
+// demotion drops straight to the highest mode still qualified for.
+func (p Policy) Next(current Mode, score float64, total int) Mode {
+	qualified := p.Qualified(score, total)
+	switch {
+	case qualified.level() > current.level():
+		return modeAt(current.level() + 1)
+	case qualified.level() < current.level():
+		return qualified
+	default:
+		return current
+	}
+}
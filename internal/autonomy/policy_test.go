@@ -0,0 +1,107 @@
+package autonomy
+
+import "testing"
+
+func TestParseMode(t *testing.T) {
+	for _, s := range []string{"shadow", "advise", "autonomous"} {
+		if _, err := ParseMode(s); err != nil {
+			t.Errorf("ParseMode(%q) returned error: %v", s, err)
+		}
+	}
+	for _, s := range []string{"", "Shadow", "auto"} {
+		if _, err := ParseMode(s); err == nil {
+			t.Errorf("ParseMode(%q) expected error", s)
+		}
+	}
+}
+
+func TestValidateSeverity(t *testing.T) {
+	for _, s := range []string{"routine", "significant", "critical"} {
+		if err := ValidateSeverity(s); err != nil {
+			t.Errorf("ValidateSeverity(%q) returned error: %v", s, err)
+		}
+	}
+	for _, s := range []string{"", "Routine", "high"} {
+		if err := ValidateSeverity(s); err == nil {
+			t.Errorf("ValidateSeverity(%q) expected error", s)
+		}
+	}
+}
+
+func TestPolicy_Qualified(t *testing.T) {
+	p := DefaultPolicies().For("routine")
+
+	tests := []struct {
+		name  string
+		score float64
+		total int
+		want  Mode
+	}{
+		{"no history", 0.0, 0, ModeShadow},
+		{"high score but too few decisions", 0.95, 5, ModeShadow},
+		{"clears advise gate", 0.65, 25, ModeAdvise},
+		{"enough decisions but score too low for autonomous", 0.8, 100, ModeAdvise},
+		{"clears autonomous gate", 0.9, 60, ModeAutonomous},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := p.Qualified(tt.score, tt.total); got != tt.want {
+				t.Errorf("Qualified(%f, %d) = %q, want %q", tt.score, tt.total, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPolicy_Next(t *testing.T) {
+	p := DefaultPolicies().For("routine")
+
+	tests := []struct {
+		name    string
+		current Mode
+		score   float64
+		total   int
+		want    Mode
+	}{
+		{"stays in shadow", ModeShadow, 0.1, 5, ModeShadow},
+		{"promotes one step only", ModeShadow, 0.95, 200, ModeAdvise},
+		{"advise to autonomous", ModeAdvise, 0.95, 200, ModeAutonomous},
+		{"holds autonomous", ModeAutonomous, 0.9, 60, ModeAutonomous},
+		{"demotes one step", ModeAutonomous, 0.7, 60, ModeAdvise},
+		{"demotes straight to shadow", ModeAutonomous, 0.2, 60, ModeShadow},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := p.Next(tt.current, tt.score, tt.total); got != tt.want {
+				t.Errorf("Next(%q, %f, %d) = %q, want %q", tt.current, tt.score, tt.total, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDefaultPolicies_CriticalNeverAutonomous(t *testing.T) {
+	p := DefaultPolicies().For("critical")
+	if got := p.Qualified(1.0, 10000); got != ModeAdvise {
+		t.Errorf("critical with perfect record qualified for %q, want advise", got)
+	}
+	if got := p.Next(ModeAdvise, 1.0, 10000); got != ModeAdvise {
+		t.Errorf("critical advise promoted to %q, want advise", got)
+	}
+}
+
+func TestPolicies_ForUnknownSeverity(t *testing.T) {
+	p := DefaultPolicies()
+	if p.For("banana") != p.For("routine") {
+		t.Error("unknown severity should fall back to routine policy")
+	}
+}
+
+func TestMode_AllowedToDecide(t *testing.T) {
+	if ModeShadow.AllowedToDecide() || ModeAdvise.AllowedToDecide() {
+		t.Error("only autonomous mode may decide")
+	}
+	if !ModeAutonomous.AllowedToDecide() {
+		t.Error("autonomous mode should be allowed to decide")
+	}
+}
@@ -0,0 +1,187 @@
+// Package bus is an in-process publish/subscribe hub for pipeline events.
+// The processor and API publish as work happens; the SSE stream and any
+// other in-process consumers subscribe with a filter. Unlike NATS it carries
+// Dredd's own activity for live views and is never persisted.
+package bus
+
+import (
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Event kinds.
+const (
+	KindTranscriptProcessed = "transcript.processed"
+	KindDecisionStored      = "decision.stored"
+	KindPatternStored       = "pattern.stored"
+	KindReviewApplied       = "review.applied"
+	KindTrustChanged        = "trust.changed"
+	KindRefinementProposed  = "refinement.proposed"
+)
+
+// Kinds lists every event kind.
+var Kinds = []string{
+	KindTranscriptProcessed,
+	KindDecisionStored,
+	KindPatternStored,
+	KindReviewApplied,
+	KindTrustChanged,
+	KindRefinementProposed,
+}
+
+// ValidKind reports whether kind is a known event kind.
+func ValidKind(kind string) bool {
+	for _, k := range Kinds {
+		if k == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// subscriberBuffer is how many events a subscriber may fall behind before
+// further events to it are dropped.
+const subscriberBuffer = 256
+
+// Event is one published event. IDs increase by one per event, so a gap seen
+// by a subscriber means it fell behind and events were dropped.
+type Event struct {
+	ID        uint64    `json:"id"`
+	Kind      string    `json:"kind"`
+	OwnerUUID string    `json:"owner_uuid,omitempty"` // empty for events not tied to one owner
+	Time      time.Time `json:"time"`
+	Data      any       `json:"data"`
+}
+
+// Filter selects events for a subscriber.
+type Filter struct {
+	OwnerUUID string   // empty matches every event; otherwise only that owner's
+	Kinds     []string // empty matches every kind
+}
+
+// Match reports whether evt passes the filter.
+func (f Filter) Match(evt Event) bool {
+	if f.OwnerUUID != "" && evt.OwnerUUID != f.OwnerUUID {
+		return false
+	}
+	if len(f.Kinds) == 0 {
+		return true
+	}
+	for _, k := range f.Kinds {
+		if k == evt.Kind {
+			return true
+		}
+	}
+	return false
+}
+
+// Bus fans events out to subscribers. A nil *Bus discards published events,
+// so publishers need not check whether one is configured.
+type Bus struct {
+	mu   sync.Mutex
+	seq  uint64
+	subs map[chan Event]Filter
+}
+
+// New creates an empty bus.
+func New() *Bus {
+	return &Bus{subs: make(map[chan Event]Filter)}
+}
+
+// Publish sends an event to every matching subscriber without blocking; a
+// subscriber whose buffer is full misses the event. owner may be uuid.Nil for
+// events not tied to one owner.
+func (b *Bus) Publish(kind string, owner uuid.UUID, data any) {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.seq++
+	evt := Event{ID: b.seq, Kind: kind, Time: time.Now().UTC(), Data: data}
+	if owner != uuid.Nil {
+		evt.OwnerUUID = owner.String()
+	}
+	for ch, f := range b.subs {
+		if !f.Match(evt) {
+			continue
+		}
+		select {
+		case ch <- evt:
+		default:
+		}
+	}
+}
+
+// Subscribe returns a channel of events matching f and a function that
+// unsubscribes and closes the channel. A nil Bus never sends on the channel.
+func (b *Bus) Subscribe(f Filter) (<-chan Event, func()) {
+	ch := make(chan Event, subscriberBuffer)
+	if b == nil {
+		var once sync.Once
+		return ch, func() { once.Do(func() { close(ch) }) }
+	}
+	b.mu.Lock()
+	b.subs[ch] = f
+	b.mu.Unlock()
+
+	var once sync.Once
+	return ch, func() {
+		once.Do(func() {
+			b.mu.Lock()
+			delete(b.subs, ch)
+			b.mu.Unlock()
+			close(ch)
+		})
+	}
+}
+
+// TranscriptProcessed is the data of a transcript.processed event.
+type TranscriptProcessed struct {
+	SessionRef  string      `json:"session_ref"`
+	DecisionIDs []uuid.UUID `json:"decision_ids"`
+	PatternIDs  []uuid.UUID `json:"pattern_ids"`
+}
+
+// DecisionStored is the data of a decision.stored event.
+type DecisionStored struct {
+	ID         uuid.UUID `json:"id"`
+	SessionRef string    `json:"session_ref"`
+	Source     string    `json:"source"`
+	Domain     string    `json:"domain"`
+	Category   string    `json:"category"`
+	Severity   string    `json:"severity"`
+	Summary    string    `json:"summary"`
+	Recurrence bool      `json:"recurrence,omitempty"` // counted as an occurrence of an existing decision
+}
+
+// PatternStored is the data of a pattern.stored event.
+type PatternStored struct {
+	ID          uuid.UUID `json:"id"`
+	SessionRef  string    `json:"session_ref"`
+	PatternType string    `json:"pattern_type"`
+	Summary     string    `json:"summary"`
+	Recurrence  bool      `json:"recurrence,omitempty"` // counted as an occurrence of an existing pattern
+}
+
+// ReviewApplied is the data of a review.applied event.
+type ReviewApplied struct {
+	Kind     string    `json:"kind"` // decision | pattern | refinement
+	ID       uuid.UUID `json:"id"`
+	Verdict  string    `json:"verdict"`
+	Reviewer string    `json:"reviewer,omitempty"`
+	Channel  string    `json:"channel"`
+}
+
+// TrustChanged is the data of a trust.changed event.
+type TrustChanged struct {
+	AgentID       string  `json:"agent_id"`
+	Category      string  `json:"category"`
+	Severity      string  `json:"severity"`
+	Correct       bool    `json:"correct"`
+	PreviousScore float64 `json:"previous_score"`
+	Score         float64 `json:"score"`
+}
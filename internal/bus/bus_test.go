@@ -0,0 +1,78 @@
+package bus
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestBus_FiltersByOwnerAndKind(t *testing.T) {
+	b := New()
+	owner := uuid.New()
+
+	all, cancelAll := b.Subscribe(Filter{})
+	defer cancelAll()
+	mine, cancelMine := b.Subscribe(Filter{OwnerUUID: owner.String(), Kinds: []string{KindPatternStored}})
+	defer cancelMine()
+
+	b.Publish(KindDecisionStored, owner, nil)
+	b.Publish(KindPatternStored, uuid.New(), nil)
+	b.Publish(KindPatternStored, owner, PatternStored{Summary: "mine"})
+	b.Publish(KindTrustChanged, uuid.Nil, nil)
+
+	if got := len(all); got != 4 {
+		t.Errorf("unfiltered subscriber: expected 4 events, got %d", got)
+	}
+	if got := len(mine); got != 1 {
+		t.Fatalf("filtered subscriber: expected 1 event, got %d", got)
+	}
+	evt := <-mine
+	if evt.Kind != KindPatternStored || evt.OwnerUUID != owner.String() || evt.ID != 3 {
+		t.Errorf("unexpected event: %+v", evt)
+	}
+	if evt.Data.(PatternStored).Summary != "mine" {
+		t.Errorf("unexpected data: %+v", evt.Data)
+	}
+}
+
+func TestBus_DropsForSlowSubscriber(t *testing.T) {
+	b := New()
+	ch, cancel := b.Subscribe(Filter{})
+	defer cancel()
+
+	for i := 0; i < subscriberBuffer+10; i++ {
+		b.Publish(KindDecisionStored, uuid.Nil, nil)
+	}
+
+	if got := len(ch); got != subscriberBuffer {
+		t.Errorf("expected a full buffer of %d, got %d", subscriberBuffer, got)
+	}
+}
+
+func TestBus_Unsubscribe(t *testing.T) {
+	b := New()
+	ch, cancel := b.Subscribe(Filter{})
+	cancel()
+	cancel() // idempotent
+
+	b.Publish(KindDecisionStored, uuid.Nil, nil)
+	if _, ok := <-ch; ok {
+		t.Error("expected closed channel after unsubscribe")
+	}
+}
+
+func TestBus_NilDiscards(t *testing.T) {
+	var b *Bus
+	b.Publish(KindDecisionStored, uuid.Nil, nil) // must not panic
+
+	ch, cancel := b.Subscribe(Filter{})
+	select {
+	case <-ch:
+		t.Error("expected no events from a nil bus")
+	default:
+	}
+	cancel()
+	if _, ok := <-ch; ok {
+		t.Error("expected closed channel after unsubscribe")
+	}
+}
@@ -0,0 +1,65 @@
+// Package chronicle fetches session transcripts from Chronicle's HTTP API.
+package chronicle
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// ErrNoTranscript is returned when Chronicle has no events for a session.
+var ErrNoTranscript = errors.New("no transcript")
+
+// Client reads session events from Chronicle.
+type Client struct {
+	baseURL string
+	client  *http.Client
+}
+
+// New creates a client for the Chronicle API at baseURL.
+func New(baseURL string) *Client {
+	return &Client{baseURL: baseURL, client: http.DefaultClient}
+}
+
+// Transcript returns the raw events JSON of a session, which the extractor
+// takes as the transcript. It returns ErrNoTranscript when Chronicle does not
+// know the session or has no events for it.
+func (c *Client) Transcript(ctx context.Context, sessionID string) (string, error) {
+	url := fmt.Sprintf("%s/api/v1/events?trace_id=%s", c.baseURL, sessionID)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return "", fmt.Errorf("build chronicle request: %w", err)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("chronicle request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return "", fmt.Errorf("%w in chronicle for session %s", ErrNoTranscript, sessionID)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("chronicle returned %d for session %s", resp.StatusCode, sessionID)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("read chronicle response: %w", err)
+	}
+
+	var events []struct {
+		Metadata json.RawMessage `json:"metadata"`
+	}
+	if err := json.Unmarshal(body, &events); err != nil {
+		return "", fmt.Errorf("parse chronicle events: %w", err)
+	}
+	if len(events) == 0 {
+		return "", fmt.Errorf("%w in chronicle for session %s", ErrNoTranscript, sessionID)
+	}
+	return string(body), nil
+}
@@ -17,6 +17,15 @@ type Config struct {
 	SlackChannel    string
 	ChronicleURL    string
 	APIToken        string
+	EmbeddingURL    string
+	EmbeddingAPIKey string
+	EmbeddingModel  string
+	SOULSource      string
+	SOULMapping     string
+	// MatchThreshold is the similarity at which a newly extracted decision or
+	// pattern is recorded as an occurrence of an existing one; 0 disables it.
+	// Needs EmbeddingURL.
+	MatchThreshold float64
 }
 
 func Load() Config {
@@ -32,6 +41,12 @@ func Load() Config {
 		SlackChannel:    envStr("SLACK_DECISIONS_CHANNEL", ""),
 		ChronicleURL:    envStr("CHRONICLE_URL", "http://chronicle:8700"),
 		APIToken:        envStr("DREDD_API_TOKEN", ""),
+		EmbeddingURL:    envStr("EMBEDDING_URL", ""),
+		EmbeddingAPIKey: envStr("EMBEDDING_API_KEY", ""),
+		EmbeddingModel:  envStr("EMBEDDING_MODEL", "text-embedding-3-small"),
+		SOULSource:      envStr("SOUL_SOURCE", ""),
+		SOULMapping:     envStr("SOUL_MAPPING", ""),
+		MatchThreshold:  envFloat("MATCH_THRESHOLD", 0),
 	}
 }
 
@@ -50,3 +65,12 @@ func envInt(key string, fallback int) int {
 	}
 	return fallback
 }
+
+func envFloat(key string, fallback float64) float64 {
+	if v := os.Getenv(key); v != "" {
+		if f, err := strconv.ParseFloat(v, 64); err == nil {
+			return f
+		}
+	}
+	return fallback
+}
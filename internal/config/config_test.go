@@ -10,6 +10,8 @@ func TestLoad_Defaults(t *testing.T) {
 		"DREDD_PORT", "NATS_URL", "NATS_TOKEN", "DATABASE_URL", "LOG_LEVEL",
 		"ANTHROPIC_API_KEY", "DREDD_MODEL", "SLACK_BOT_TOKEN",
 		"SLACK_DECISIONS_CHANNEL", "CHRONICLE_URL", "DREDD_API_TOKEN",
+		"EMBEDDING_URL", "EMBEDDING_API_KEY", "EMBEDDING_MODEL", "SOUL_SOURCE", "SOUL_MAPPING",
+		"MATCH_THRESHOLD",
 	} {
 		t.Setenv(key, "")
 	}
@@ -38,6 +40,15 @@ func TestLoad_Defaults(t *testing.T) {
 	if cfg.APIToken != "" {
 		t.Errorf("expected empty default api token, got %s", cfg.APIToken)
 	}
+	if cfg.EmbeddingURL != "" {
+		t.Errorf("expected embeddings disabled by default, got %s", cfg.EmbeddingURL)
+	}
+	if cfg.EmbeddingModel != "text-embedding-3-small" {
+		t.Errorf("expected default embedding model, got %s", cfg.EmbeddingModel)
+	}
+	if cfg.MatchThreshold != 0 {
+		t.Errorf("expected write-time matching disabled by default, got %v", cfg.MatchThreshold)
+	}
 }
 
 func TestLoad_CustomValues(t *testing.T) {
@@ -52,6 +63,7 @@ func TestLoad_CustomValues(t *testing.T) {
 	t.Setenv("SLACK_DECISIONS_CHANNEL", "C12345")
 	t.Setenv("CHRONICLE_URL", "http://localhost:8700")
 	t.Setenv("DREDD_API_TOKEN", "dredd-secret-token")
+	t.Setenv("MATCH_THRESHOLD", "0.93")
 
 	cfg := Load()
 
@@ -88,6 +100,9 @@ func TestLoad_CustomValues(t *testing.T) {
 	if cfg.APIToken != "dredd-secret-token" {
 		t.Errorf("expected custom api token, got %s", cfg.APIToken)
 	}
+	if cfg.MatchThreshold != 0.93 {
+		t.Errorf("expected custom match threshold, got %v", cfg.MatchThreshold)
+	}
 }
 
 func TestLoad_InvalidPort(t *testing.T) {
@@ -0,0 +1,379 @@
+// Package dataset builds fine-tuning datasets from reviewed extractions.
+//
+// Each example is one transcript session: the prompt is the production
+// extraction prompt over the session's transcript and the target is what
+// reviewers confirmed. Transcripts are not stored, so they are fetched again
+// by session; a session whose transcript is gone is skipped, since the items'
+// own excerpts would put the expected output in the prompt.
+package dataset
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/rand/v2"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/MikeSquared-Agency/dredd/internal/chronicle"
+	"github.com/MikeSquared-Agency/dredd/internal/extractor"
+	"github.com/MikeSquared-Agency/dredd/internal/store"
+)
+
+// Formats.
+const (
+	// FormatChat is chat fine-tuning: system and user prompt, then the
+	// confirmed extraction as the assistant reply.
+	FormatChat = "chat"
+	// FormatPreference is preference pairs: the confirmed extraction is
+	// preferred over the rejected one for the same prompt.
+	FormatPreference = "preference"
+)
+
+// Splits.
+const (
+	SplitTrain      = "train"
+	SplitValidation = "validation"
+)
+
+// pageSize is how many rows are read per listing call.
+const pageSize = 500
+
+// Source lists reviewed extractions. *store.Store implements it.
+type Source interface {
+	ListDecisions(ctx context.Context, f store.DecisionFilter) ([]store.DecisionDetail, *store.Cursor, error)
+	ListPatterns(ctx context.Context, f store.PatternFilter) ([]store.PatternRow, *store.Cursor, error)
+}
+
+// Transcripts fetches the transcript of a session by the session ref its
+// extractions were stored under. *chronicle.Client implements it, returning
+// chronicle.ErrNoTranscript for a session it does not have.
+type Transcripts interface {
+	Transcript(ctx context.Context, sessionRef string) (string, error)
+}
+
+// Options selects and splits the examples.
+type Options struct {
+	Format     string     // chat | preference
+	Kind       string     // decision | pattern; empty for both
+	Owner      uuid.UUID  // uuid.Nil for every owner
+	Since      *time.Time // inclusive, on extraction time
+	Until      *time.Time // exclusive
+	Validation float64    // fraction of examples in the validation split, [0, 1)
+	Seed       uint64     // shuffle seed; the same seed and data give the same split
+}
+
+// Validate checks the options.
+func (o Options) Validate() error {
+	if o.Format != FormatChat && o.Format != FormatPreference {
+		return fmt.Errorf("format must be %q or %q", FormatChat, FormatPreference)
+	}
+	if o.Kind != "" && o.Kind != "decision" && o.Kind != "pattern" {
+		return fmt.Errorf("kind must be 'decision' or 'pattern'")
+	}
+	if o.Validation < 0 || o.Validation >= 1 {
+		return fmt.Errorf("validation fraction must be in [0, 1)")
+	}
+	return nil
+}
+
+// Message is one chat turn.
+type Message struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+// Input is the prompt of a preference example.
+type Input struct {
+	Messages []Message `json:"messages"`
+}
+
+// Example is one JSONL line. Chat examples set Messages; preference examples
+// set Input, PreferredOutput and NonPreferredOutput.
+type Example struct {
+	Messages           []Message `json:"messages,omitempty"`
+	Input              *Input    `json:"input,omitempty"`
+	PreferredOutput    []Message `json:"preferred_output,omitempty"`
+	NonPreferredOutput []Message `json:"non_preferred_output,omitempty"`
+}
+
+// Dataset is a shuffled train/validation split.
+type Dataset struct {
+	Train      []Example
+	Validation []Example
+	Skipped    int // labelled sessions left out because their transcript is gone
+}
+
+// Split returns the examples of the named split.
+func (d *Dataset) Split(name string) ([]Example, error) {
+	switch name {
+	case SplitTrain:
+		return d.Train, nil
+	case SplitValidation:
+		return d.Validation, nil
+	default:
+		return nil, fmt.Errorf("split must be %q or %q", SplitTrain, SplitValidation)
+	}
+}
+
+// session collects the reviewed items of one transcript session.
+type session struct {
+	ref       string
+	owner     uuid.UUID
+	confirmed extractor.ExtractionResult
+	rejected  extractor.ExtractionResult
+}
+
+// Build reads reviewed extractions from src, fetches their sessions'
+// transcripts from transcripts, and returns the dataset.
+func Build(ctx context.Context, src Source, transcripts Transcripts, opts Options) (*Dataset, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+
+	sessions := map[string]*session{}
+	get := func(owner uuid.UUID, ref string) *session {
+		key := owner.String() + "/" + ref
+		s, ok := sessions[key]
+		if !ok {
+			s = &session{ref: ref, owner: owner}
+			sessions[key] = s
+		}
+		return s
+	}
+
+	for _, status := range []string{"confirmed", "rejected"} {
+		if opts.Kind != "pattern" {
+			decisions, err := listDecisions(ctx, src, store.DecisionFilter{
+				ReviewStatus: status,
+				Source:       "dredd", // transcript extractions only, not gate or task captures
+				Owner:        opts.Owner,
+				Since:        opts.Since,
+				Until:        opts.Until,
+			})
+			if err != nil {
+				return nil, err
+			}
+			for _, d := range decisions {
+				s := get(d.DecidedBy, d.SessionRef)
+				ep := decisionEpisode(d)
+				if status == "confirmed" {
+					s.confirmed.Decisions = append(s.confirmed.Decisions, ep)
+				} else {
+					s.rejected.Decisions = append(s.rejected.Decisions, ep)
+				}
+			}
+		}
+
+		if opts.Kind != "decision" {
+			patterns, err := listPatterns(ctx, src, store.PatternFilter{
+				ReviewStatus: status,
+				Owner:        opts.Owner,
+				Since:        opts.Since,
+				Until:        opts.Until,
+			})
+			if err != nil {
+				return nil, err
+			}
+			for _, p := range patterns {
+				s := get(p.OwnerUUID, p.SessionRef)
+				pat := extractor.ReasoningPattern{
+					PatternType:     p.PatternType,
+					Summary:         p.Summary,
+					ConversationArc: p.ConversationArc,
+					Tags:            p.Tags,
+					Confidence:      p.Confidence,
+				}
+				if status == "confirmed" {
+					s.confirmed.Patterns = append(s.confirmed.Patterns, pat)
+				} else {
+					s.rejected.Patterns = append(s.rejected.Patterns, pat)
+				}
+			}
+		}
+	}
+
+	// Sort before shuffling so the split depends only on the data and seed.
+	keys := make([]string, 0, len(sessions))
+	for key := range sessions {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var examples []Example
+	skipped := 0
+	for _, key := range keys {
+		s := sessions[key]
+		if !labelled(s, opts.Format) {
+			continue
+		}
+		transcript, err := transcripts.Transcript(ctx, s.ref)
+		if errors.Is(err, chronicle.ErrNoTranscript) || (err == nil && transcript == "") {
+			skipped++
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("fetch transcript for session %s: %w", s.ref, err)
+		}
+		ex, err := example(s, transcript, opts.Format)
+		if err != nil {
+			return nil, err
+		}
+		examples = append(examples, ex)
+	}
+
+	rng := rand.New(rand.NewPCG(opts.Seed, 0))
+	rng.Shuffle(len(examples), func(i, j int) { examples[i], examples[j] = examples[j], examples[i] })
+
+	nVal := int(math.Round(float64(len(examples)) * opts.Validation))
+	return &Dataset{
+		Train:      examples[:len(examples)-nVal],
+		Validation: examples[len(examples)-nVal:],
+		Skipped:    skipped,
+	}, nil
+}
+
+// labelled reports whether a session has the labels the format needs.
+func labelled(s *session, format string) bool {
+	hasConfirmed := len(s.confirmed.Decisions)+len(s.confirmed.Patterns) > 0
+	hasRejected := len(s.rejected.Decisions)+len(s.rejected.Patterns) > 0
+	if format == FormatChat {
+		return hasConfirmed
+	}
+	return hasConfirmed && hasRejected
+}
+
+// example renders a labelled session over its transcript in the given format.
+func example(s *session, transcript, format string) (Example, error) {
+	system, user := extractor.Prompt(s.ref, s.owner, transcript)
+	prompt := []Message{{Role: "system", Content: system}, {Role: "user", Content: user}}
+
+	switch format {
+	case FormatChat:
+		reply, err := completion(s.confirmed)
+		if err != nil {
+			return Example{}, err
+		}
+		return Example{Messages: append(prompt, reply)}, nil
+
+	default: // FormatPreference
+		chosen, err := completion(s.confirmed)
+		if err != nil {
+			return Example{}, err
+		}
+		rejected, err := completion(s.rejected)
+		if err != nil {
+			return Example{}, err
+		}
+		return Example{
+			Input:              &Input{Messages: prompt},
+			PreferredOutput:    []Message{chosen},
+			NonPreferredOutput: []Message{rejected},
+		}, nil
+	}
+}
+
+// completion renders an extraction as the assistant reply the extraction
+// prompt asks for.
+func completion(r extractor.ExtractionResult) (Message, error) {
+	body := struct {
+		Decisions []extractor.DecisionEpisode  `json:"decisions"`
+		Patterns  []extractor.ReasoningPattern `json:"patterns"`
+		Styles    []extractor.WritingStyle     `json:"styles"`
+	}{
+		Decisions: r.Decisions,
+		Patterns:  r.Patterns,
+		Styles:    []extractor.WritingStyle{},
+	}
+	if body.Decisions == nil {
+		body.Decisions = []extractor.DecisionEpisode{}
+	}
+	if body.Patterns == nil {
+		body.Patterns = []extractor.ReasoningPattern{}
+	}
+	b, err := json.Marshal(body)
+	if err != nil {
+		return Message{}, fmt.Errorf("marshal completion: %w", err)
+	}
+	return Message{Role: "assistant", Content: string(b)}, nil
+}
+
+// decisionEpisode converts a stored decision back into the extraction shape.
+// The extracted confidence is not stored, so reviewed decisions carry 1.
+func decisionEpisode(d store.DecisionDetail) extractor.DecisionEpisode {
+	ep := extractor.DecisionEpisode{
+		Domain:        d.Domain,
+		Category:      d.Category,
+		Severity:      d.Severity,
+		Summary:       d.Summary,
+		SituationText: d.Situation,
+		Options:       []extractor.DecisionOption{},
+		Tags:          d.Tags,
+		Confidence:    1,
+		AgentID:       d.AgentID,
+		SignalType:    d.SignalType,
+	}
+	for _, o := range d.Options {
+		ep.Options = append(ep.Options, extractor.DecisionOption{
+			OptionKey:  o.OptionKey,
+			ProSignals: o.ProSignals,
+			ConSignals: o.ConSignals,
+			WasChosen:  o.WasChosen,
+		})
+	}
+	if d.Reasoning != nil {
+		ep.Reasoning = extractor.DecisionReasoning{
+			Factors:       d.Reasoning.Factors,
+			Tradeoffs:     d.Reasoning.Tradeoffs,
+			ReasoningText: d.Reasoning.ReasoningText,
+		}
+	}
+	return ep
+}
+
+func listDecisions(ctx context.Context, src Source, f store.DecisionFilter) ([]store.DecisionDetail, error) {
+	f.Limit = pageSize
+	var all []store.DecisionDetail
+	for {
+		page, next, err := src.ListDecisions(ctx, f)
+		if err != nil {
+			return nil, fmt.Errorf("list decisions: %w", err)
+		}
+		all = append(all, page...)
+		if next == nil {
+			return all, nil
+		}
+		f.After = next
+	}
+}
+
+func listPatterns(ctx context.Context, src Source, f store.PatternFilter) ([]store.PatternRow, error) {
+	f.Limit = pageSize
+	var all []store.PatternRow
+	for {
+		page, next, err := src.ListPatterns(ctx, f)
+		if err != nil {
+			return nil, fmt.Errorf("list patterns: %w", err)
+		}
+		all = append(all, page...)
+		if next == nil {
+			return all, nil
+		}
+		f.After = next
+	}
+}
+
+// WriteJSONL writes one example per line.
+func WriteJSONL(w io.Writer, examples []Example) error {
+	enc := json.NewEncoder(w)
+	for _, ex := range examples {
+		if err := enc.Encode(ex); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,201 @@
+package dataset
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/MikeSquared-Agency/dredd/internal/chronicle"
+	"github.com/MikeSquared-Agency/dredd/internal/store"
+)
+
+// fakeSource serves fixed rows, filtered by review status and paged one row
+// at a time to exercise cursor following.
+type fakeSource struct {
+	decisions []store.DecisionDetail
+	patterns  []store.PatternRow
+}
+
+func (f *fakeSource) ListDecisions(ctx context.Context, filter store.DecisionFilter) ([]store.DecisionDetail, *store.Cursor, error) {
+	var rows []store.DecisionDetail
+	for _, d := range f.decisions {
+		if d.ReviewStatus == filter.ReviewStatus && d.Source == filter.Source {
+			rows = append(rows, d)
+		}
+	}
+	return page(rows, filter.After)
+}
+
+func (f *fakeSource) ListPatterns(ctx context.Context, filter store.PatternFilter) ([]store.PatternRow, *store.Cursor, error) {
+	var rows []store.PatternRow
+	for _, p := range f.patterns {
+		if p.ReviewStatus == filter.ReviewStatus {
+			rows = append(rows, p)
+		}
+	}
+	return page(rows, filter.After)
+}
+
+// page returns the row at the cursor's offset, smuggled in its ID.
+func page[T any](rows []T, after *store.Cursor) ([]T, *store.Cursor, error) {
+	i := 0
+	if after != nil {
+		i = int(after.ID[0])
+	}
+	if i >= len(rows) {
+		return nil, nil, nil
+	}
+	if i+1 == len(rows) {
+		return rows[i:], nil, nil
+	}
+	var next uuid.UUID
+	next[0] = byte(i + 1)
+	return rows[i : i+1], &store.Cursor{ID: next}, nil
+}
+
+// fakeTranscripts has a transcript for every session but the missing ones.
+type fakeTranscripts struct {
+	missing map[string]bool
+}
+
+func (f fakeTranscripts) Transcript(ctx context.Context, sessionRef string) (string, error) {
+	if f.missing[sessionRef] {
+		return "", chronicle.ErrNoTranscript
+	}
+	return "transcript of " + sessionRef, nil
+}
+
+func testSource(sessions int) *fakeSource {
+	owner := uuid.New()
+	src := &fakeSource{}
+	for i := 0; i < sessions; i++ {
+		ref := fmt.Sprintf("session-%02d", i)
+		src.patterns = append(src.patterns, store.PatternRow{
+			ID: uuid.New(), OwnerUUID: owner, SessionRef: ref, PatternType: "pushback",
+			Summary: "confirmed pattern", ConversationArc: "arc " + ref, Confidence: 0.9, ReviewStatus: "confirmed",
+		})
+		src.decisions = append(src.decisions, store.DecisionDetail{
+			ID: uuid.New(), DecidedBy: owner, SessionRef: ref, Source: "dredd", Domain: "engineering",
+			Category: "architecture", Severity: "routine", Summary: "rejected decision",
+			Situation: "situation " + ref, ReviewStatus: "rejected",
+		})
+	}
+	return src
+}
+
+func TestBuild_Chat(t *testing.T) {
+	ds, err := Build(context.Background(), testSource(3), fakeTranscripts{}, Options{Format: FormatChat})
+	if err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+	if len(ds.Train) != 3 || len(ds.Validation) != 0 {
+		t.Fatalf("expected 3 train and 0 validation examples, got %d and %d", len(ds.Train), len(ds.Validation))
+	}
+
+	ex := ds.Train[0]
+	if len(ex.Messages) != 3 || ex.Messages[0].Role != "system" || ex.Messages[1].Role != "user" || ex.Messages[2].Role != "assistant" {
+		t.Fatalf("unexpected messages: %+v", ex.Messages)
+	}
+	// The prompt is the session's transcript, not the labelled items' excerpts.
+	prompt := ex.Messages[1].Content
+	if !strings.Contains(prompt, "transcript of session-") || strings.Contains(prompt, "arc session-") || strings.Contains(prompt, "situation session-") {
+		t.Errorf("expected only the transcript in the prompt, got %q", prompt)
+	}
+	var target struct {
+		Decisions []json.RawMessage `json:"decisions"`
+		Patterns  []json.RawMessage `json:"patterns"`
+		Styles    []json.RawMessage `json:"styles"`
+	}
+	if err := json.Unmarshal([]byte(ex.Messages[2].Content), &target); err != nil {
+		t.Fatalf("assistant reply is not JSON: %v", err)
+	}
+	if len(target.Decisions) != 0 || len(target.Patterns) != 1 || target.Styles == nil {
+		t.Errorf("expected only the confirmed pattern, got %s", ex.Messages[2].Content)
+	}
+}
+
+func TestBuild_Preference(t *testing.T) {
+	src := testSource(2)
+	// A session with only confirmed items has no pair.
+	src.patterns = append(src.patterns, store.PatternRow{SessionRef: "lonely", ReviewStatus: "confirmed", ConversationArc: "arc"})
+
+	ds, err := Build(context.Background(), src, fakeTranscripts{}, Options{Format: FormatPreference})
+	if err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+	if len(ds.Train) != 2 {
+		t.Fatalf("expected 2 pairs, got %d", len(ds.Train))
+	}
+	ex := ds.Train[0]
+	if ex.Input == nil || len(ex.Input.Messages) != 2 || len(ex.PreferredOutput) != 1 || len(ex.NonPreferredOutput) != 1 {
+		t.Fatalf("unexpected example: %+v", ex)
+	}
+	if !strings.Contains(ex.PreferredOutput[0].Content, "confirmed pattern") || !strings.Contains(ex.NonPreferredOutput[0].Content, "rejected decision") {
+		t.Errorf("outputs are not confirmed vs rejected: %+v", ex)
+	}
+}
+
+func TestBuild_SkipsMissingTranscripts(t *testing.T) {
+	ds, err := Build(context.Background(), testSource(3), fakeTranscripts{missing: map[string]bool{"session-01": true}}, Options{Format: FormatChat})
+	if err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+	if len(ds.Train) != 2 || ds.Skipped != 1 {
+		t.Errorf("expected 2 examples and 1 skipped session, got %d and %d", len(ds.Train), ds.Skipped)
+	}
+	for _, ex := range ds.Train {
+		if strings.Contains(ex.Messages[1].Content, "session-01") {
+			t.Errorf("expected session-01 to be skipped, got %q", ex.Messages[1].Content)
+		}
+	}
+}
+
+func TestBuild_SplitIsDeterministic(t *testing.T) {
+	src := testSource(20)
+	opts := Options{Format: FormatChat, Validation: 0.25, Seed: 7}
+
+	render := func(o Options) (string, string) {
+		ds, err := Build(context.Background(), src, fakeTranscripts{}, o)
+		if err != nil {
+			t.Fatalf("Build failed: %v", err)
+		}
+		var train, val bytes.Buffer
+		WriteJSONL(&train, ds.Train)
+		WriteJSONL(&val, ds.Validation)
+		if len(ds.Train) != 15 || len(ds.Validation) != 5 {
+			t.Fatalf("expected a 15/5 split, got %d/%d", len(ds.Train), len(ds.Validation))
+		}
+		return train.String(), val.String()
+	}
+
+	train1, val1 := render(opts)
+	train2, val2 := render(opts)
+	if train1 != train2 || val1 != val2 {
+		t.Error("expected the same seed to give the same split")
+	}
+	if strings.Count(train1, "\n") != 15 {
+		t.Errorf("expected one line per example")
+	}
+
+	opts.Seed = 8
+	if train3, _ := render(opts); train3 == train1 {
+		t.Error("expected a different seed to shuffle differently")
+	}
+}
+
+func TestOptions_Validate(t *testing.T) {
+	for _, opts := range []Options{
+		{Format: "csv"},
+		{Format: FormatChat, Kind: "style"},
+		{Format: FormatChat, Validation: 1},
+		{Format: FormatChat, Validation: -0.1},
+	} {
+		if err := opts.Validate(); err == nil {
+			t.Errorf("expected %+v to be invalid", opts)
+		}
+	}
+}
@@ -4,9 +4,11 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/MikeSquared-Agency/dredd/internal/metrics"
 )
 
 // DeduResult represents the result of a deduplication operation.
@@ -18,6 +20,9 @@ type DeduResult struct {
 	TotalItems  int                `json:"total_items"`
 	Deduped     int                `json:"deduped"`
 	Survivors   int                `json:"survivors"`
+	RunID       *uuid.UUID         `json:"run_id,omitempty"` // set when executed; pass to restore to undo
+	Neighbours  int                `json:"neighbours"`
+	Since       *time.Time         `json:"since,omitempty"` // set by incremental runs: only records created since were scanned
 	Details     []ClusterDetail    `json:"details,omitempty"`
 }
 
@@ -28,63 +33,114 @@ type ClusterDetail struct {
 	Size       int         `json:"size"`
 }
 
+// Options controls a dedup pass.
+type Options struct {
+	Threshold  float64
+	Neighbours int // nearest neighbours compared per record; DefaultNeighbours when <= 0
+	// Incremental only looks for duplicates of records created since the
+	// table's last finished, unrestored run. Their neighbours may be older.
+	Incremental bool
+	Execute     bool
+}
+
+// ProgressFunc receives progress reports: the "scan" stage once per batch of
+// records looked up, then "merge" once per processed cluster.
+type ProgressFunc func(stage string, done, total int)
+
 // Deduplicator orchestrates the deduplication process.
 type Deduplicator struct {
-	pool    *pgxpool.Pool
-	scanner *Scanner
-	ranker  *Ranker
-	logger  *slog.Logger
+	pool     *pgxpool.Pool
+	scanner  *Scanner
+	ranker   *Ranker
+	logger   *slog.Logger
+	progress ProgressFunc
 }
 
 // New creates a new deduplicator instance.
 func New(pool *pgxpool.Pool, logger *slog.Logger) *Deduplicator {
-	return &Deduplicator{
+	d := &Deduplicator{
 		pool:    pool,
 		scanner: NewScanner(pool),
 		ranker:  NewRanker(pool),
 		logger:  logger,
 	}
+	d.scanner.progress = func(done, total int) { d.report("scan", done, total) }
+	return d
+}
+
+// WithProgress sets a callback for progress reports and returns d.
+func (d *Deduplicator) WithProgress(fn ProgressFunc) *Deduplicator {
+	d.progress = fn
+	return d
+}
+
+func (d *Deduplicator) report(stage string, done, total int) {
+	if d.progress != nil {
+		d.progress(stage, done, total)
+	}
 }
 
 // DeduplicateReasoningPatterns performs deduplication on reasoning patterns.
-func (d *Deduplicator) DeduplicateReasoningPatterns(ctx context.Context, threshold float64, execute bool) (*DeduResult, error) {
-	d.logger.Info("starting reasoning patterns deduplication", "threshold", threshold, "execute", execute)
+func (d *Deduplicator) DeduplicateReasoningPatterns(ctx context.Context, opts Options) (_ *DeduResult, err error) {
+	d.logger.Info("starting reasoning patterns deduplication", "threshold", opts.Threshold, "execute", opts.Execute, "incremental", opts.Incremental)
+	defer func() { observeRun("reasoning_patterns", opts.Execute, err) }()
+
+	scan, err := d.scanOptions(ctx, "reasoning_patterns", opts)
+	if err != nil {
+		return nil, err
+	}
+
+	// Record the run before scanning, so the next incremental run picks up
+	// records created while this one was in progress. It only counts as the
+	// watermark once it finishes.
+	var runID uuid.UUID
+	if opts.Execute {
+		if runID, err = d.startRun(ctx, "reasoning_patterns", opts.Threshold); err != nil {
+			return nil, err
+		}
+		defer func() {
+			if err == nil {
+				err = d.finishRun(ctx, runID)
+			}
+		}()
+	}
 
 	// Find duplicate pairs
-	pairs, err := d.scanner.FindReasoningPatternDuplicates(ctx, threshold)
+	pairs, err := d.scanner.FindReasoningPatternDuplicates(ctx, scan)
 	if err != nil {
 		return nil, fmt.Errorf("find duplicates: %w", err)
 	}
 
 	d.logger.Info("found duplicate pairs", "count", len(pairs))
 
-	if len(pairs) == 0 {
-		return &DeduResult{
-			Table:     "reasoning_patterns",
-			Threshold: threshold,
-			Execute:   execute,
-			Clusters:  0,
-			Survivors: 0,
-			Deduped:   0,
-		}, nil
-	}
-
 	// Cluster duplicates using union-find
 	clusters := d.clusterPairs(pairs)
 	d.logger.Info("clustered duplicates", "clusters", len(clusters))
 
 	result := &DeduResult{
-		Table:     "reasoning_patterns",
-		Threshold: threshold,
-		Execute:   execute,
-		Clusters:  len(clusters),
+		Table:      "reasoning_patterns",
+		Threshold:  opts.Threshold,
+		Execute:    opts.Execute,
+		Clusters:   len(clusters),
+		Neighbours: scan.Neighbours,
+		Since:      scan.Since,
+	}
+	if opts.Execute {
+		result.RunID = &runID
+	}
+	if len(pairs) == 0 {
+		return result, nil
 	}
 
 	var allSurvivors []uuid.UUID
 	var allDeduped []uuid.UUID
 
-	// Process each cluster
-	for _, cluster := range clusters {
+	// Process each cluster, stopping between clusters if ctx is cancelled
+	for i, cluster := range clusters {
+		if err := ctx.Err(); err != nil {
+			return nil, fmt.Errorf("dedup stopped after %d of %d clusters: %w", i, len(clusters), err)
+		}
+		d.report("merge", i, len(clusters))
 		result.TotalItems += len(cluster)
 
 		// Rank to find survivor
@@ -105,9 +161,9 @@ func (d *Deduplicator) DeduplicateReasoningPatterns(ctx context.Context, thresho
 		allSurvivors = append(allSurvivors, survivorID)
 		allDeduped = append(allDeduped, dedupedIDs...)
 
-		if execute {
+		if opts.Execute {
 			// Update deduped items
-			if err := d.markReasoningPatternsAsDeduped(ctx, dedupedIDs, survivorID); err != nil {
+			if err := d.mergeCluster(ctx, runID, "reasoning_patterns", survivorID, dedupedIDs); err != nil {
 				d.logger.Error("failed to mark items as deduped", "survivor", survivorID, "deduped", dedupedIDs, "error", err)
 				continue
 			}
@@ -121,6 +177,7 @@ func (d *Deduplicator) DeduplicateReasoningPatterns(ctx context.Context, thresho
 		})
 	}
 
+	d.report("merge", len(clusters), len(clusters))
 	result.Survivors = len(allSurvivors)
 	result.Deduped = len(allDeduped)
 
@@ -129,44 +186,66 @@ func (d *Deduplicator) DeduplicateReasoningPatterns(ctx context.Context, thresho
 }
 
 // DeduplicateDecisions performs deduplication on decisions.
-func (d *Deduplicator) DeduplicateDecisions(ctx context.Context, threshold float64, execute bool) (*DeduResult, error) {
-	d.logger.Info("starting decisions deduplication", "threshold", threshold, "execute", execute)
+func (d *Deduplicator) DeduplicateDecisions(ctx context.Context, opts Options) (_ *DeduResult, err error) {
+	d.logger.Info("starting decisions deduplication", "threshold", opts.Threshold, "execute", opts.Execute, "incremental", opts.Incremental)
+	defer func() { observeRun("decisions", opts.Execute, err) }()
+
+	scan, err := d.scanOptions(ctx, "decisions", opts)
+	if err != nil {
+		return nil, err
+	}
+
+	// Record the run before scanning, so the next incremental run picks up
+	// records created while this one was in progress. It only counts as the
+	// watermark once it finishes.
+	var runID uuid.UUID
+	if opts.Execute {
+		if runID, err = d.startRun(ctx, "decisions", opts.Threshold); err != nil {
+			return nil, err
+		}
+		defer func() {
+			if err == nil {
+				err = d.finishRun(ctx, runID)
+			}
+		}()
+	}
 
 	// Find duplicate pairs
-	pairs, err := d.scanner.FindDecisionDuplicates(ctx, threshold)
+	pairs, err := d.scanner.FindDecisionDuplicates(ctx, scan)
 	if err != nil {
 		return nil, fmt.Errorf("find duplicates: %w", err)
 	}
 
 	d.logger.Info("found duplicate pairs", "count", len(pairs))
 
-	if len(pairs) == 0 {
-		return &DeduResult{
-			Table:     "decisions",
-			Threshold: threshold,
-			Execute:   execute,
-			Clusters:  0,
-			Survivors: 0,
-			Deduped:   0,
-		}, nil
-	}
-
 	// Cluster duplicates using union-find
 	clusters := d.clusterPairs(pairs)
 	d.logger.Info("clustered duplicates", "clusters", len(clusters))
 
 	result := &DeduResult{
-		Table:     "decisions",
-		Threshold: threshold,
-		Execute:   execute,
-		Clusters:  len(clusters),
+		Table:      "decisions",
+		Threshold:  opts.Threshold,
+		Execute:    opts.Execute,
+		Clusters:   len(clusters),
+		Neighbours: scan.Neighbours,
+		Since:      scan.Since,
+	}
+	if opts.Execute {
+		result.RunID = &runID
+	}
+	if len(pairs) == 0 {
+		return result, nil
 	}
 
 	var allSurvivors []uuid.UUID
 	var allDeduped []uuid.UUID
 
-	// Process each cluster
-	for _, cluster := range clusters {
+	// Process each cluster, stopping between clusters if ctx is cancelled
+	for i, cluster := range clusters {
+		if err := ctx.Err(); err != nil {
+			return nil, fmt.Errorf("dedup stopped after %d of %d clusters: %w", i, len(clusters), err)
+		}
+		d.report("merge", i, len(clusters))
 		result.TotalItems += len(cluster)
 
 		// Rank to find survivor
@@ -187,9 +266,9 @@ func (d *Deduplicator) DeduplicateDecisions(ctx context.Context, threshold float
 		allSurvivors = append(allSurvivors, survivorID)
 		allDeduped = append(allDeduped, dedupedIDs...)
 
-		if execute {
+		if opts.Execute {
 			// Update deduped items
-			if err := d.markDecisionsAsDeduped(ctx, dedupedIDs, survivorID); err != nil {
+			if err := d.mergeCluster(ctx, runID, "decisions", survivorID, dedupedIDs); err != nil {
 				d.logger.Error("failed to mark items as deduped", "survivor", survivorID, "deduped", dedupedIDs, "error", err)
 				continue
 			}
@@ -203,6 +282,7 @@ func (d *Deduplicator) DeduplicateDecisions(ctx context.Context, threshold float
 		})
 	}
 
+	d.report("merge", len(clusters), len(clusters))
 	result.Survivors = len(allSurvivors)
 	result.Deduped = len(allDeduped)
 
@@ -210,6 +290,36 @@ func (d *Deduplicator) DeduplicateDecisions(ctx context.Context, threshold float
 	return result, nil
 }
 
+// scanOptions resolves opts into a scan of table, finding the start of an
+// incremental scan from the table's last finished, unrestored run. The first
+// incremental run of a table scans all of it.
+func (d *Deduplicator) scanOptions(ctx context.Context, table string, opts Options) (ScanOptions, error) {
+	scan := ScanOptions{Threshold: opts.Threshold, Neighbours: opts.Neighbours}
+	if scan.Neighbours <= 0 {
+		scan.Neighbours = DefaultNeighbours
+	}
+	if opts.Incremental {
+		err := d.pool.QueryRow(ctx, `
+			SELECT max(created_at) FROM dedup_runs
+			WHERE table_name = $1 AND finished_at IS NOT NULL AND restored_at IS NULL`,
+			table,
+		).Scan(&scan.Since)
+		if err != nil {
+			return scan, fmt.Errorf("find last dedup run: %w", err)
+		}
+	}
+	return scan, nil
+}
+
+// observeRun counts a finished dedup run in the metrics.
+func observeRun(table string, execute bool, err error) {
+	mode := "dry_run"
+	if execute {
+		mode = "execute"
+	}
+	metrics.DedupRuns.WithLabelValues(table, mode, metrics.Outcome(err)).Inc()
+}
+
 // clusterPairs groups duplicate pairs into connected components using union-find.
 func (d *Deduplicator) clusterPairs(pairs []DuplicatePair) [][]uuid.UUID {
 	if len(pairs) == 0 {
@@ -269,41 +379,3 @@ func (d *Deduplicator) clusterPairs(pairs []DuplicatePair) [][]uuid.UUID {
 
 	return clusters
 }
-
-// markReasoningPatternsAsDeduped updates reasoning patterns as deduped.
-func (d *Deduplicator) markReasoningPatternsAsDeduped(ctx context.Context, dedupedIDs []uuid.UUID, survivorID uuid.UUID) error {
-	if len(dedupedIDs) == 0 {
-		return nil
-	}
-
-	query := `
-		UPDATE reasoning_patterns 
-		SET deduped_at = now(), dedup_survivor_id = $1 
-		WHERE id = ANY($2)`
-
-	_, err := d.pool.Exec(ctx, query, survivorID, dedupedIDs)
-	if err != nil {
-		return fmt.Errorf("update reasoning patterns: %w", err)
-	}
-
-	return nil
-}
-
-// markDecisionsAsDeduped updates decisions as deduped.
-func (d *Deduplicator) markDecisionsAsDeduped(ctx context.Context, dedupedIDs []uuid.UUID, survivorID uuid.UUID) error {
-	if len(dedupedIDs) == 0 {
-		return nil
-	}
-
-	query := `
-		UPDATE decisions 
-		SET deduped_at = now(), dedup_survivor_id = $1 
-		WHERE id = ANY($2)`
-
-	_, err := d.pool.Exec(ctx, query, survivorID, dedupedIDs)
-	if err != nil {
-		return fmt.Errorf("update decisions: %w", err)
-	}
-
-	return nil
-}
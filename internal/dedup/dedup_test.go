@@ -265,3 +265,20 @@ func TestSeverityPriority(t *testing.T) {
 		}
 	}
 }
+
+func TestIvfflatLists(t *testing.T) {
+	tests := []struct {
+		rows, want int
+	}{
+		{0, 10},
+		{5000, 10},
+		{250_000, 250},
+		{1_000_000, 1000},
+		{4_000_000, 2000},
+	}
+	for _, tt := range tests {
+		if got := ivfflatLists(tt.rows); got != tt.want {
+			t.Errorf("ivfflatLists(%d) = %d, want %d", tt.rows, got, tt.want)
+		}
+	}
+}
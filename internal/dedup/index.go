@@ -0,0 +1,188 @@
+package dedup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// Vector index methods.
+const (
+	IndexHNSW    = "hnsw"
+	IndexIVFFlat = "ivfflat"
+)
+
+// ErrIndexExists is returned by CreateIndex when the index it would build
+// already exists and IndexOptions.Replace is not set.
+var ErrIndexExists = errors.New("index exists")
+
+// IndexOptions controls CreateIndex. Zero values take pgvector's defaults,
+// except Lists, which is sized from the row count.
+type IndexOptions struct {
+	Method         string // IndexHNSW or IndexIVFFlat
+	M              int    // hnsw: connections per layer
+	EFConstruction int    // hnsw: candidate list size while building
+	Lists          int    // ivfflat: number of lists
+	// Replace rebuilds an existing index of the same method and drops the
+	// column's other vector indexes once the new one is built.
+	Replace bool
+}
+
+// VectorIndex is a pgvector index on a dedup table's embedding column.
+type VectorIndex struct {
+	Table     string `json:"table"`
+	Column    string `json:"column"`
+	Name      string `json:"name"`
+	Method    string `json:"method"`
+	Options   string `json:"options,omitempty"` // storage parameters, e.g. "m=16,ef_construction=64"
+	Valid     bool   `json:"valid"`             // false for a failed concurrent build
+	SizeBytes int64  `json:"size_bytes"`
+}
+
+// Tables returns the deduplicated tables.
+func Tables() []string {
+	return []string{"reasoning_patterns", "decisions"}
+}
+
+// ListIndexes returns the vector indexes on table's embedding column.
+func (d *Deduplicator) ListIndexes(ctx context.Context, table string) ([]VectorIndex, error) {
+	spec, ok := tableSpecs[table]
+	if !ok {
+		return nil, fmt.Errorf("unknown dedup table %q", table)
+	}
+
+	rows, err := d.pool.Query(ctx, `
+		SELECT t.relname, a.attname, i.relname, am.amname, coalesce(array_to_string(i.reloptions, ','), ''),
+		       x.indisvalid, pg_relation_size(i.oid)
+		FROM pg_index x
+		JOIN pg_class i ON i.oid = x.indexrelid
+		JOIN pg_class t ON t.oid = x.indrelid
+		JOIN pg_am am ON am.oid = i.relam
+		JOIN pg_attribute a ON a.attrelid = t.oid AND a.attnum = ANY(x.indkey)
+		WHERE t.oid = to_regclass($1) AND a.attname = $2 AND am.amname IN ('hnsw', 'ivfflat')
+		ORDER BY i.relname`,
+		table, spec.embedding,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("list %s indexes: %w", table, err)
+	}
+	defer rows.Close()
+
+	var indexes []VectorIndex
+	for rows.Next() {
+		var ix VectorIndex
+		if err := rows.Scan(&ix.Table, &ix.Column, &ix.Name, &ix.Method, &ix.Options, &ix.Valid, &ix.SizeBytes); err != nil {
+			return nil, fmt.Errorf("scan index: %w", err)
+		}
+		indexes = append(indexes, ix)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+	return indexes, nil
+}
+
+// CreateIndex builds a cosine-distance vector index on table's embedding
+// column without blocking writes, then analyzes the table. The index is named
+// idx_<table>_<column>_<method>.
+func (d *Deduplicator) CreateIndex(ctx context.Context, table string, opts IndexOptions) (*VectorIndex, error) {
+	spec, ok := tableSpecs[table]
+	if !ok {
+		return nil, fmt.Errorf("unknown dedup table %q", table)
+	}
+
+	var params []string
+	switch opts.Method {
+	case IndexHNSW:
+		if opts.M > 0 {
+			params = append(params, fmt.Sprintf("m = %d", opts.M))
+		}
+		if opts.EFConstruction > 0 {
+			params = append(params, fmt.Sprintf("ef_construction = %d", opts.EFConstruction))
+		}
+	case IndexIVFFlat:
+		lists := opts.Lists
+		if lists <= 0 {
+			var rows int
+			if err := d.pool.QueryRow(ctx, `SELECT count(*) FROM `+table+` WHERE `+spec.embedding+` IS NOT NULL`).Scan(&rows); err != nil {
+				return nil, fmt.Errorf("count %s: %w", table, err)
+			}
+			lists = ivfflatLists(rows)
+		}
+		params = append(params, fmt.Sprintf("lists = %d", lists))
+	default:
+		return nil, fmt.Errorf("unknown index method %q", opts.Method)
+	}
+
+	existing, err := d.ListIndexes(ctx, table)
+	if err != nil {
+		return nil, err
+	}
+	name := fmt.Sprintf("idx_%s_%s_%s", table, spec.embedding, opts.Method)
+	build := name
+	for _, ix := range existing {
+		if ix.Name == name {
+			if !opts.Replace {
+				return nil, fmt.Errorf("%w: %s; replace it to rebuild", ErrIndexExists, name)
+			}
+			build = name + "_new"
+		}
+	}
+
+	ddl := `CREATE INDEX CONCURRENTLY ` + build + ` ON ` + table + ` USING ` + opts.Method + ` (` + spec.embedding + ` vector_cosine_ops)`
+	if len(params) > 0 {
+		ddl += ` WITH (` + strings.Join(params, ", ") + `)`
+	}
+	d.logger.Info("building vector index", "table", table, "index", build, "ddl", ddl)
+	if _, err := d.pool.Exec(ctx, `DROP INDEX CONCURRENTLY IF EXISTS `+build); err != nil {
+		return nil, fmt.Errorf("drop leftover index %s: %w", build, err)
+	}
+	if _, err := d.pool.Exec(ctx, ddl); err != nil {
+		// A failed concurrent build leaves an invalid index behind.
+		d.pool.Exec(context.WithoutCancel(ctx), `DROP INDEX CONCURRENTLY IF EXISTS `+build)
+		return nil, fmt.Errorf("create index %s: %w", build, err)
+	}
+
+	if opts.Replace {
+		for _, ix := range existing {
+			d.logger.Info("dropping replaced vector index", "table", table, "index", ix.Name)
+			if _, err := d.pool.Exec(ctx, `DROP INDEX CONCURRENTLY IF EXISTS `+pgx.Identifier{ix.Name}.Sanitize()); err != nil {
+				return nil, fmt.Errorf("drop index %s: %w", ix.Name, err)
+			}
+		}
+		if build != name {
+			if _, err := d.pool.Exec(ctx, `ALTER INDEX `+build+` RENAME TO `+name); err != nil {
+				return nil, fmt.Errorf("rename index %s: %w", build, err)
+			}
+		}
+	}
+
+	if _, err := d.pool.Exec(ctx, `ANALYZE `+table); err != nil {
+		return nil, fmt.Errorf("analyze %s: %w", table, err)
+	}
+
+	indexes, err := d.ListIndexes(ctx, table)
+	if err != nil {
+		return nil, err
+	}
+	for _, ix := range indexes {
+		if ix.Name == name {
+			return &ix, nil
+		}
+	}
+	return nil, pgx.ErrNoRows
+}
+
+// ivfflatLists sizes an ivfflat index as pgvector recommends: rows / 1000 up
+// to a million rows, sqrt(rows) beyond, and at least 10.
+func ivfflatLists(rows int) int {
+	lists := rows / 1000
+	if rows > 1_000_000 {
+		lists = int(math.Sqrt(float64(rows)))
+	}
+	return max(lists, 10)
+}
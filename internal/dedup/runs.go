@@ -0,0 +1,519 @@
+package dedup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/MikeSquared-Agency/dredd/internal/metrics"
+)
+
+// tableSpec describes a deduplicated table.
+type tableSpec struct {
+	embedding string
+	// tagColumn is the survivor's text[] tag column, when tags are stored
+	// inline; tagTable is the table of tag rows keyed by record ID otherwise.
+	tagColumn string
+	tagTable  string
+	// dependents are rows that belong to a record and move to the survivor
+	// when it is merged away.
+	dependents []dependent
+}
+
+// dependent is a table whose rows reference a deduplicated record.
+type dependent struct {
+	table  string
+	column string // column holding the record ID
+	filter string // extra predicate selecting the rows, if any
+}
+
+var tableSpecs = map[string]tableSpec{
+	"reasoning_patterns": {
+		embedding: "arc_embedding",
+		tagColumn: "tags",
+		dependents: []dependent{
+			{table: "review_log", column: "target_id", filter: "target_kind = 'pattern'"},
+		},
+	},
+	"decisions": {
+		embedding: "embedding",
+		tagTable:  "decision_tags",
+		dependents: []dependent{
+			{table: "decision_outcomes", column: "decision_id"},
+			{table: "review_log", column: "target_id", filter: "target_kind = 'decision'"},
+		},
+	},
+}
+
+// ErrRestoreConflict is returned when a cluster cannot be restored because a
+// later, unrestored run merged its survivor into another record.
+var ErrRestoreConflict = errors.New("restore conflict")
+
+// Run is one executed dedup pass over a table.
+type Run struct {
+	ID         uuid.UUID    `json:"id"`
+	Table      string       `json:"table"`
+	Threshold  float64      `json:"threshold"`
+	Clusters   int          `json:"clusters"`
+	Deduped    int          `json:"deduped"`
+	CreatedAt  time.Time    `json:"created_at"`
+	FinishedAt *time.Time   `json:"finished_at,omitempty"` // unset while running, or if the run failed or was cancelled
+	RestoredAt *time.Time   `json:"restored_at,omitempty"`
+	Details    []RunCluster `json:"details,omitempty"`
+}
+
+// RunCluster is one merged cluster of a run.
+type RunCluster struct {
+	ID          uuid.UUID   `json:"id"`
+	RunID       uuid.UUID   `json:"run_id"`
+	Table       string      `json:"table"`
+	SurvivorID  uuid.UUID   `json:"survivor_id"`
+	DedupedIDs  []uuid.UUID `json:"deduped_ids"`
+	AddedTagIDs []uuid.UUID `json:"added_tag_ids,omitempty"` // tag rows the merge added to the survivor
+	Moved       []MovedRow  `json:"moved,omitempty"`         // rows the merge re-pointed at the survivor
+	Added       MergeDelta  `json:"added"`                   // what the merge added to the survivor's own columns
+	CreatedAt   time.Time   `json:"created_at"`
+	RestoredAt  *time.Time  `json:"restored_at,omitempty"`
+}
+
+// MergeDelta is what a merge added to the survivor's columns, which a restore
+// takes away again.
+type MergeDelta struct {
+	Occurrences int      `json:"occurrences"`
+	SessionRefs []string `json:"session_refs,omitempty"`
+	Tags        []string `json:"tags,omitempty"` // inline tags; tag rows are in AddedTagIDs
+}
+
+// MovedRow is a dependent row re-pointed from a deduped record to the
+// survivor.
+type MovedRow struct {
+	Table string    `json:"table"`
+	ID    uuid.UUID `json:"id"`
+	From  uuid.UUID `json:"from"`
+}
+
+// RestoreResult summarises a restore.
+type RestoreResult struct {
+	Clusters []uuid.UUID `json:"clusters"` // restored cluster IDs
+	Restored int         `json:"restored"` // records whose dedup flags were cleared
+}
+
+// startRun records the start of an executed dedup pass.
+func (d *Deduplicator) startRun(ctx context.Context, table string, threshold float64) (uuid.UUID, error) {
+	var id uuid.UUID
+	err := d.pool.QueryRow(ctx, `
+		INSERT INTO dedup_runs (table_name, threshold) VALUES ($1, $2) RETURNING id`,
+		table, threshold,
+	).Scan(&id)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("insert dedup run: %w", err)
+	}
+	return id, nil
+}
+
+// finishRun marks a run complete, which makes it the table's incremental
+// watermark.
+func (d *Deduplicator) finishRun(ctx context.Context, id uuid.UUID) error {
+	if _, err := d.pool.Exec(ctx, `UPDATE dedup_runs SET finished_at = now() WHERE id = $1`, id); err != nil {
+		return fmt.Errorf("finish dedup run: %w", err)
+	}
+	return nil
+}
+
+// mergeCluster merges the deduped records' evidence into the survivor, marks
+// the deduped records and records the cluster, with what the merge added,
+// against runID, in one transaction. The survivor gains the deduped records' tags, sessions
+// and occurrence counts, and their dependent rows are re-pointed at it.
+func (d *Deduplicator) mergeCluster(ctx context.Context, runID uuid.UUID, table string, survivorID uuid.UUID, dedupedIDs []uuid.UUID) error {
+	if len(dedupedIDs) == 0 {
+		return nil
+	}
+	spec := tableSpecs[table]
+	members := append([]uuid.UUID{survivorID}, dedupedIDs...)
+
+	tx, err := d.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	var clusterID uuid.UUID
+	err = tx.QueryRow(ctx, `
+		INSERT INTO dedup_run_clusters (run_id, table_name, survivor_id, deduped_ids)
+		SELECT $1, $2, t.id, $3
+		FROM `+table+` t WHERE t.id = $4
+		RETURNING id`,
+		runID, table, dedupedIDs, survivorID,
+	).Scan(&clusterID)
+	if err != nil {
+		return fmt.Errorf("record cluster: %w", err)
+	}
+
+	// A record that never took part in a merge stands for itself and its own
+	// session; one that did already lists its sessions. The survivor's columns
+	// before the merge are compared with after, to record what it added.
+	set := `
+		occurrence_count = (SELECT sum(occurrence_count) FROM ` + table + ` WHERE id = ANY($2)),
+		session_refs = array(
+			SELECT DISTINCT ref FROM ` + table + ` m,
+				unnest(CASE WHEN cardinality(m.session_refs) > 0 THEN m.session_refs ELSE array[m.session_ref] END) ref
+			WHERE m.id = ANY($2) AND coalesce(ref, '') <> ''
+			ORDER BY ref)`
+	// The deduped records' tags are appended, so whatever follows the
+	// survivor's own tags was added.
+	priorTags, addedTags := `'{}'::text[]`, `'{}'::text[]`
+	if spec.tagColumn != "" {
+		set += `,
+		` + spec.tagColumn + ` = coalesce(s.` + spec.tagColumn + `, '{}') || array(
+			SELECT DISTINCT tag FROM ` + table + ` m, unnest(m.` + spec.tagColumn + `) tag
+			WHERE m.id = ANY($2) AND NOT tag = ANY(coalesce(s.` + spec.tagColumn + `, '{}'))
+			ORDER BY tag)`
+		priorTags = `coalesce(` + spec.tagColumn + `, '{}')`
+		addedTags = `s.` + spec.tagColumn + `[cardinality(prior.tags) + 1:]`
+	}
+	var delta MergeDelta
+	err = tx.QueryRow(ctx, `
+		WITH prior AS (
+			SELECT occurrence_count,
+			       array_remove(CASE WHEN cardinality(session_refs) > 0 THEN session_refs ELSE array[session_ref] END, NULL) AS refs,
+			       `+priorTags+` AS tags
+			FROM `+table+` WHERE id = $1
+		)
+		UPDATE `+table+` s SET `+set+`
+		FROM prior
+		WHERE s.id = $1
+		RETURNING s.occurrence_count - prior.occurrence_count,
+		          array(SELECT ref FROM unnest(s.session_refs) ref WHERE ref <> ALL(prior.refs)),
+		          coalesce(`+addedTags+`, '{}')`,
+		survivorID, members,
+	).Scan(&delta.Occurrences, &delta.SessionRefs, &delta.Tags)
+	if err != nil {
+		return fmt.Errorf("merge into survivor: %w", err)
+	}
+
+	addedTagRows := []uuid.UUID{}
+	if spec.tagTable != "" {
+		rows, err := tx.Query(ctx, `
+			INSERT INTO `+spec.tagTable+` (decision_id, tag)
+			SELECT DISTINCT $1::uuid, t.tag FROM `+spec.tagTable+` t
+			WHERE t.decision_id = ANY($2)
+			  AND NOT EXISTS (SELECT 1 FROM `+spec.tagTable+` s WHERE s.decision_id = $1 AND s.tag = t.tag)
+			RETURNING id`,
+			survivorID, dedupedIDs,
+		)
+		if err != nil {
+			return fmt.Errorf("merge tags: %w", err)
+		}
+		addedTagRows, err = pgx.AppendRows(addedTagRows, rows, pgx.RowTo[uuid.UUID])
+		if err != nil {
+			return fmt.Errorf("merge tags: %w", err)
+		}
+	}
+
+	moved := []MovedRow{}
+	for _, dep := range spec.dependents {
+		filter := ""
+		if dep.filter != "" {
+			filter = " AND " + dep.filter
+		}
+		rows, err := tx.Query(ctx, `
+			WITH moving AS (
+				SELECT id, `+dep.column+` AS from_id FROM `+dep.table+`
+				WHERE `+dep.column+` = ANY($2)`+filter+`
+				FOR UPDATE
+			)
+			UPDATE `+dep.table+` t SET `+dep.column+` = $1
+			FROM moving WHERE t.id = moving.id
+			RETURNING t.id, moving.from_id`,
+			survivorID, dedupedIDs,
+		)
+		if err != nil {
+			return fmt.Errorf("re-point %s: %w", dep.table, err)
+		}
+		for rows.Next() {
+			m := MovedRow{Table: dep.table}
+			if err := rows.Scan(&m.ID, &m.From); err != nil {
+				rows.Close()
+				return fmt.Errorf("scan re-pointed %s: %w", dep.table, err)
+			}
+			moved = append(moved, m)
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return fmt.Errorf("re-point %s: %w", dep.table, err)
+		}
+	}
+
+	_, err = tx.Exec(ctx, `
+		UPDATE dedup_run_clusters
+		SET added_tag_ids = $2, moved_rows = $3, added_occurrences = $4, added_session_refs = $5, added_tags = $6
+		WHERE id = $1`,
+		clusterID, addedTagRows, moved, delta.Occurrences, delta.SessionRefs, delta.Tags,
+	)
+	if err != nil {
+		return fmt.Errorf("record merge: %w", err)
+	}
+
+	_, err = tx.Exec(ctx, `
+		UPDATE `+table+`
+		SET deduped_at = now(), dedup_survivor_id = $1
+		WHERE id = ANY($2)`,
+		survivorID, dedupedIDs,
+	)
+	if err != nil {
+		return fmt.Errorf("update %s: %w", table, err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return err
+	}
+	metrics.DedupMerged.WithLabelValues(table).Add(float64(len(dedupedIDs)))
+	return nil
+}
+
+// ListRuns returns executed dedup runs, newest first.
+func (d *Deduplicator) ListRuns(ctx context.Context, limit int) ([]Run, error) {
+	if limit <= 0 {
+		limit = 50
+	}
+	rows, err := d.pool.Query(ctx, `
+		SELECT r.id, r.table_name, r.threshold, count(c.id), coalesce(sum(cardinality(c.deduped_ids)), 0), r.created_at, r.finished_at, r.restored_at
+		FROM dedup_runs r
+		LEFT JOIN dedup_run_clusters c ON c.run_id = r.id
+		GROUP BY r.id
+		ORDER BY r.created_at DESC
+		LIMIT $1`, limit)
+	if err != nil {
+		return nil, fmt.Errorf("list dedup runs: %w", err)
+	}
+	defer rows.Close()
+
+	var runs []Run
+	for rows.Next() {
+		var r Run
+		if err := rows.Scan(&r.ID, &r.Table, &r.Threshold, &r.Clusters, &r.Deduped, &r.CreatedAt, &r.FinishedAt, &r.RestoredAt); err != nil {
+			return nil, fmt.Errorf("scan dedup run: %w", err)
+		}
+		runs = append(runs, r)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+	return runs, nil
+}
+
+// GetRun returns a run with its clusters. It returns pgx.ErrNoRows when the
+// run does not exist.
+func (d *Deduplicator) GetRun(ctx context.Context, id uuid.UUID) (*Run, error) {
+	var r Run
+	err := d.pool.QueryRow(ctx, `
+		SELECT id, table_name, threshold, created_at, finished_at, restored_at FROM dedup_runs WHERE id = $1`, id,
+	).Scan(&r.ID, &r.Table, &r.Threshold, &r.CreatedAt, &r.FinishedAt, &r.RestoredAt)
+	if err != nil {
+		return nil, err
+	}
+
+	clusters, err := d.queryClusters(ctx, d.pool, `WHERE run_id = $1 ORDER BY created_at`, id)
+	if err != nil {
+		return nil, err
+	}
+	r.Details = clusters
+	r.Clusters = len(clusters)
+	for _, c := range clusters {
+		r.Deduped += len(c.DedupedIDs)
+	}
+	return &r, nil
+}
+
+type querier interface {
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+}
+
+func (d *Deduplicator) queryClusters(ctx context.Context, q querier, where string, args ...any) ([]RunCluster, error) {
+	rows, err := q.Query(ctx, `
+		SELECT id, run_id, table_name, survivor_id, deduped_ids, added_tag_ids, moved_rows,
+		       added_occurrences, added_session_refs, added_tags, created_at, restored_at
+		FROM dedup_run_clusters `+where, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query dedup clusters: %w", err)
+	}
+	defer rows.Close()
+
+	var clusters []RunCluster
+	for rows.Next() {
+		var c RunCluster
+		if err := rows.Scan(&c.ID, &c.RunID, &c.Table, &c.SurvivorID, &c.DedupedIDs, &c.AddedTagIDs, &c.Moved,
+			&c.Added.Occurrences, &c.Added.SessionRefs, &c.Added.Tags, &c.CreatedAt, &c.RestoredAt); err != nil {
+			return nil, fmt.Errorf("scan dedup cluster: %w", err)
+		}
+		clusters = append(clusters, c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+	return clusters, nil
+}
+
+// RestoreRun reverses every unrestored cluster of a run. It returns
+// pgx.ErrNoRows when the run does not exist.
+func (d *Deduplicator) RestoreRun(ctx context.Context, runID uuid.UUID) (*RestoreResult, error) {
+	tx, err := d.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	var exists bool
+	if err := tx.QueryRow(ctx, `SELECT true FROM dedup_runs WHERE id = $1 FOR UPDATE`, runID).Scan(&exists); err != nil {
+		return nil, err
+	}
+
+	clusters, err := d.queryClusters(ctx, tx, `WHERE run_id = $1 AND restored_at IS NULL FOR UPDATE`, runID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &RestoreResult{Clusters: []uuid.UUID{}}
+	for _, c := range clusters {
+		n, err := d.restoreCluster(ctx, tx, c)
+		if err != nil {
+			return nil, err
+		}
+		result.Clusters = append(result.Clusters, c.ID)
+		result.Restored += n
+	}
+
+	if _, err := tx.Exec(ctx, `UPDATE dedup_runs SET restored_at = now() WHERE id = $1`, runID); err != nil {
+		return nil, fmt.Errorf("mark run restored: %w", err)
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("commit: %w", err)
+	}
+
+	d.logger.Info("dedup run restored", "run_id", runID, "clusters", len(result.Clusters), "restored", result.Restored)
+	return result, nil
+}
+
+// RestoreRecord reverses the most recent unrestored cluster that contains id,
+// as survivor or as a deduped record. The whole cluster is restored. It returns pgx.ErrNoRows when id
+// is in no unrestored cluster.
+func (d *Deduplicator) RestoreRecord(ctx context.Context, id uuid.UUID) (*RestoreResult, error) {
+	tx, err := d.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	clusters, err := d.queryClusters(ctx, tx, `
+		WHERE restored_at IS NULL AND (survivor_id = $1 OR $1 = ANY(deduped_ids))
+		ORDER BY created_at DESC LIMIT 1 FOR UPDATE`, id)
+	if err != nil {
+		return nil, err
+	}
+	if len(clusters) == 0 {
+		return nil, pgx.ErrNoRows
+	}
+
+	n, err := d.restoreCluster(ctx, tx, clusters[0])
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("commit: %w", err)
+	}
+
+	d.logger.Info("dedup cluster restored", "record_id", id, "cluster_id", clusters[0].ID, "restored", n)
+	return &RestoreResult{Clusters: []uuid.UUID{clusters[0].ID}, Restored: n}, nil
+}
+
+// restoreCluster clears the dedup flags of a cluster's records, removes the
+// tags the merge added, points the rows it moved back at their records and
+// takes the occurrences and sessions it added off the survivor. Whatever the
+// survivor gained since, from a later merge, a write-time occurrence or a
+// review, is kept. Records and rows since re-pointed at another survivor are
+// left alone.
+func (d *Deduplicator) restoreCluster(ctx context.Context, tx pgx.Tx, c RunCluster) (int, error) {
+	spec, ok := tableSpecs[c.Table]
+	if !ok {
+		return 0, fmt.Errorf("unknown dedup table %q", c.Table)
+	}
+
+	// Once the survivor has itself been merged away, its occurrences count
+	// towards the later survivor, so that merge must be restored first.
+	var later uuid.UUID
+	err := tx.QueryRow(ctx, `
+		SELECT run_id FROM dedup_run_clusters
+		WHERE table_name = $1 AND $2 = ANY(deduped_ids) AND restored_at IS NULL AND created_at > $3
+		ORDER BY created_at DESC LIMIT 1`,
+		c.Table, c.SurvivorID, c.CreatedAt,
+	).Scan(&later)
+	if err == nil {
+		return 0, fmt.Errorf("%w: survivor %s was merged away by run %s; restore that run first", ErrRestoreConflict, c.SurvivorID, later)
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return 0, fmt.Errorf("check later merges: %w", err)
+	}
+
+	tag, err := tx.Exec(ctx, `
+		UPDATE `+c.Table+`
+		SET deduped_at = NULL, dedup_survivor_id = NULL
+		WHERE id = ANY($1) AND dedup_survivor_id = $2`,
+		c.DedupedIDs, c.SurvivorID,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("clear dedup flags: %w", err)
+	}
+
+	if spec.tagTable != "" && len(c.AddedTagIDs) > 0 {
+		_, err := tx.Exec(ctx, `DELETE FROM `+spec.tagTable+` WHERE id = ANY($1) AND decision_id = $2`, c.AddedTagIDs, c.SurvivorID)
+		if err != nil {
+			return 0, fmt.Errorf("remove merged tags: %w", err)
+		}
+	}
+	for _, dep := range spec.dependents {
+		var ids, from []uuid.UUID
+		for _, m := range c.Moved {
+			if m.Table == dep.table {
+				ids = append(ids, m.ID)
+				from = append(from, m.From)
+			}
+		}
+		if len(ids) == 0 {
+			continue
+		}
+		_, err := tx.Exec(ctx, `
+			UPDATE `+dep.table+` t SET `+dep.column+` = m.from_id
+			FROM unnest($1::uuid[], $2::uuid[]) AS m(id, from_id)
+			WHERE t.id = m.id AND t.`+dep.column+` = $3`,
+			ids, from, c.SurvivorID,
+		)
+		if err != nil {
+			return 0, fmt.Errorf("restore %s: %w", dep.table, err)
+		}
+	}
+
+	// A survivor left with only its own session stands for itself again.
+	set := `
+		occurrence_count = greatest(t.occurrence_count - $2, 1),
+		session_refs = (
+			SELECT CASE WHEN refs = array[t.session_ref] THEN '{}' ELSE refs END
+			FROM (SELECT array(SELECT ref FROM unnest(t.session_refs) ref WHERE ref <> ALL($3::text[])) AS refs) r)`
+	args := []any{c.SurvivorID, c.Added.Occurrences, c.Added.SessionRefs}
+	if spec.tagColumn != "" {
+		set += `,
+		` + spec.tagColumn + ` = array(SELECT tag FROM unnest(t.` + spec.tagColumn + `) tag WHERE tag <> ALL($4::text[]))`
+		args = append(args, c.Added.Tags)
+	}
+	_, err = tx.Exec(ctx, `UPDATE `+c.Table+` t SET `+set+` WHERE t.id = $1`, args...)
+	if err != nil {
+		return 0, fmt.Errorf("restore survivor: %w", err)
+	}
+
+	if _, err := tx.Exec(ctx, `UPDATE dedup_run_clusters SET restored_at = now() WHERE id = $1`, c.ID); err != nil {
+		return 0, fmt.Errorf("mark cluster restored: %w", err)
+	}
+	return int(tag.RowsAffected()), nil
+}
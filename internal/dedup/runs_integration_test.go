@@ -0,0 +1,185 @@
+//go:build integration
+
+package dedup
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+func TestIntegration_MergeAndRestoreDecisions(t *testing.T) {
+	dbURL := os.Getenv("DATABASE_URL")
+	if dbURL == "" {
+		t.Skip("DATABASE_URL not set, skipping integration test")
+	}
+	ctx := context.Background()
+	pool, err := pgxpool.New(ctx, dbURL)
+	if err != nil {
+		t.Fatalf("failed to connect: %v", err)
+	}
+	t.Cleanup(pool.Close)
+	d := New(pool, slog.Default())
+
+	owner := uuid.New()
+	survivor, loser := uuid.New(), uuid.New()
+	for id, session := range map[uuid.UUID]string{survivor: "integration-merge-1", loser: "integration-merge-2"} {
+		_, err := pool.Exec(ctx, `
+			INSERT INTO decisions (id, domain, category, decided_by, summary, session_ref)
+			VALUES ($1, 'architecture', 'gate_approval', $2, 'Integration merge decision', $3)`,
+			id, owner, session)
+		if err != nil {
+			t.Fatalf("insert decision: %v", err)
+		}
+	}
+	t.Cleanup(func() {
+		pool.Exec(ctx, "DELETE FROM review_log WHERE target_id = ANY($1)", []uuid.UUID{survivor, loser})
+		pool.Exec(ctx, "DELETE FROM decisions WHERE id = ANY($1)", []uuid.UUID{survivor, loser})
+	})
+	for id, tags := range map[uuid.UUID][]string{survivor: {"a", "b"}, loser: {"b", "c"}} {
+		for _, tag := range tags {
+			if _, err := pool.Exec(ctx, `INSERT INTO decision_tags (decision_id, tag) VALUES ($1, $2)`, id, tag); err != nil {
+				t.Fatalf("insert tag: %v", err)
+			}
+		}
+	}
+	if _, err := pool.Exec(ctx, `INSERT INTO decision_outcomes (decision_id, outcome_text) VALUES ($1, 'shipped')`, loser); err != nil {
+		t.Fatalf("insert outcome: %v", err)
+	}
+	if _, err := pool.Exec(ctx, `
+		INSERT INTO review_log (target_kind, target_id, verdict, channel) VALUES ('decision', $1, 'confirmed', 'api')`, loser); err != nil {
+		t.Fatalf("insert review: %v", err)
+	}
+
+	runID, err := d.startRun(ctx, "decisions", 0.9)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { pool.Exec(ctx, "DELETE FROM dedup_runs WHERE id = $1", runID) })
+	if err := d.mergeCluster(ctx, runID, "decisions", survivor, []uuid.UUID{loser}); err != nil {
+		t.Fatalf("mergeCluster: %v", err)
+	}
+
+	type state struct {
+		Occurrences int
+		Sessions    []string
+		Tags        []string
+		Outcomes    int
+		Reviews     int
+	}
+	stateOf := func() state {
+		t.Helper()
+		var st state
+		err := pool.QueryRow(ctx, `
+			SELECT occurrence_count, session_refs,
+			       array(SELECT tag FROM decision_tags WHERE decision_id = $1),
+			       (SELECT count(*) FROM decision_outcomes WHERE decision_id = $1),
+			       (SELECT count(*) FROM review_log WHERE target_kind = 'decision' AND target_id = $1)
+			FROM decisions WHERE id = $1`, survivor,
+		).Scan(&st.Occurrences, &st.Sessions, &st.Tags, &st.Outcomes, &st.Reviews)
+		if err != nil {
+			t.Fatalf("query survivor: %v", err)
+		}
+		sort.Strings(st.Tags)
+		return st
+	}
+
+	merged := state{Occurrences: 2, Sessions: []string{"integration-merge-1", "integration-merge-2"}, Tags: []string{"a", "b", "c"}, Outcomes: 1, Reviews: 1}
+	if got := stateOf(); !reflect.DeepEqual(got, merged) {
+		t.Errorf("after merge: got %+v, want %+v", got, merged)
+	}
+
+	// A review of the survivor after the merge, and an occurrence recorded on
+	// it at write time, outlive the restore.
+	_, err = pool.Exec(ctx, `
+		UPDATE decisions
+		SET review_status = 'confirmed', review_note = 'kept',
+		    occurrence_count = occurrence_count + 1, session_refs = session_refs || 'integration-merge-3'::text
+		WHERE id = $1`, survivor)
+	if err != nil {
+		t.Fatalf("update survivor: %v", err)
+	}
+
+	if _, err := d.RestoreRun(ctx, runID); err != nil {
+		t.Fatalf("RestoreRun: %v", err)
+	}
+	var status, note string
+	if err := pool.QueryRow(ctx, `SELECT review_status, review_note FROM decisions WHERE id = $1`, survivor).Scan(&status, &note); err != nil {
+		t.Fatalf("query review: %v", err)
+	}
+	if status != "confirmed" || note != "kept" {
+		t.Errorf("expected the survivor's review to survive the restore, got %q %q", status, note)
+	}
+	restored := state{Occurrences: 2, Sessions: []string{"integration-merge-1", "integration-merge-3"}, Tags: []string{"a", "b"}}
+	if got := stateOf(); !reflect.DeepEqual(got, restored) {
+		t.Errorf("after restore: got %+v, want %+v", got, restored)
+	}
+	var outcomes int
+	pool.QueryRow(ctx, `SELECT count(*) FROM decision_outcomes WHERE decision_id = $1`, loser).Scan(&outcomes)
+	if outcomes != 1 {
+		t.Errorf("expected the outcome back on the deduped decision, got %d", outcomes)
+	}
+}
+
+func TestIntegration_UnfinishedRunKeepsWatermark(t *testing.T) {
+	dbURL := os.Getenv("DATABASE_URL")
+	if dbURL == "" {
+		t.Skip("DATABASE_URL not set, skipping integration test")
+	}
+	ctx := context.Background()
+	pool, err := pgxpool.New(ctx, dbURL)
+	if err != nil {
+		t.Fatalf("failed to connect: %v", err)
+	}
+	t.Cleanup(pool.Close)
+	d := New(pool, slog.Default())
+	opts := Options{Threshold: 0.9, Incremental: true}
+
+	before, err := d.scanOptions(ctx, "decisions", opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// A run that was cancelled or failed part-way is never finished.
+	cancelled, err := d.startRun(ctx, "decisions", 0.9)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { pool.Exec(ctx, "DELETE FROM dedup_runs WHERE id = $1", cancelled) })
+	after, err := d.scanOptions(ctx, "decisions", opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(after.Since, before.Since) {
+		t.Errorf("an unfinished run moved the watermark from %v to %v", before.Since, after.Since)
+	}
+
+	finished, err := d.startRun(ctx, "decisions", 0.9)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { pool.Exec(ctx, "DELETE FROM dedup_runs WHERE id = $1", finished) })
+	if err := d.finishRun(ctx, finished); err != nil {
+		t.Fatal(err)
+	}
+	run, err := d.GetRun(ctx, finished)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if run.FinishedAt == nil {
+		t.Error("expected the finished run to report finished_at")
+	}
+	after, err = d.scanOptions(ctx, "decisions", opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if after.Since == nil || !after.Since.Equal(run.CreatedAt) {
+		t.Errorf("expected the watermark at the finished run's start %v, got %v", run.CreatedAt, after.Since)
+	}
+}
@@ -3,11 +3,24 @@ package dedup
 import (
 	"context"
 	"fmt"
+	"sort"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
+// DefaultNeighbours is how many nearest neighbours each record is compared
+// with when ScanOptions.Neighbours is unset.
+const DefaultNeighbours = 10
+
+// scanBatchSize is how many records each neighbour query looks up.
+const scanBatchSize = 500
+
+// ivfflatProbes is the number of ivfflat lists searched per lookup. The
+// pgvector default of 1 misses too many neighbours for dedup.
+const ivfflatProbes = 10
+
 // DuplicatePair represents two potentially duplicate records.
 type DuplicatePair struct {
 	ID1        uuid.UUID
@@ -15,9 +28,24 @@ type DuplicatePair struct {
 	Similarity float64
 }
 
+// ScanOptions controls a duplicate scan.
+type ScanOptions struct {
+	Threshold  float64
+	Neighbours int        // nearest neighbours compared per record; DefaultNeighbours when <= 0
+	Since      *time.Time // only look up records created at or after Since; nil scans the whole table
+}
+
 // Scanner finds duplicate pairs using pgvector cosine similarity.
+//
+// Rather than comparing every pair of records, it looks up each record's
+// nearest neighbours through the table's vector index (ORDER BY <=> LIMIT k),
+// a batch of records per query. With an HNSW or ivfflat index that is close
+// to linear in the table size; the lookups are approximate, so a duplicate
+// outside a record's k nearest neighbours, or one the index misses, is not
+// reported. See "dredd index" for creating and tuning the indexes.
 type Scanner struct {
-	pool *pgxpool.Pool
+	pool     *pgxpool.Pool
+	progress func(done, total int)
 }
 
 // NewScanner creates a new scanner instance.
@@ -26,67 +54,136 @@ func NewScanner(pool *pgxpool.Pool) *Scanner {
 }
 
 // FindReasoningPatternDuplicates finds duplicate reasoning patterns above the threshold.
-func (s *Scanner) FindReasoningPatternDuplicates(ctx context.Context, threshold float64) ([]DuplicatePair, error) {
-	query := `
-		SELECT a.id, b.id, 1 - (a.arc_embedding <=> b.arc_embedding) AS similarity
-		FROM reasoning_patterns a, reasoning_patterns b
-		WHERE a.id < b.id
-		  AND a.arc_embedding IS NOT NULL AND b.arc_embedding IS NOT NULL
-		  AND a.deduped_at IS NULL AND b.deduped_at IS NULL
-		  AND 1 - (a.arc_embedding <=> b.arc_embedding) > $1
-		ORDER BY similarity DESC`
-
-	rows, err := s.pool.Query(ctx, query, threshold)
+func (s *Scanner) FindReasoningPatternDuplicates(ctx context.Context, opts ScanOptions) ([]DuplicatePair, error) {
+	return s.findDuplicates(ctx, "reasoning_patterns", opts)
+}
+
+// FindDecisionDuplicates finds duplicate decisions above the threshold.
+func (s *Scanner) FindDecisionDuplicates(ctx context.Context, opts ScanOptions) ([]DuplicatePair, error) {
+	return s.findDuplicates(ctx, "decisions", opts)
+}
+
+// findDuplicates looks up the nearest neighbours of every live record of
+// table created since opts.Since, in batches ordered by ID, and returns each
+// pair above the threshold once, most similar first.
+func (s *Scanner) findDuplicates(ctx context.Context, table string, opts ScanOptions) ([]DuplicatePair, error) {
+	col := tableSpecs[table].embedding
+	k := opts.Neighbours
+	if k <= 0 {
+		k = DefaultNeighbours
+	}
+
+	var total int
+	err := s.pool.QueryRow(ctx, `
+		SELECT count(*) FROM `+table+`
+		WHERE `+col+` IS NOT NULL AND deduped_at IS NULL AND ($1::timestamptz IS NULL OR created_at >= $1)`,
+		opts.Since,
+	).Scan(&total)
 	if err != nil {
-		return nil, fmt.Errorf("query reasoning pattern duplicates: %w", err)
+		return nil, fmt.Errorf("count %s: %w", table, err)
+	}
+	if s.progress != nil {
+		s.progress(0, total)
 	}
-	defer rows.Close()
 
+	seen := make(map[[2]uuid.UUID]bool)
 	var pairs []DuplicatePair
-	for rows.Next() {
-		var pair DuplicatePair
-		if err := rows.Scan(&pair.ID1, &pair.ID2, &pair.Similarity); err != nil {
-			return nil, fmt.Errorf("scan duplicate pair: %w", err)
+	var after uuid.UUID
+	for done := 0; done < total; {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		n, last, err := s.scanBatch(ctx, table, col, opts, k, after, func(a, b uuid.UUID, sim float64) {
+			if b.String() < a.String() {
+				a, b = b, a
+			}
+			if key := [2]uuid.UUID{a, b}; !seen[key] {
+				seen[key] = true
+				pairs = append(pairs, DuplicatePair{ID1: a, ID2: b, Similarity: sim})
+			}
+		})
+		if err != nil {
+			return nil, fmt.Errorf("query %s duplicates: %w", table, err)
+		}
+		if n == 0 {
+			break // rows deduped or deleted since the count
+		}
+		done += n
+		after = last
+		if s.progress != nil {
+			s.progress(min(done, total), total)
 		}
-		pairs = append(pairs, pair)
-	}
-
-	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("rows error: %w", err)
 	}
 
+	sort.SliceStable(pairs, func(i, j int) bool { return pairs[i].Similarity > pairs[j].Similarity })
 	return pairs, nil
 }
 
-// FindDecisionDuplicates finds duplicate decisions above the threshold.
-func (s *Scanner) FindDecisionDuplicates(ctx context.Context, threshold float64) ([]DuplicatePair, error) {
-	query := `
-		SELECT a.id, b.id, 1 - (a.embedding <=> b.embedding) AS similarity
-		FROM decisions a, decisions b
-		WHERE a.id < b.id
-		  AND a.embedding IS NOT NULL AND b.embedding IS NOT NULL
-		  AND a.deduped_at IS NULL AND b.deduped_at IS NULL
-		  AND 1 - (a.embedding <=> b.embedding) > $1
-		ORDER BY similarity DESC`
-
-	rows, err := s.pool.Query(ctx, query, threshold)
+// scanBatch looks up the neighbours of the next batch of records after the
+// given ID, calling found for each neighbour above the threshold. It returns
+// how many records it looked up and the last one's ID.
+func (s *Scanner) scanBatch(ctx context.Context, table, col string, opts ScanOptions, k int, after uuid.UUID, found func(a, b uuid.UUID, sim float64)) (int, uuid.UUID, error) {
+	tx, err := s.pool.Begin(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("query decision duplicates: %w", err)
+		return 0, uuid.Nil, fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	// Search widely enough to return k neighbours; both settings are ignored
+	// by the index type they do not belong to.
+	_, err = tx.Exec(ctx, `
+		SELECT set_config('hnsw.ef_search', $1, true), set_config('ivfflat.probes', $2, true)`,
+		fmt.Sprint(max(40, 2*k)), fmt.Sprint(ivfflatProbes),
+	)
+	if err != nil {
+		return 0, uuid.Nil, fmt.Errorf("tune index search: %w", err)
+	}
+
+	// The LEFT JOIN keeps records without neighbours above the threshold, so
+	// every record of the batch comes back and the last ID moves the cursor.
+	rows, err := tx.Query(ctx, `
+		WITH batch AS (
+			SELECT id, `+col+` AS embedding FROM `+table+`
+			WHERE `+col+` IS NOT NULL AND deduped_at IS NULL AND id > $1 AND ($2::timestamptz IS NULL OR created_at >= $2)
+			ORDER BY id
+			LIMIT $3
+		)
+		SELECT a.id, n.id, coalesce(n.similarity, 0)
+		FROM batch a
+		LEFT JOIN LATERAL (
+			SELECT b.id, 1 - (b.`+col+` <=> a.embedding) AS similarity
+			FROM `+table+` b
+			WHERE b.`+col+` IS NOT NULL AND b.deduped_at IS NULL AND b.id <> a.id
+			ORDER BY b.`+col+` <=> a.embedding
+			LIMIT $4
+		) n ON n.similarity > $5
+		ORDER BY a.id`,
+		after, opts.Since, scanBatchSize, k, opts.Threshold,
+	)
+	if err != nil {
+		return 0, uuid.Nil, err
 	}
 	defer rows.Close()
 
-	var pairs []DuplicatePair
+	n := 0
+	last := after
 	for rows.Next() {
-		var pair DuplicatePair
-		if err := rows.Scan(&pair.ID1, &pair.ID2, &pair.Similarity); err != nil {
-			return nil, fmt.Errorf("scan duplicate pair: %w", err)
+		var a uuid.UUID
+		var b *uuid.UUID
+		var sim float64
+		if err := rows.Scan(&a, &b, &sim); err != nil {
+			return 0, uuid.Nil, fmt.Errorf("scan duplicate pair: %w", err)
+		}
+		if a != last {
+			n++
+			last = a
+		}
+		if b != nil {
+			found(a, *b, sim)
 		}
-		pairs = append(pairs, pair)
 	}
-
 	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("rows error: %w", err)
+		return 0, uuid.Nil, fmt.Errorf("rows error: %w", err)
 	}
-
-	return pairs, nil
+	return n, last, nil
 }
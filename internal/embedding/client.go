@@ -0,0 +1,99 @@
+package embedding
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// Dimensions is the vector size of every embedding column in the schema.
+const Dimensions = 1536
+
+// Client calls an OpenAI-compatible embeddings endpoint.
+type Client struct {
+	apiKey string
+	model  string
+	apiURL string
+	client *http.Client
+}
+
+func NewClient(apiURL, apiKey, model string) *Client {
+	return &Client{
+		apiKey: apiKey,
+		model:  model,
+		apiURL: apiURL,
+		client: &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+type request struct {
+	Model string `json:"model"`
+	Input string `json:"input"`
+}
+
+type response struct {
+	Data []struct {
+		Embedding []float32 `json:"embedding"`
+	} `json:"data"`
+}
+
+type errorResponse struct {
+	Error struct {
+		Type    string `json:"type"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// Embed returns the embedding vector for text.
+func (c *Client) Embed(ctx context.Context, text string) ([]float32, error) {
+	body, err := json.Marshal(request{Model: c.model, Input: text})
+	if err != nil {
+		return nil, fmt.Errorf("marshal request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.apiURL, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if c.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+c.apiKey)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("api call: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("read response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		var errResp errorResponse
+		if json.Unmarshal(respBody, &errResp) == nil && errResp.Error.Message != "" {
+			return nil, fmt.Errorf("api error %d: %s — %s", resp.StatusCode, errResp.Error.Type, errResp.Error.Message)
+		}
+		return nil, fmt.Errorf("api error %d: %s", resp.StatusCode, string(respBody))
+	}
+
+	var apiResp response
+	if err := json.Unmarshal(respBody, &apiResp); err != nil {
+		return nil, fmt.Errorf("unmarshal response: %w", err)
+	}
+
+	if len(apiResp.Data) == 0 {
+		return nil, fmt.Errorf("empty embedding response")
+	}
+	if n := len(apiResp.Data[0].Embedding); n != Dimensions {
+		return nil, fmt.Errorf("embedding has %d dimensions, expected %d", n, Dimensions)
+	}
+
+	return apiResp.Data[0].Embedding, nil
+}
@@ -0,0 +1,107 @@
+package embedding
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func vectorResponse(n int) []byte {
+	vec := make([]float64, n)
+	for i := range vec {
+		vec[i] = 0.001 * float64(i)
+	}
+	body, _ := json.Marshal(map[string]any{
+		"data": []map[string]any{{"embedding": vec}},
+	})
+	return body
+}
+
+func TestEmbed_Success(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if auth := r.Header.Get("Authorization"); auth != "Bearer test-key" {
+			t.Errorf("expected Authorization Bearer test-key, got %q", auth)
+		}
+
+		var req request
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatalf("failed to decode request: %v", err)
+		}
+		if req.Model != "test-model" {
+			t.Errorf("expected model test-model, got %q", req.Model)
+		}
+		if req.Input != "should we ship on friday" {
+			t.Errorf("unexpected input %q", req.Input)
+		}
+
+		w.WriteHeader(http.StatusOK)
+		w.Write(vectorResponse(Dimensions))
+	}))
+	defer server.Close()
+
+	c := NewClient(server.URL, "test-key", "test-model")
+	vec, err := c.Embed(context.Background(), "should we ship on friday")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(vec) != Dimensions {
+		t.Errorf("expected %d dimensions, got %d", Dimensions, len(vec))
+	}
+}
+
+func TestEmbed_NoAPIKey(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if auth := r.Header.Get("Authorization"); auth != "" {
+			t.Errorf("expected no Authorization header, got %q", auth)
+		}
+		w.Write(vectorResponse(Dimensions))
+	}))
+	defer server.Close()
+
+	c := NewClient(server.URL, "", "local-model")
+	if _, err := c.Embed(context.Background(), "text"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestEmbed_WrongDimensions(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write(vectorResponse(768))
+	}))
+	defer server.Close()
+
+	c := NewClient(server.URL, "test-key", "test-model")
+	_, err := c.Embed(context.Background(), "text")
+	if err == nil || !strings.Contains(err.Error(), "768 dimensions") {
+		t.Errorf("expected dimension error, got %v", err)
+	}
+}
+
+func TestEmbed_APIError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte(`{"error":{"type":"invalid_request_error","message":"bad key"}}`))
+	}))
+	defer server.Close()
+
+	c := NewClient(server.URL, "bad", "test-model")
+	_, err := c.Embed(context.Background(), "text")
+	if err == nil || !strings.Contains(err.Error(), "bad key") {
+		t.Errorf("expected api error with message, got %v", err)
+	}
+}
+
+func TestEmbed_EmptyData(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"data":[]}`))
+	}))
+	defer server.Close()
+
+	c := NewClient(server.URL, "test-key", "test-model")
+	if _, err := c.Embed(context.Background(), "text"); err == nil {
+		t.Error("expected error for empty data")
+	}
+}
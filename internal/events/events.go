@@ -0,0 +1,212 @@
+// Package events defines the NATS contracts dredd publishes and consumes.
+//
+// Every published payload carries schema_version. Additive changes (new
+// optional fields) keep the version; renames, removals, and type changes bump
+// it. Consumed payloads are owned by other services and are described here so
+// dredd's expectations are explicit.
+package events
+
+import "time"
+
+// SchemaVersion is the current version of every payload dredd publishes.
+const SchemaVersion = 1
+
+// Subjects dredd publishes.
+const (
+	SubjectRegistered         = "swarm.agent.dredd.registered"
+	SubjectPatternConfirmed   = "swarm.dredd.pattern.confirmed"
+	SubjectTrustSignal        = "swarm.dredd.trust.signal"
+	SubjectAssignmentSignal   = "swarm.dredd.assignment.signal"
+	SubjectExtractionRejected = "swarm.dredd.extraction.rejected"
+	SubjectCorrection         = "swarm.dredd.correction"
+	SubjectAutonomyChanged    = "swarm.dredd.autonomy.changed"
+	SubjectRefinementProposed = "pattern.refinement.proposed"
+)
+
+// Subjects dredd consumes.
+const (
+	SubjectTranscriptStored = "swarm.chronicle.transcript.stored"
+	SubjectSlackReaction    = "swarm.slack.reaction"
+	SubjectSlackInteraction = "swarm.slack.interaction"
+	SubjectGateEvidence     = "swarm.dispatch.*.gate.evidence"
+	SubjectTaskPicked       = "swarm.slack.task.picked"
+	SubjectTaskRegenerated  = "swarm.slack.task.regenerated"
+)
+
+// Review outcomes carried by trust and correction signals.
+const (
+	OutcomeCorrect   = "correct"
+	OutcomeIncorrect = "incorrect"
+
+	CorrectionConfirmed = "confirmed"
+	CorrectionRejected  = "rejected"
+)
+
+// Registered announces dredd on startup with its autonomy configuration.
+type Registered struct {
+	SchemaVersion int            `json:"schema_version"`
+	Timestamp     time.Time      `json:"timestamp"`
+	Port          int            `json:"port"`
+	Mode          string         `json:"mode"`
+	Modes         []AutonomyMode `json:"modes"`
+}
+
+// AutonomyMode is a per-category/severity override of the default mode.
+type AutonomyMode struct {
+	Category  string    `json:"category"`
+	Severity  string    `json:"severity"`
+	Mode      string    `json:"mode"`
+	Pinned    bool      `json:"pinned"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// PatternConfirmed is emitted when a reviewer confirms a reasoning pattern.
+type PatternConfirmed struct {
+	SchemaVersion int      `json:"schema_version"`
+	PatternType   string   `json:"pattern_type"`
+	Summary       string   `json:"summary"`
+	Tags          []string `json:"tags"`
+	OwnerUUID     string   `json:"owner_uuid"`
+	SessionRef    string   `json:"session_ref"`
+}
+
+// TrustSignal reports whether an agent's decision held up under review.
+type TrustSignal struct {
+	SchemaVersion int    `json:"schema_version"`
+	AgentID       string `json:"agent_id"`
+	Category      string `json:"category"`
+	Outcome       string `json:"outcome"` // correct | incorrect
+	Severity      string `json:"severity"`
+	SessionRef    string `json:"session_ref"`
+}
+
+// AssignmentSignal forwards a reviewed decision that implies a change in how
+// work is assigned (reassignment, budget correction, and so on).
+type AssignmentSignal struct {
+	SchemaVersion int    `json:"schema_version"`
+	SignalType    string `json:"signal_type"`
+	AgentID       string `json:"agent_id"`
+	Category      string `json:"category"`
+	Severity      string `json:"severity"`
+	SessionRef    string `json:"session_ref"`
+}
+
+// ExtractionRejected is emitted when a reviewer rejects an extracted decision,
+// for self-training on extraction mistakes.
+type ExtractionRejected struct {
+	SchemaVersion int    `json:"schema_version"`
+	SessionRef    string `json:"session_ref"`
+	Decision      string `json:"decision"`
+	Category      string `json:"category"`
+}
+
+// CorrectionSignal is emitted when a decision is confirmed or rejected,
+// enabling downstream prompt optimisation loops to adjust extraction quality.
+type CorrectionSignal struct {
+	SchemaVersion  int    `json:"schema_version"`
+	SessionRef     string `json:"session_ref"`
+	DecisionID     string `json:"decision_id"`
+	AgentID        string `json:"agent_id"`
+	ModelID        string `json:"model_id"`
+	ModelTier      string `json:"model_tier"`
+	CorrectionType string `json:"correction_type"` // confirmed | rejected
+	Category       string `json:"category"`
+	Severity       string `json:"severity"`
+}
+
+// AutonomyChanged is emitted whenever a category/severity changes mode.
+type AutonomyChanged struct {
+	SchemaVersion  int       `json:"schema_version"`
+	Category       string    `json:"category"`
+	Severity       string    `json:"severity"`
+	FromMode       string    `json:"from_mode"`
+	ToMode         string    `json:"to_mode"`
+	Trigger        string    `json:"trigger"`
+	Actor          string    `json:"actor"`
+	Reason         string    `json:"reason"`
+	Pinned         bool      `json:"pinned"`
+	TrustScore     float64   `json:"trust_score"`
+	TotalDecisions int       `json:"total_decisions"`
+	Timestamp      time.Time `json:"timestamp"`
+}
+
+// RefinementProposed represents a pattern refinement proposal.
+type RefinementProposed struct {
+	SchemaVersion  int               `json:"schema_version"`
+	ProposalID     string            `json:"proposal_id,omitempty"` // refinement_proposals row; accept or reject through the API
+	ClusterID      string            `json:"cluster_id,omitempty"`  // stable across scans
+	Patterns       []PatternProposal `json:"patterns"`
+	TargetSOULSlug string            `json:"target_soul_slug"`
+	TargetSection  string            `json:"target_section"`
+	ProposedChange string            `json:"proposed_change"`
+	SectionDiff    string            `json:"section_diff,omitempty"` // unified diff of the target section, when drafted
+	ClusterSize    int               `json:"cluster_size"`
+	Timestamp      time.Time         `json:"timestamp"`
+}
+
+// PatternProposal represents a pattern within a refinement proposal.
+type PatternProposal struct {
+	ID          string  `json:"id"`
+	Summary     string  `json:"summary"`
+	PatternType string  `json:"pattern_type"`
+	Confidence  float64 `json:"confidence"`
+}
+
+// TranscriptStored is the NATS event payload from Chronicle.
+type TranscriptStored struct {
+	SessionID  string `json:"session_id"`
+	OwnerUUID  string `json:"owner_uuid"`
+	SessionRef string `json:"session_ref"`
+	Title      string `json:"title"`
+	Duration   string `json:"duration"`
+	Surface    string `json:"surface"`    // e.g. "cc", "slack", "web"
+	Transcript string `json:"transcript"` // full transcript text (preferred delivery method)
+	ModelID    string `json:"model_id,omitempty"`
+	ModelTier  string `json:"model_tier,omitempty"`
+}
+
+// SlackReaction is the slack-forwarder reaction wrapper. Metadata carries
+// text (the emoji), user_id, channel_id, and message_ts.
+type SlackReaction struct {
+	Metadata map[string]string `json:"metadata"`
+}
+
+// SlackInteraction matches the slack-gateway interaction event format.
+type SlackInteraction struct {
+	ActionID  string `json:"action_id"`
+	Value     string `json:"value"`
+	UserID    string `json:"user_id"`
+	UserName  string `json:"user_name"`
+	ChannelID string `json:"channel_id"`
+	MessageTS string `json:"message_ts"`
+	TriggerID string `json:"trigger_id"`
+}
+
+// GateEvidence matches the Dispatch gate evidence NATS event.
+type GateEvidence struct {
+	ItemID          string `json:"item_id"`
+	ItemTitle       string `json:"item_title"`
+	Stage           string `json:"stage"`
+	Criterion       string `json:"criterion"`
+	Evidence        string `json:"evidence"`
+	SubmittedBy     string `json:"submitted_by"`
+	AgentID         string `json:"agent_id"`
+	PromptVersionID string `json:"prompt_version_id,omitempty"`
+}
+
+// TaskPicked matches the slack-gateway task picked event format.
+type TaskPicked struct {
+	ItemID           string   `json:"item_id"`
+	ItemTitle        string   `json:"item_title"`
+	PickedBy         string   `json:"picked_by"`
+	OptionsPresented []string `json:"options_presented"`
+	Timestamp        string   `json:"timestamp"`
+}
+
+// TaskRegenerated is emitted by slack-gateway when the user rejects every
+// presented task option.
+type TaskRegenerated struct {
+	OptionsPresented []string `json:"options_presented"`
+	UserID           string   `json:"user_id"`
+	Timestamp        string   `json:"timestamp"`
+}
@@ -0,0 +1,94 @@
+package events
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+)
+
+func TestContracts_UniqueSubjects(t *testing.T) {
+	seen := make(map[string]bool)
+	for _, c := range Contracts() {
+		if seen[c.Subject] {
+			t.Errorf("duplicate contract for %q", c.Subject)
+		}
+		seen[c.Subject] = true
+
+		if c.Payload == nil {
+			t.Errorf("%s: missing payload type", c.Subject)
+		}
+		if c.Version < 1 {
+			t.Errorf("%s: version must be at least 1, got %d", c.Subject, c.Version)
+		}
+	}
+}
+
+func TestContracts_PublishedCarrySchemaVersion(t *testing.T) {
+	for _, c := range Contracts() {
+		s := c.Schema()
+		_, hasVersion := s.Properties["schema_version"]
+
+		switch c.Direction {
+		case Publishes:
+			if !hasVersion {
+				t.Errorf("%s: published payload has no schema_version", c.Subject)
+			}
+			if !slices.Contains(s.Required, "schema_version") {
+				t.Errorf("%s: schema_version must be required", c.Subject)
+			}
+		case Consumes:
+			if hasVersion {
+				t.Errorf("%s: consumed payloads are owned upstream and should not declare schema_version", c.Subject)
+			}
+		default:
+			t.Errorf("%s: unknown direction %q", c.Subject, c.Direction)
+		}
+	}
+}
+
+func TestCorrectionSignal_WireFormat(t *testing.T) {
+	data, err := json.Marshal(CorrectionSignal{
+		SchemaVersion:  SchemaVersion,
+		SessionRef:     "sess-001",
+		DecisionID:     "dec-abc",
+		CorrectionType: CorrectionRejected,
+	})
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+
+	var raw map[string]any
+	if err := json.Unmarshal(data, &raw); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if raw["schema_version"] != float64(SchemaVersion) {
+		t.Errorf("expected schema_version %d, got %v", SchemaVersion, raw["schema_version"])
+	}
+	if raw["correction_type"] != "rejected" {
+		t.Errorf("expected correction_type rejected, got %v", raw["correction_type"])
+	}
+}
+
+func TestLookup(t *testing.T) {
+	c, ok := Lookup(SubjectTrustSignal)
+	if !ok {
+		t.Fatal("expected trust signal contract")
+	}
+	if c.Direction != Publishes {
+		t.Errorf("expected publishes, got %q", c.Direction)
+	}
+
+	s := c.Schema()
+	if s.ID != SubjectTrustSignal || s.Schema == "" {
+		t.Errorf("expected schema header to be set, got id=%q schema=%q", s.ID, s.Schema)
+	}
+	for _, field := range []string{"agent_id", "category", "outcome", "severity", "session_ref"} {
+		if _, ok := s.Properties[field]; !ok {
+			t.Errorf("trust signal schema missing %q", field)
+		}
+	}
+
+	if _, ok := Lookup("swarm.nope"); ok {
+		t.Error("expected unknown subject to be missing")
+	}
+}
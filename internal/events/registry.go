@@ -0,0 +1,62 @@
+package events
+
+import (
+	"github.com/MikeSquared-Agency/dredd/internal/jsonschema"
+)
+
+// Direction says whether dredd produces or consumes a subject.
+type Direction string
+
+const (
+	Publishes Direction = "publishes"
+	Consumes  Direction = "consumes"
+)
+
+// Contract ties a subject to its payload type and version.
+type Contract struct {
+	Subject     string    `json:"subject"`
+	Direction   Direction `json:"direction"`
+	Version     int       `json:"version"`
+	Description string    `json:"description"`
+	Payload     any       `json:"-"` // zero value of the payload type
+}
+
+// Contracts lists every subject dredd publishes or consumes.
+func Contracts() []Contract {
+	return []Contract{
+		{SubjectRegistered, Publishes, SchemaVersion, "Dredd started and announced its autonomy modes.", Registered{}},
+		{SubjectPatternConfirmed, Publishes, SchemaVersion, "A reviewer confirmed a reasoning pattern.", PatternConfirmed{}},
+		{SubjectTrustSignal, Publishes, SchemaVersion, "A reviewed decision counted for or against an agent's trust.", TrustSignal{}},
+		{SubjectAssignmentSignal, Publishes, SchemaVersion, "A reviewed decision implies an assignment change.", AssignmentSignal{}},
+		{SubjectExtractionRejected, Publishes, SchemaVersion, "A reviewer rejected an extracted decision.", ExtractionRejected{}},
+		{SubjectCorrection, Publishes, SchemaVersion, "A decision was confirmed or rejected, for prompt optimisation.", CorrectionSignal{}},
+		{SubjectAutonomyChanged, Publishes, SchemaVersion, "A category/severity changed autonomy mode.", AutonomyChanged{}},
+		{SubjectRefinementProposed, Publishes, SchemaVersion, "A cluster of patterns suggests a SOUL refinement.", RefinementProposed{}},
+		{SubjectTranscriptStored, Consumes, 1, "Chronicle stored a session transcript.", TranscriptStored{}},
+		{SubjectSlackReaction, Consumes, 1, "A reviewer reacted to a Slack message.", SlackReaction{}},
+		{SubjectSlackInteraction, Consumes, 1, "A reviewer pressed a Slack button.", SlackInteraction{}},
+		{SubjectGateEvidence, Consumes, 1, "Dispatch received evidence for a gate criterion.", GateEvidence{}},
+		{SubjectTaskPicked, Consumes, 1, "A user picked a task from the task picker.", TaskPicked{}},
+		{SubjectTaskRegenerated, Consumes, 1, "A user rejected every task picker option.", TaskRegenerated{}},
+	}
+}
+
+// Lookup returns the contract for subject.
+func Lookup(subject string) (Contract, bool) {
+	for _, c := range Contracts() {
+		if c.Subject == subject {
+			return c, true
+		}
+	}
+	return Contract{}, false
+}
+
+// Schema returns the JSON Schema document for c's payload.
+func (c Contract) Schema() *jsonschema.Schema {
+	s := jsonschema.For(c.Payload)
+	s.Schema = jsonschema.Draft
+	s.ID = c.Subject
+	s.Title = c.Subject
+	s.Description = c.Description
+	return s
+}
@@ -5,9 +5,11 @@ import (
 	"encoding/json"
 	"fmt"
 	"log/slog"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/MikeSquared-Agency/dredd/internal/anthropic"
+	"github.com/MikeSquared-Agency/dredd/internal/metrics"
 )
 
 type Extractor struct {
@@ -25,9 +27,14 @@ type llmResponse struct {
 	Styles    []WritingStyle     `json:"styles"`
 }
 
+// Prompt returns the system and user prompts Extract sends for a transcript.
+func Prompt(sessionRef string, ownerUUID uuid.UUID, transcript string) (system, user string) {
+	return systemPrompt, fmt.Sprintf(extractionUserPrompt, sessionRef, ownerUUID.String(), transcript)
+}
+
 // Extract processes a transcript and returns structured extractions.
 func (e *Extractor) Extract(ctx context.Context, sessionRef string, ownerUUID uuid.UUID, transcript string) (*ExtractionResult, error) {
-	prompt := fmt.Sprintf(extractionUserPrompt, sessionRef, ownerUUID.String(), transcript)
+	system, prompt := Prompt(sessionRef, ownerUUID, transcript)
 
 	messages := []anthropic.Message{
 		{Role: "user", Content: prompt},
@@ -39,13 +46,17 @@ func (e *Extractor) Extract(ctx context.Context, sessionRef string, ownerUUID uu
 		"transcript_len", len(transcript),
 	)
 
-	raw, err := e.llm.Complete(ctx, systemPrompt, messages, 8192)
+	start := time.Now()
+	raw, err := e.llm.Complete(ctx, system, messages, 8192)
+	metrics.ExtractionDuration.Observe(time.Since(start).Seconds())
 	if err != nil {
+		metrics.ExtractionFailures.WithLabelValues("llm").Inc()
 		return nil, fmt.Errorf("llm extraction: %w", err)
 	}
 
 	var resp llmResponse
 	if err := json.Unmarshal([]byte(raw), &resp); err != nil {
+		metrics.ExtractionFailures.WithLabelValues("parse").Inc()
 		e.logger.Error("failed to parse extraction response",
 			"error", err,
 			"raw", raw,
@@ -53,6 +64,13 @@ func (e *Extractor) Extract(ctx context.Context, sessionRef string, ownerUUID uu
 		return nil, fmt.Errorf("parse extraction: %w", err)
 	}
 
+	for _, d := range resp.Decisions {
+		metrics.DecisionsExtracted.WithLabelValues(d.Category).Inc()
+	}
+	for _, p := range resp.Patterns {
+		metrics.PatternsExtracted.WithLabelValues(p.PatternType).Inc()
+	}
+
 	e.logger.Info("extraction complete",
 		"session_ref", sessionRef,
 		"decisions", len(resp.Decisions),
@@ -10,7 +10,9 @@ import (
 	"testing"
 
 	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/MikeSquared-Agency/dredd/internal/anthropic"
+	"github.com/MikeSquared-Agency/dredd/internal/metrics"
 )
 
 func discardLogger() *slog.Logger {
@@ -105,10 +107,16 @@ func TestExtract_InvalidJSON(t *testing.T) {
 
 	ext := New(llm, discardLogger())
 
+	failures := metrics.ExtractionFailures.WithLabelValues("parse")
+	before := testutil.ToFloat64(failures)
+
 	_, err := ext.Extract(context.Background(), "test-session", uuid.New(), "some transcript")
 	if err == nil {
 		t.Fatal("expected error for invalid JSON response")
 	}
+	if got := testutil.ToFloat64(failures) - before; got != 1 {
+		t.Errorf("expected 1 recorded parse failure, got %v", got)
+	}
 }
 
 func TestExtract_EmptyTranscript(t *testing.T) {
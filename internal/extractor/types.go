@@ -1,27 +1,20 @@
 package extractor
 
-import "github.com/google/uuid"
+import (
+	"github.com/google/uuid"
+	"github.com/MikeSquared-Agency/dredd/internal/events"
+)
 
 // TranscriptEvent is the NATS event payload from Chronicle.
-type TranscriptEvent struct {
-	SessionID  string `json:"session_id"`
-	OwnerUUID  string `json:"owner_uuid"`
-	SessionRef string `json:"session_ref"`
-	Title      string `json:"title"`
-	Duration   string `json:"duration"`
-	Surface    string `json:"surface"`    // e.g. "cc", "slack", "web"
-	Transcript string `json:"transcript"` // full transcript text (preferred delivery method)
-	ModelID    string `json:"model_id,omitempty"`
-	ModelTier  string `json:"model_tier,omitempty"`
-}
+type TranscriptEvent = events.TranscriptStored
 
 // ExtractionResult holds all extractions from a single transcript.
 type ExtractionResult struct {
-	SessionRef string
-	OwnerUUID  uuid.UUID
-	Decisions  []DecisionEpisode
-	Patterns   []ReasoningPattern
-	Styles     []WritingStyle
+	SessionRef string             `json:"session_ref"`
+	OwnerUUID  uuid.UUID          `json:"owner_uuid"`
+	Decisions  []DecisionEpisode  `json:"decisions"`
+	Patterns   []ReasoningPattern `json:"patterns"`
+	Styles     []WritingStyle     `json:"styles"`
 }
 
 // DecisionEpisode is a Type 1 extraction — a directive decision.
@@ -8,23 +8,16 @@ import (
 	"time"
 
 	"github.com/nats-io/nats.go"
+	"github.com/MikeSquared-Agency/dredd/internal/events"
+	"github.com/MikeSquared-Agency/dredd/internal/metrics"
 )
 
 // SubjectCorrection is the NATS subject for prompt-loop correction signals.
-const SubjectCorrection = "swarm.dredd.correction"
+const SubjectCorrection = events.SubjectCorrection
 
 // CorrectionSignal is emitted when a decision is confirmed or rejected,
 // enabling downstream prompt optimisation loops to adjust extraction quality.
-type CorrectionSignal struct {
-	SessionRef     string `json:"session_ref"`
-	DecisionID     string `json:"decision_id"`
-	AgentID        string `json:"agent_id"`
-	ModelID        string `json:"model_id"`
-	ModelTier      string `json:"model_tier"`
-	CorrectionType string `json:"correction_type"`
-	Category       string `json:"category"`
-	Severity       string `json:"severity"`
-}
+type CorrectionSignal = events.CorrectionSignal
 
 type Client struct {
 	conn   *nats.Conn
@@ -66,8 +59,18 @@ func (c *Client) Publish(subject string, data any) error {
 	return c.conn.Publish(subject, payload)
 }
 
+// Subscribe delivers messages on subject to handler. A panicking handler is
+// recovered, logged and counted as a handler error rather than taking down
+// the process.
 func (c *Client) Subscribe(subject string, handler func(subject string, data []byte)) error {
 	sub, err := c.conn.Subscribe(subject, func(msg *nats.Msg) {
+		metrics.NATSMessages.WithLabelValues(msg.Subject).Inc()
+		defer func() {
+			if r := recover(); r != nil {
+				metrics.NATSHandlerErrors.WithLabelValues(msg.Subject).Inc()
+				c.logger.Error("nats handler panicked", "subject", msg.Subject, "panic", r)
+			}
+		}()
 		handler(msg.Subject, msg.Data)
 	})
 	if err != nil {
@@ -78,6 +81,14 @@ func (c *Client) Subscribe(subject string, handler func(subject string, data []b
 	return nil
 }
 
+// Ready returns an error unless the NATS connection is established.
+func (c *Client) Ready() error {
+	if status := c.conn.Status(); status != nats.CONNECTED {
+		return fmt.Errorf("nats connection %s", status)
+	}
+	return nil
+}
+
 func (c *Client) Close() {
 	for _, sub := range c.subs {
 		_ = sub.Unsubscribe()
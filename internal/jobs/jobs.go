@@ -0,0 +1,330 @@
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Status is the lifecycle state of a job.
+type Status string
+
+const (
+	StatusQueued    Status = "queued"
+	StatusRunning   Status = "running"
+	StatusSucceeded Status = "succeeded"
+	StatusFailed    Status = "failed"
+	StatusCancelled Status = "cancelled"
+)
+
+// Done reports whether s is a terminal state.
+func (s Status) Done() bool {
+	return s == StatusSucceeded || s == StatusFailed || s == StatusCancelled
+}
+
+// ErrNotRunning is returned by Cancel for a job that has already finished or
+// whose process has gone.
+var ErrNotRunning = errors.New("job is not running")
+
+// Lease is how long a job stays owned by the process running it without a
+// renewal. A manager renews its jobs' leases every Lease/3, so a job whose
+// lease has expired was left behind by a process that stopped.
+const Lease = time.Minute
+
+// Progress is the last progress report of a running job.
+type Progress struct {
+	Stage string `json:"stage"`
+	Done  int    `json:"done"`
+	Total int    `json:"total"`
+}
+
+// Job is a background job and its outcome.
+type Job struct {
+	ID         uuid.UUID       `json:"id"`
+	Kind       string          `json:"kind"`
+	Status     Status          `json:"status"`
+	Params     json.RawMessage `json:"params,omitempty"`
+	Progress   *Progress       `json:"progress,omitempty"`
+	Result     json.RawMessage `json:"result,omitempty"`
+	Error      string          `json:"error,omitempty"`
+	CreatedAt  time.Time       `json:"created_at"`
+	StartedAt  *time.Time      `json:"started_at,omitempty"`
+	FinishedAt *time.Time      `json:"finished_at,omitempty"`
+}
+
+// Store persists jobs so their results outlive the process, and leases them
+// to the process running them so replicas can share it.
+type Store interface {
+	CreateJob(ctx context.Context, j Job) error
+	UpdateJob(ctx context.Context, j Job) error
+	GetJob(ctx context.Context, id uuid.UUID) (*Job, error)
+	// RenewJobs extends the leases of ids and returns those whose
+	// cancellation another process has requested.
+	RenewJobs(ctx context.Context, ids []uuid.UUID) ([]uuid.UUID, error)
+	// RequestJobCancel reports false if the job is not running under a live lease.
+	RequestJobCancel(ctx context.Context, id uuid.UUID) (bool, error)
+	// AbandonJobs fails unfinished jobs whose lease has expired.
+	AbandonJobs(ctx context.Context) (int64, error)
+}
+
+// ReportFunc records the progress of a running job.
+type ReportFunc func(stage string, done, total int)
+
+// Func is the work of a job. It must return promptly once ctx is cancelled.
+// The result is stored as JSON.
+type Func func(ctx context.Context, report ReportFunc) (any, error)
+
+// progressInterval limits how often progress is written to the store; the
+// in-memory copy served by Get is always current.
+const progressInterval = time.Second
+
+// Manager runs jobs in the background and tracks the ones in flight.
+type Manager struct {
+	store  Store
+	logger *slog.Logger
+
+	mu      sync.Mutex
+	running map[uuid.UUID]*run
+	wg      sync.WaitGroup
+
+	stop     chan struct{}
+	stopOnce sync.Once
+}
+
+type run struct {
+	job       Job
+	cancel    context.CancelFunc
+	persisted time.Time
+}
+
+// NewManager creates a job manager backed by store. Until Shutdown it renews
+// the leases of its running jobs, picks up cancellations requested by other
+// processes, and fails jobs abandoned by processes that have gone.
+func NewManager(store Store, logger *slog.Logger) *Manager {
+	return newManager(store, logger, Lease/3)
+}
+
+func newManager(store Store, logger *slog.Logger, renewEvery time.Duration) *Manager {
+	m := &Manager{
+		store:   store,
+		logger:  logger,
+		running: make(map[uuid.UUID]*run),
+		stop:    make(chan struct{}),
+	}
+	go m.keepLeases(renewEvery)
+	return m
+}
+
+// keepLeases renews the leases of running jobs every interval until Shutdown.
+func (m *Manager) keepLeases(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-m.stop:
+			return
+		case <-ticker.C:
+			m.renew()
+		}
+	}
+}
+
+func (m *Manager) renew() {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	m.mu.Lock()
+	ids := make([]uuid.UUID, 0, len(m.running))
+	for id := range m.running {
+		ids = append(ids, id)
+	}
+	m.mu.Unlock()
+
+	if len(ids) > 0 {
+		cancelled, err := m.store.RenewJobs(ctx, ids)
+		if err != nil {
+			m.logger.Error("failed to renew job leases", "jobs", len(ids), "error", err)
+		}
+		for _, id := range cancelled {
+			m.logger.Info("job cancel requested by another process", "job_id", id)
+			m.cancelLocal(id)
+		}
+	}
+
+	if n, err := m.store.AbandonJobs(ctx); err != nil {
+		m.logger.Error("failed to abandon expired jobs", "error", err)
+	} else if n > 0 {
+		m.logger.Warn("marked jobs with expired leases as failed", "count", n)
+	}
+}
+
+// Submit records a new job and starts fn in the background. The job runs
+// independently of ctx, which only bounds the initial insert.
+func (m *Manager) Submit(ctx context.Context, kind string, params any, fn Func) (*Job, error) {
+	raw, err := json.Marshal(params)
+	if err != nil {
+		return nil, fmt.Errorf("marshal params: %w", err)
+	}
+
+	job := Job{
+		ID:        uuid.New(),
+		Kind:      kind,
+		Status:    StatusQueued,
+		Params:    raw,
+		CreatedAt: time.Now().UTC(),
+	}
+	if err := m.store.CreateJob(ctx, job); err != nil {
+		return nil, fmt.Errorf("create job: %w", err)
+	}
+
+	runCtx, cancel := context.WithCancel(context.Background())
+	r := &run{job: job, cancel: cancel}
+
+	m.mu.Lock()
+	m.running[job.ID] = r
+	m.mu.Unlock()
+
+	m.wg.Add(1)
+	go m.execute(runCtx, r, fn)
+
+	return &job, nil
+}
+
+func (m *Manager) execute(ctx context.Context, r *run, fn Func) {
+	defer m.wg.Done()
+	defer r.cancel()
+
+	m.mu.Lock()
+	now := time.Now().UTC()
+	r.job.Status = StatusRunning
+	r.job.StartedAt = &now
+	job := r.job
+	m.mu.Unlock()
+	m.persist(job)
+
+	m.logger.Info("job started", "job_id", job.ID, "kind", job.Kind)
+
+	result, err := fn(ctx, func(stage string, done, total int) {
+		m.report(r, Progress{Stage: stage, Done: done, Total: total})
+	})
+
+	m.mu.Lock()
+	finished := time.Now().UTC()
+	r.job.FinishedAt = &finished
+	switch {
+	case err != nil && ctx.Err() != nil:
+		r.job.Status = StatusCancelled
+		r.job.Error = err.Error()
+	case err != nil:
+		r.job.Status = StatusFailed
+		r.job.Error = err.Error()
+	default:
+		r.job.Status = StatusSucceeded
+		if raw, merr := json.Marshal(result); merr != nil {
+			r.job.Status = StatusFailed
+			r.job.Error = fmt.Sprintf("marshal result: %v", merr)
+		} else {
+			r.job.Result = raw
+		}
+	}
+	job = r.job
+	delete(m.running, job.ID)
+	m.mu.Unlock()
+	m.persist(job)
+
+	m.logger.Info("job finished", "job_id", job.ID, "kind", job.Kind, "status", job.Status, "error", job.Error)
+}
+
+func (m *Manager) report(r *run, p Progress) {
+	m.mu.Lock()
+	r.job.Progress = &p
+	if time.Since(r.persisted) < progressInterval {
+		m.mu.Unlock()
+		return
+	}
+	r.persisted = time.Now()
+	job := r.job
+	m.mu.Unlock()
+	m.persist(job)
+}
+
+// persist writes job to the store. It deliberately ignores the job context so
+// that the final state of a cancelled job is still recorded.
+func (m *Manager) persist(job Job) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := m.store.UpdateJob(ctx, job); err != nil {
+		m.logger.Error("failed to persist job", "job_id", job.ID, "status", job.Status, "error", err)
+	}
+}
+
+// Get returns a job, preferring the live copy of a job running in this process.
+func (m *Manager) Get(ctx context.Context, id uuid.UUID) (*Job, error) {
+	m.mu.Lock()
+	if r, ok := m.running[id]; ok {
+		job := r.job
+		m.mu.Unlock()
+		return &job, nil
+	}
+	m.mu.Unlock()
+	return m.store.GetJob(ctx, id)
+}
+
+// Cancel cancels a running job. A job running in this process has its
+// context cancelled at once; one running in another process is cancelled at
+// that process's next lease renewal. The job records its cancelled state once
+// its function returns.
+func (m *Manager) Cancel(ctx context.Context, id uuid.UUID) error {
+	if m.cancelLocal(id) {
+		return nil
+	}
+	requested, err := m.store.RequestJobCancel(ctx, id)
+	if err != nil {
+		return err
+	}
+	if !requested {
+		return ErrNotRunning
+	}
+	return nil
+}
+
+// cancelLocal cancels id's context if it runs in this process.
+func (m *Manager) cancelLocal(id uuid.UUID) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	r, ok := m.running[id]
+	if ok {
+		r.cancel()
+	}
+	return ok
+}
+
+// Shutdown cancels every running job and waits for them to record their final
+// state, or for ctx to expire.
+func (m *Manager) Shutdown(ctx context.Context) error {
+	m.stopOnce.Do(func() { close(m.stop) })
+
+	m.mu.Lock()
+	for _, r := range m.running {
+		r.cancel()
+	}
+	m.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		m.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,216 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type memStore struct {
+	mu      sync.Mutex
+	jobs    map[uuid.UUID]Job
+	cancels map[uuid.UUID]bool
+}
+
+func newMemStore() *memStore {
+	return &memStore{jobs: make(map[uuid.UUID]Job), cancels: make(map[uuid.UUID]bool)}
+}
+
+func (s *memStore) CreateJob(ctx context.Context, j Job) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.jobs[j.ID] = j
+	return nil
+}
+
+func (s *memStore) UpdateJob(ctx context.Context, j Job) error {
+	return s.CreateJob(ctx, j)
+}
+
+func (s *memStore) GetJob(ctx context.Context, id uuid.UUID) (*Job, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	j, ok := s.jobs[id]
+	if !ok {
+		return nil, errors.New("not found")
+	}
+	return &j, nil
+}
+
+func (s *memStore) RenewJobs(ctx context.Context, ids []uuid.UUID) ([]uuid.UUID, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var cancel []uuid.UUID
+	for _, id := range ids {
+		if s.cancels[id] {
+			cancel = append(cancel, id)
+		}
+	}
+	return cancel, nil
+}
+
+func (s *memStore) RequestJobCancel(ctx context.Context, id uuid.UUID) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	j, ok := s.jobs[id]
+	if !ok || j.Status.Done() {
+		return false, nil
+	}
+	s.cancels[id] = true
+	return true, nil
+}
+
+func (s *memStore) AbandonJobs(ctx context.Context) (int64, error) {
+	return 0, nil
+}
+
+func testManager() (*Manager, *memStore) {
+	s := newMemStore()
+	return NewManager(s, slog.New(slog.NewTextHandler(io.Discard, nil))), s
+}
+
+func waitDone(t *testing.T, m *Manager, id uuid.UUID) *Job {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		job, err := m.Get(context.Background(), id)
+		if err != nil {
+			t.Fatalf("get job: %v", err)
+		}
+		if job.Status.Done() {
+			return job
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	t.Fatal("job did not finish")
+	return nil
+}
+
+func TestManager_Succeeds(t *testing.T) {
+	m, s := testManager()
+
+	job, err := m.Submit(context.Background(), "dedup", map[string]float64{"threshold": 0.9}, func(ctx context.Context, report ReportFunc) (any, error) {
+		report("merge", 1, 2)
+		report("merge", 2, 2)
+		return map[string]int{"deduped": 3}, nil
+	})
+	if err != nil {
+		t.Fatalf("submit: %v", err)
+	}
+	if job.Status != StatusQueued {
+		t.Errorf("expected queued, got %s", job.Status)
+	}
+
+	done := waitDone(t, m, job.ID)
+	if done.Status != StatusSucceeded {
+		t.Fatalf("expected succeeded, got %s (%s)", done.Status, done.Error)
+	}
+	if string(done.Result) != `{"deduped":3}` {
+		t.Errorf("unexpected result %s", done.Result)
+	}
+	if done.Progress == nil || done.Progress.Done != 2 {
+		t.Errorf("expected final progress 2/2, got %+v", done.Progress)
+	}
+
+	stored, _ := s.GetJob(context.Background(), job.ID)
+	if stored.Status != StatusSucceeded || stored.FinishedAt == nil {
+		t.Errorf("expected stored job to be finished, got %+v", stored)
+	}
+	if string(stored.Params) != `{"threshold":0.9}` {
+		t.Errorf("unexpected params %s", stored.Params)
+	}
+}
+
+func TestManager_Fails(t *testing.T) {
+	m, _ := testManager()
+
+	job, _ := m.Submit(context.Background(), "dedup", nil, func(ctx context.Context, report ReportFunc) (any, error) {
+		return nil, errors.New("scan failed")
+	})
+
+	done := waitDone(t, m, job.ID)
+	if done.Status != StatusFailed || done.Error != "scan failed" {
+		t.Errorf("expected failed with error, got %s %q", done.Status, done.Error)
+	}
+}
+
+func TestManager_Cancel(t *testing.T) {
+	m, _ := testManager()
+	started := make(chan struct{})
+
+	job, _ := m.Submit(context.Background(), "dedup", nil, func(ctx context.Context, report ReportFunc) (any, error) {
+		close(started)
+		<-ctx.Done()
+		return nil, ctx.Err()
+	})
+	<-started
+
+	if err := m.Cancel(context.Background(), job.ID); err != nil {
+		t.Fatalf("cancel: %v", err)
+	}
+	done := waitDone(t, m, job.ID)
+	if done.Status != StatusCancelled {
+		t.Errorf("expected cancelled, got %s", done.Status)
+	}
+
+	if err := m.Cancel(context.Background(), job.ID); !errors.Is(err, ErrNotRunning) {
+		t.Errorf("expected ErrNotRunning for finished job, got %v", err)
+	}
+}
+
+func TestManager_CancelFromAnotherProcess(t *testing.T) {
+	s := newMemStore()
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	owner := newManager(s, logger, 10*time.Millisecond)
+	other := newManager(s, logger, 10*time.Millisecond)
+	t.Cleanup(func() {
+		owner.Shutdown(context.Background())
+		other.Shutdown(context.Background())
+	})
+	started := make(chan struct{})
+
+	job, _ := owner.Submit(context.Background(), "dedup", nil, func(ctx context.Context, report ReportFunc) (any, error) {
+		close(started)
+		<-ctx.Done()
+		return nil, ctx.Err()
+	})
+	<-started
+
+	if err := other.Cancel(context.Background(), job.ID); err != nil {
+		t.Fatalf("cancel: %v", err)
+	}
+	done := waitDone(t, owner, job.ID)
+	if done.Status != StatusCancelled {
+		t.Errorf("expected cancelled by the owner's lease renewal, got %s", done.Status)
+	}
+
+	if err := other.Cancel(context.Background(), uuid.New()); !errors.Is(err, ErrNotRunning) {
+		t.Errorf("expected ErrNotRunning for an unknown job, got %v", err)
+	}
+}
+
+func TestManager_Shutdown(t *testing.T) {
+	m, s := testManager()
+
+	job, _ := m.Submit(context.Background(), "dedup", nil, func(ctx context.Context, report ReportFunc) (any, error) {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := m.Shutdown(ctx); err != nil {
+		t.Fatalf("shutdown: %v", err)
+	}
+
+	stored, _ := s.GetJob(context.Background(), job.ID)
+	if stored.Status != StatusCancelled {
+		t.Errorf("expected cancelled after shutdown, got %s", stored.Status)
+	}
+}
@@ -0,0 +1,104 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type inner struct {
+	Value float64 `json:"value"`
+}
+
+type Base struct {
+	Version int `json:"version"`
+}
+
+type sample struct {
+	Base
+	ID       uuid.UUID         `json:"id"`
+	Name     string            `json:"name"`
+	Count    int               `json:"count,omitempty"`
+	Enabled  bool              `json:"enabled"`
+	Tags     []string          `json:"tags"`
+	Labels   map[string]string `json:"labels,omitempty"`
+	Nested   *inner            `json:"nested,omitempty"`
+	At       time.Time         `json:"at"`
+	Skipped  string            `json:"-"`
+	internal string
+}
+
+func TestFor_Struct(t *testing.T) {
+	s := For(sample{})
+
+	if s.Type != "object" {
+		t.Fatalf("expected object, got %q", s.Type)
+	}
+
+	want := map[string]string{
+		"version": "integer",
+		"id":      "string",
+		"name":    "string",
+		"count":   "integer",
+		"enabled": "boolean",
+		"tags":    "array",
+		"labels":  "object",
+		"nested":  "object",
+		"at":      "string",
+	}
+	if len(s.Properties) != len(want) {
+		t.Errorf("expected %d properties, got %d: %v", len(want), len(s.Properties), s.Properties)
+	}
+	for name, typ := range want {
+		p, ok := s.Properties[name]
+		if !ok {
+			t.Errorf("missing property %q", name)
+			continue
+		}
+		if p.Type != typ {
+			t.Errorf("property %q: expected type %q, got %q", name, typ, p.Type)
+		}
+	}
+
+	if s.Properties["at"].Format != "date-time" {
+		t.Errorf("expected date-time format, got %q", s.Properties["at"].Format)
+	}
+	if s.Properties["tags"].Items.Type != "string" {
+		t.Errorf("expected string items, got %q", s.Properties["tags"].Items.Type)
+	}
+	if s.Properties["nested"].Properties["value"].Type != "number" {
+		t.Error("expected nested value to be a number")
+	}
+
+	wantRequired := []string{"version", "id", "name", "enabled", "tags", "at"}
+	if !reflect.DeepEqual(s.Required, wantRequired) {
+		t.Errorf("required = %v, want %v", s.Required, wantRequired)
+	}
+}
+
+func TestFor_Scalars(t *testing.T) {
+	tests := []struct {
+		name string
+		v    any
+		want string
+	}{
+		{"string", "", "string"},
+		{"int", 0, "integer"},
+		{"float", 0.0, "number"},
+		{"bool", false, "boolean"},
+		{"pointer", new(int), "integer"},
+		{"any", nil, ""},
+		{"raw json", json.RawMessage(`{}`), ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := For(tt.v).Type; got != tt.want {
+				t.Errorf("For(%T).Type = %q, want %q", tt.v, got, tt.want)
+			}
+		})
+	}
+}
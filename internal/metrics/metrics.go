@@ -0,0 +1,161 @@
+// Package metrics defines the Prometheus metrics exported at /metrics.
+//
+// Collectors are package-level so the pipeline can be instrumented where the
+// work happens without threading a registry through every constructor. They
+// are registered on a dedicated registry rather than the global default, so
+// only Dredd's metrics (plus Go runtime and process metrics) are exported.
+package metrics
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "dredd"
+
+// Outcome label values.
+const (
+	OutcomeSuccess = "success"
+	OutcomeFailure = "failure"
+)
+
+// Registry holds every Dredd collector.
+var Registry = prometheus.NewRegistry()
+
+var (
+	// TranscriptsReceived counts transcript.stored events received over NATS.
+	TranscriptsReceived = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "transcripts_received_total",
+		Help:      "Transcript stored events received.",
+	})
+
+	// TranscriptsProcessed counts received transcripts by outcome.
+	TranscriptsProcessed = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "transcripts_processed_total",
+		Help:      "Transcripts fully processed, by outcome (success, failure).",
+	}, []string{"outcome"})
+
+	// ExtractionDuration observes the latency of each LLM extraction.
+	ExtractionDuration = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "extraction_duration_seconds",
+		Help:      "Latency of transcript extraction, including the LLM call.",
+		Buckets:   prometheus.ExponentialBuckets(0.5, 2, 10), // 0.5s to ~4m
+	})
+
+	// ExtractionFailures counts failed extractions by reason.
+	ExtractionFailures = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "extraction_failures_total",
+		Help:      "Failed extractions, by reason (llm, parse).",
+	}, []string{"reason"})
+
+	// DecisionsExtracted counts extracted decisions by category.
+	DecisionsExtracted = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "decisions_extracted_total",
+		Help:      "Decisions extracted from transcripts, by category.",
+	}, []string{"category"})
+
+	// PatternsExtracted counts extracted reasoning patterns by pattern type.
+	PatternsExtracted = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "patterns_extracted_total",
+		Help:      "Reasoning patterns extracted from transcripts, by pattern type.",
+	}, []string{"pattern_type"})
+
+	// LLMTokens counts tokens billed by the LLM API.
+	LLMTokens = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "llm_tokens_total",
+		Help:      "LLM tokens used, by model and direction (input, output).",
+	}, []string{"model", "direction"})
+
+	// SlackPostFailures counts Slack messages that could not be posted.
+	SlackPostFailures = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "slack_post_failures_total",
+		Help:      "Failed Slack posts, by kind (message, thread).",
+	}, []string{"kind"})
+
+	// ReviewVerdicts counts recorded review verdicts.
+	ReviewVerdicts = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "review_verdicts_total",
+		Help:      "Review verdicts recorded, by kind (decision, pattern), verdict and channel.",
+	}, []string{"kind", "verdict", "channel"})
+
+	// TrustUpdates counts trust records written.
+	TrustUpdates = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "trust_updates_total",
+		Help:      "Trust score updates, by outcome of the signal (correct, incorrect).",
+	}, []string{"outcome"})
+
+	// NATSMessages counts messages delivered to NATS handlers.
+	NATSMessages = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "nats_messages_total",
+		Help:      "NATS messages delivered to handlers, by subject.",
+	}, []string{"subject"})
+
+	// NATSHandlerErrors counts NATS messages whose handler failed or panicked.
+	NATSHandlerErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "nats_handler_errors_total",
+		Help:      "NATS messages whose handler failed, by subject.",
+	}, []string{"subject"})
+
+	// DedupRuns counts dedup runs by table, mode and outcome.
+	DedupRuns = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "dedup_runs_total",
+		Help:      "Dedup runs, by table, mode (dry_run, execute) and outcome.",
+	}, []string{"table", "mode", "outcome"})
+
+	// DedupMerged counts records merged into a survivor by executed dedup runs.
+	DedupMerged = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "dedup_merged_total",
+		Help:      "Records merged into a survivor by executed dedup runs, by table.",
+	}, []string{"table"})
+)
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		TranscriptsReceived,
+		TranscriptsProcessed,
+		ExtractionDuration,
+		ExtractionFailures,
+		DecisionsExtracted,
+		PatternsExtracted,
+		LLMTokens,
+		SlackPostFailures,
+		ReviewVerdicts,
+		TrustUpdates,
+		NATSMessages,
+		NATSHandlerErrors,
+		DedupRuns,
+		DedupMerged,
+	)
+}
+
+// Handler serves Registry in the Prometheus exposition format.
+func Handler() http.Handler {
+	return promhttp.HandlerFor(Registry, promhttp.HandlerOpts{Registry: Registry})
+}
+
+// Outcome returns OutcomeFailure if err is non-nil, OutcomeSuccess otherwise.
+func Outcome(err error) string {
+	if err != nil {
+		return OutcomeFailure
+	}
+	return OutcomeSuccess
+}
@@ -7,23 +7,26 @@ import (
 	"strings"
 
 	"github.com/google/uuid"
+	"github.com/MikeSquared-Agency/dredd/internal/autonomy"
+	"github.com/MikeSquared-Agency/dredd/internal/events"
 	"github.com/MikeSquared-Agency/dredd/internal/extractor"
+	"github.com/MikeSquared-Agency/dredd/internal/shadow"
+	"github.com/MikeSquared-Agency/dredd/internal/store"
 )
 
+// shadowNeighbours is how many similar past submissions vote on a shadow prediction.
+const shadowNeighbours = 5
+
+// shadowExamples caps how many past gate decisions are compared per stage.
+const shadowExamples = 200
+
 // InteractionEvent matches the slack-gateway interaction event format.
-type InteractionEvent struct {
-	ActionID  string `json:"action_id"`
-	Value     string `json:"value"`
-	UserID    string `json:"user_id"`
-	UserName  string `json:"user_name"`
-	ChannelID string `json:"channel_id"`
-	MessageTS string `json:"message_ts"`
-	TriggerID string `json:"trigger_id"`
-}
+type InteractionEvent = events.SlackInteraction
 
 type gateMetadata struct {
-	ItemID string `json:"item_id"`
-	Stage  string `json:"stage"`
+	ItemID   string `json:"item_id"`
+	Stage    string `json:"stage"`
+	Severity string `json:"severity,omitempty"` // the gate's severity; routine when unset
 }
 
 // HandleGateDecision processes gate approval/rejection interactions from Slack.
@@ -31,6 +34,7 @@ func (p *Processor) HandleGateDecision(subject string, data []byte) {
 	var evt InteractionEvent
 	if err := json.Unmarshal(data, &evt); err != nil {
 		p.logger.Warn("failed to parse interaction event", "error", err)
+		handlerFailed(subject)
 		return
 	}
 
@@ -67,7 +71,7 @@ func (p *Processor) HandleGateDecision(subject string, data []byte) {
 		severity = "critical"
 	}
 
-	summary := "Gate " + decisionType + ": item " + itemID[:8] + " stage " + meta.Stage
+	summary := "Gate " + decisionType + ": item " + shortID(itemID) + " stage " + meta.Stage
 	if evt.UserName != "" {
 		summary += " by " + evt.UserName
 	}
@@ -102,46 +106,86 @@ func (p *Processor) HandleGateDecision(subject string, data []byte) {
 			"stage", meta.Stage,
 			"type", decisionType,
 		)
+		handlerFailed(subject)
 		return
 	}
 
+	p.decisionStored(id, ownerUUID, meta.ItemID, "slack-gateway", ep, false)
+
 	p.logger.Info("gate decision captured",
 		"decision_id", id,
-		"item_id", itemID[:8],
+		"item_id", shortID(itemID),
 		"stage", meta.Stage,
 		"type", decisionType,
 		"user", evt.UserName,
 	)
+
+	// Predictions are scored under the gate's severity, not the verdict's:
+	// trust and autonomy for a severity must see every verdict given at it.
+	p.scoreGatePredictions(ctx, meta.ItemID, meta.Stage, decisionType, shadow.GateSeverity(meta.Severity))
 }
 
+// scoreGatePredictions resolves any open shadow predictions for the item/stage
+// against the human verdict. Each agreement or disagreement is a trust signal
+// for Dredd itself, which in turn drives autonomy promotion for gate approvals.
+func (p *Processor) scoreGatePredictions(ctx context.Context, itemID, stage, verdict, severity string) {
+	if stage == "unknown" {
+		stage = "" // metadata missing — match the item at any stage
+	}
 
-// GateEvidenceEvent matches the Dispatch gate evidence NATS event.
-type GateEvidenceEvent struct {
-	ItemID          string `json:"item_id"`
-	ItemTitle       string `json:"item_title"`
-	Stage           string `json:"stage"`
-	Criterion       string `json:"criterion"`
-	Evidence        string `json:"evidence"`
-	SubmittedBy     string `json:"submitted_by"`
-	AgentID         string `json:"agent_id"`
-	PromptVersionID string `json:"prompt_version_id,omitempty"`
+	resolved, err := p.store.ResolveGatePredictions(ctx, itemID, stage, verdict)
+	if err != nil {
+		p.logger.Error("failed to resolve gate predictions", "item_id", shortID(itemID), "error", err)
+		return
+	}
+	if len(resolved) == 0 {
+		return
+	}
+
+	for _, pred := range resolved {
+		agreed := pred.Agreed != nil && *pred.Agreed
+		p.logger.Info("shadow prediction scored",
+			"item_id", shortID(itemID),
+			"stage", pred.Stage,
+			"predicted", pred.PredictedVerdict,
+			"actual", verdict,
+			"agreed", agreed,
+			"confidence", pred.Confidence,
+		)
+		p.updateTrust(ctx, autonomy.AgentID, pred.Category, severity, agreed)
+	}
+
+	if p.autonomy != nil {
+		if _, err := p.autonomy.Evaluate(ctx, shadow.Category, severity); err != nil {
+			p.logger.Error("autonomy evaluation failed", "category", shadow.Category, "severity", severity, "error", err)
+		}
+	}
 }
 
-// HandleGateEvidence captures evidence submissions and logs prompt version attribution.
+
+// GateEvidenceEvent matches the Dispatch gate evidence NATS event.
+type GateEvidenceEvent = events.GateEvidence
+
+// HandleGateEvidence predicts the gate verdict in shadow mode and captures
+// prompt version attribution for evidence submissions.
 func (p *Processor) HandleGateEvidence(subject string, data []byte) {
 	var evt GateEvidenceEvent
 	if err := json.Unmarshal(data, &evt); err != nil {
 		p.logger.Warn("failed to parse gate evidence event", "error", err)
+		handlerFailed(subject)
 		return
 	}
 
+	ctx := context.Background()
+	p.predictGateVerdict(ctx, evt)
+
 	// Only log if we have version attribution
 	if evt.PromptVersionID == "" {
 		return
 	}
 
 	p.logger.Info("gate evidence with version attribution",
-		"item_id", evt.ItemID[:8],
+		"item_id", shortID(evt.ItemID),
 		"stage", evt.Stage,
 		"criterion", evt.Criterion,
 		"prompt_version_id", evt.PromptVersionID,
@@ -153,7 +197,7 @@ func (p *Processor) HandleGateEvidence(subject string, data []byte) {
 		Domain:        "gate_evidence",
 		Category:      evt.Stage,
 		Severity:      "routine",
-		Summary:       "Evidence submitted for " + evt.ItemID[:8] + " stage " + evt.Stage + " criterion " + evt.Criterion,
+		Summary:       "Evidence submitted for " + shortID(evt.ItemID) + " stage " + evt.Stage + " criterion " + evt.Criterion,
 		SituationText: "Evidence: " + evt.Evidence,
 		Reasoning: extractor.DecisionReasoning{
 			ReasoningText: "Agent " + evt.AgentID + " submitted evidence using prompt version " + evt.PromptVersionID,
@@ -164,38 +208,95 @@ func (p *Processor) HandleGateEvidence(subject string, data []byte) {
 		ModelID:    evt.PromptVersionID,
 	}
 
-	ctx := context.Background()
-	_, err := p.store.WriteDecisionEpisode(ctx, uuid.Nil, evt.ItemID, "dispatch", ep)
+	id, err := p.store.WriteDecisionEpisode(ctx, uuid.Nil, evt.ItemID, "dispatch", ep)
 	if err != nil {
 		p.logger.Error("failed to store versioned evidence",
 			"error", err,
-			"item_id", evt.ItemID[:8],
+			"item_id", shortID(evt.ItemID),
 			"prompt_version_id", evt.PromptVersionID,
 		)
+		handlerFailed(subject)
+		return
 	}
+	p.decisionStored(id, uuid.Nil, evt.ItemID, "dispatch", ep, false)
 }
 
-// TaskPickedEvent matches the slack-gateway task picked event format.
-type TaskPickedEvent struct {
-	ItemID           string   `json:"item_id"`
-	ItemTitle        string   `json:"item_title"`
-	PickedBy         string   `json:"picked_by"`
-	OptionsPresented []string `json:"options_presented"`
-	Timestamp        string   `json:"timestamp"`
+// predictGateVerdict records a shadow prediction of the human verdict for the
+// evidence's item/stage. Repeat submissions for the same item/stage refine a
+// single open prediction rather than creating new ones. Nothing is published:
+// the prediction only exists to be scored when the human verdict arrives.
+func (p *Processor) predictGateVerdict(ctx context.Context, evt GateEvidenceEvent) {
+	if evt.ItemID == "" || evt.Stage == "" {
+		return
+	}
+
+	pred, err := p.store.GetOpenGatePrediction(ctx, evt.ItemID, evt.Stage)
+	if err != nil && !store.IsNotFound(err) {
+		p.logger.Error("failed to load open gate prediction", "item_id", shortID(evt.ItemID), "error", err)
+		return
+	}
+
+	submission := "[" + evt.Criterion + "] " + evt.Evidence
+	if pred == nil {
+		pred = &store.GatePrediction{
+			ItemID:   evt.ItemID,
+			Stage:    evt.Stage,
+			Category: shadow.Category,
+			Evidence: submission,
+		}
+	} else {
+		pred.Evidence += "\n" + submission
+	}
+
+	past, err := p.store.ListGateDecisions(ctx, evt.Stage, shadowExamples)
+	if err != nil {
+		p.logger.Error("failed to load past gate decisions", "stage", evt.Stage, "error", err)
+		return
+	}
+	examples := make([]shadow.Example, 0, len(past))
+	for _, prev := range past {
+		examples = append(examples, shadow.Example{Text: prev.Evidence, Reasoning: prev.Reasoning, Verdict: prev.Verdict})
+	}
+
+	priors, err := p.store.CountGateVerdicts(ctx, evt.Stage)
+	if err != nil {
+		p.logger.Error("failed to count gate verdicts", "stage", evt.Stage, "error", err)
+		return
+	}
+
+	prediction := shadow.Predict(pred.Evidence, examples, priors, shadowNeighbours)
+	pred.PredictedVerdict = prediction.Verdict
+	pred.Confidence = prediction.Confidence
+	pred.Rationale = prediction.Rationale
+	pred.Neighbours = prediction.Neighbours
+
+	if err := p.store.SaveGatePrediction(ctx, pred); err != nil {
+		p.logger.Error("failed to save gate prediction", "item_id", shortID(evt.ItemID), "error", err)
+		return
+	}
+
+	p.logger.Info("shadow gate prediction",
+		"item_id", shortID(evt.ItemID),
+		"stage", evt.Stage,
+		"verdict", prediction.Verdict,
+		"confidence", prediction.Confidence,
+		"neighbours", prediction.Neighbours,
+	)
 }
 
+// TaskPickedEvent matches the slack-gateway task picked event format.
+type TaskPickedEvent = events.TaskPicked
+
 // HandleTaskPicked captures task picker selection decisions.
 func (p *Processor) HandleTaskPicked(subject string, data []byte) {
 	var evt TaskPickedEvent
 	if err := json.Unmarshal(data, &evt); err != nil {
 		p.logger.Warn("failed to parse task picked event", "error", err)
+		handlerFailed(subject)
 		return
 	}
 
-	itemShort := evt.ItemID
-	if len(itemShort) > 8 {
-		itemShort = itemShort[:8]
-	}
+	itemShort := shortID(evt.ItemID)
 
 	// Build situation text with all options
 	situation := "Task picker presented " + strings.Join(evt.OptionsPresented, ", ")
@@ -238,9 +339,12 @@ func (p *Processor) HandleTaskPicked(subject string, data []byte) {
 	id, err := p.store.WriteDecisionEpisode(ctx, uuid.Nil, evt.ItemID, "slack-gateway", ep)
 	if err != nil {
 		p.logger.Error("failed to store task pick decision", "error", err, "item_id", itemShort)
+		handlerFailed(subject)
 		return
 	}
 
+	p.decisionStored(id, uuid.Nil, evt.ItemID, "slack-gateway", ep, false)
+
 	p.logger.Info("task pick decision captured",
 		"decision_id", id,
 		"item_id", itemShort,
@@ -251,13 +355,10 @@ func (p *Processor) HandleTaskPicked(subject string, data []byte) {
 
 // HandleTaskRegenerate captures when user rejects all presented options.
 func (p *Processor) HandleTaskRegenerate(subject string, data []byte) {
-	var evt struct {
-		OptionsPresented []string `json:"options_presented"`
-		UserID           string   `json:"user_id"`
-		Timestamp        string   `json:"timestamp"`
-	}
+	var evt events.TaskRegenerated
 	if err := json.Unmarshal(data, &evt); err != nil {
 		p.logger.Warn("failed to parse task regenerate event", "error", err)
+		handlerFailed(subject)
 		return
 	}
 
@@ -280,11 +381,22 @@ func (p *Processor) HandleTaskRegenerate(subject string, data []byte) {
 	id, err := p.store.WriteDecisionEpisode(ctx, uuid.Nil, "regenerate", "slack-gateway", ep)
 	if err != nil {
 		p.logger.Error("failed to store task regenerate decision", "error", err)
+		handlerFailed(subject)
 		return
 	}
 
+	p.decisionStored(id, uuid.Nil, "regenerate", "slack-gateway", ep, false)
+
 	p.logger.Info("task regenerate decision captured",
 		"decision_id", id,
 		"options_rejected", len(evt.OptionsPresented),
 	)
 }
+
+// shortID truncates an ID to 8 characters for logs and summaries.
+func shortID(id string) string {
+	if len(id) > 8 {
+		return id[:8]
+	}
+	return id
+}
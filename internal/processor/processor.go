@@ -4,14 +4,17 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"io"
 	"log/slog"
-	"net/http"
 	"sync"
 
 	"github.com/google/uuid"
+	"github.com/MikeSquared-Agency/dredd/internal/autonomy"
+	"github.com/MikeSquared-Agency/dredd/internal/bus"
+	"github.com/MikeSquared-Agency/dredd/internal/chronicle"
+	"github.com/MikeSquared-Agency/dredd/internal/events"
 	"github.com/MikeSquared-Agency/dredd/internal/extractor"
 	"github.com/MikeSquared-Agency/dredd/internal/hermes"
+	"github.com/MikeSquared-Agency/dredd/internal/metrics"
 	"github.com/MikeSquared-Agency/dredd/internal/slack"
 	"github.com/MikeSquared-Agency/dredd/internal/store"
 	"github.com/MikeSquared-Agency/dredd/internal/trust"
@@ -25,6 +28,10 @@ type Processor struct {
 	slack        *slack.Poster
 	logger       *slog.Logger
 	chronicleURL string
+	autonomy     *autonomy.Manager // optional — re-evaluates modes after Dredd's own trust changes
+	bus          *bus.Bus          // optional — live events for the API stream
+	embedder     Embedder          // optional — embeds extractions as they are written
+	matchAbove   float64           // similarity at which a written extraction recurs an existing one; 0 disables
 
 	mu             sync.Mutex
 	pendingReviews map[string]*pendingReview // keyed by header TS (for rejection thread replies)
@@ -66,19 +73,47 @@ func New(s *store.Store, ext *extractor.Extractor, h *hermes.Client, sl *slack.P
 	}
 }
 
+// SetAutonomyManager enables autonomy re-evaluation when shadow predictions are scored.
+func (p *Processor) SetAutonomyManager(m *autonomy.Manager) {
+	p.autonomy = m
+}
+
+// SetEventBus publishes pipeline activity (stored extractions, reviews, trust
+// changes) to b as it happens.
+func (p *Processor) SetEventBus(b *bus.Bus) {
+	p.bus = b
+}
+
+// Embedder turns text into a vector.
+type Embedder interface {
+	Embed(ctx context.Context, text string) ([]float32, error)
+}
+
+// SetEmbedder embeds decisions and patterns as they are written. With a
+// threshold above zero, an extraction at least that similar to an existing
+// live one of the same kind is recorded as another occurrence of it rather
+// than stored and posted for review again.
+func (p *Processor) SetEmbedder(e Embedder, threshold float64) {
+	p.embedder = e
+	p.matchAbove = threshold
+}
+
 // HandleTranscriptStored is the NATS handler for swarm.chronicle.transcript.stored.
 func (p *Processor) HandleTranscriptStored(subject string, data []byte) {
 	ctx := context.Background()
+	metrics.TranscriptsReceived.Inc()
 
 	var evt extractor.TranscriptEvent
 	if err := json.Unmarshal(data, &evt); err != nil {
 		p.logger.Error("failed to parse transcript event", "error", err)
+		handlerFailed(subject)
 		return
 	}
 
 	ownerUUID, err := uuid.Parse(evt.OwnerUUID)
 	if err != nil {
 		p.logger.Error("invalid owner uuid", "owner_uuid", evt.OwnerUUID, "error", err)
+		handlerFailed(subject)
 		return
 	}
 
@@ -88,18 +123,54 @@ func (p *Processor) HandleTranscriptStored(subject string, data []byte) {
 		"owner", evt.OwnerUUID,
 	)
 
+	res, err := p.Process(ctx, evt, ownerUUID, ProcessOptions{Persist: true, PostToSlack: true})
+	metrics.TranscriptsProcessed.WithLabelValues(metrics.Outcome(err)).Inc()
+	if err != nil {
+		p.logger.Error("transcript processing failed", "session_ref", evt.SessionRef, "error", err)
+		handlerFailed(subject)
+		return
+	}
+
+	p.logger.Info("transcript processed",
+		"session_ref", evt.SessionRef,
+		"decisions", len(res.DecisionIDs),
+		"patterns", len(res.PatternIDs),
+	)
+}
+
+// ProcessOptions controls what Process does with an extraction.
+type ProcessOptions struct {
+	Persist     bool // write decisions and patterns to the database
+	PostToSlack bool // post a review thread; requires Persist
+}
+
+// ProcessResult is the outcome of processing one transcript.
+type ProcessResult struct {
+	Result        *extractor.ExtractionResult `json:"result"`
+	DecisionIDs   []uuid.UUID                 `json:"decision_ids,omitempty"`
+	PatternIDs    []uuid.UUID                 `json:"pattern_ids,omitempty"`
+	RecurringIDs  []uuid.UUID                 `json:"recurring_ids,omitempty"` // decision and pattern IDs that recurred existing ones; not posted for review
+	SlackHeaderTS string                      `json:"slack_header_ts,omitempty"`
+}
+
+// Process fetches, extracts, and optionally persists and posts a transcript
+// for review. A Slack failure is logged rather than returned, since the
+// extraction has already been stored by then.
+func (p *Processor) Process(ctx context.Context, evt extractor.TranscriptEvent, ownerUUID uuid.UUID, opts ProcessOptions) (*ProcessResult, error) {
+	if opts.PostToSlack && !opts.Persist {
+		return nil, fmt.Errorf("posting to slack requires persisting the extraction")
+	}
+
 	// Fetch transcript content from the event payload or Chronicle.
 	transcript, err := p.fetchTranscript(ctx, evt)
 	if err != nil {
-		p.logger.Error("failed to fetch transcript", "session_id", evt.SessionID, "error", err)
-		return
+		return nil, fmt.Errorf("fetch transcript: %w", err)
 	}
 
 	// Extract decisions and patterns.
 	result, err := p.extractor.Extract(ctx, evt.SessionRef, ownerUUID, transcript)
 	if err != nil {
-		p.logger.Error("extraction failed", "session_ref", evt.SessionRef, "error", err)
-		return
+		return nil, fmt.Errorf("extract: %w", err)
 	}
 
 	// Propagate model tracking fields from the transcript event to each decision.
@@ -108,67 +179,83 @@ func (p *Processor) HandleTranscriptStored(subject string, data []byte) {
 		result.Decisions[i].ModelTier = evt.ModelTier
 	}
 
+	res := &ProcessResult{Result: result}
+	if !opts.Persist {
+		return res, nil
+	}
+
 	// Persist extractions.
-	decisionIDs, patternIDs, err := p.persist(ctx, result)
+	review, err := p.persist(ctx, result, res)
 	if err != nil {
-		p.logger.Error("persistence failed", "session_ref", evt.SessionRef, "error", err)
-		return
+		return nil, fmt.Errorf("persist: %w", err)
 	}
+	p.bus.Publish(bus.KindTranscriptProcessed, ownerUUID, bus.TranscriptProcessed{
+		SessionRef:  evt.SessionRef,
+		DecisionIDs: res.DecisionIDs,
+		PatternIDs:  res.PatternIDs,
+	})
 
-	// Post per-item review thread to Slack.
-	if p.slack != nil {
-		thread, err := p.slack.PostReviewThread(ctx, result, evt.Title, evt.Surface, evt.Duration)
-		if err != nil {
-			p.logger.Error("slack post failed", "error", err)
-		} else {
-			p.mu.Lock()
-			// Store header-level review for rejection thread replies.
-			p.pendingReviews[thread.HeaderTS] = &pendingReview{
-				SessionRef:  evt.SessionRef,
-				OwnerUUID:   ownerUUID,
-				HeaderTS:    thread.HeaderTS,
-				DecisionIDs: decisionIDs,
-				PatternIDs:  patternIDs,
-				Decisions:   result.Decisions,
-				Patterns:    result.Patterns,
+	// Post per-item review thread to Slack, unless everything recurred.
+	allRecurred := len(res.RecurringIDs) > 0 && len(review.result.Decisions)+len(review.result.Patterns) == 0
+	if opts.PostToSlack && p.slack != nil && !allRecurred {
+		res.SlackHeaderTS = p.postReview(ctx, evt, ownerUUID, review)
+	}
+
+	return res, nil
+}
+
+// postReview posts a review thread and tracks its messages for reactions.
+// It returns the header message TS, or "" if posting failed.
+func (p *Processor) postReview(ctx context.Context, evt extractor.TranscriptEvent, ownerUUID uuid.UUID, review reviewBatch) string {
+	result, decisionIDs, patternIDs := review.result, review.decisionIDs, review.patternIDs
+	thread, err := p.slack.PostReviewThread(ctx, result, evt.Title, evt.Surface, evt.Duration)
+	if err != nil {
+		p.logger.Error("slack post failed", "error", err)
+		return ""
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	// Store header-level review for rejection thread replies.
+	p.pendingReviews[thread.HeaderTS] = &pendingReview{
+		SessionRef:  evt.SessionRef,
+		OwnerUUID:   ownerUUID,
+		HeaderTS:    thread.HeaderTS,
+		DecisionIDs: decisionIDs,
+		PatternIDs:  patternIDs,
+		Decisions:   result.Decisions,
+		Patterns:    result.Patterns,
+	}
+	// Store per-item TS mappings for per-item reactions.
+	for _, item := range thread.Items {
+		pi := &pendingItem{
+			SessionRef: evt.SessionRef,
+			OwnerUUID:  ownerUUID,
+			Kind:       item.Kind,
+			Idx:        item.Idx,
+		}
+		switch item.Kind {
+		case "decision":
+			if item.Idx < len(decisionIDs) {
+				pi.StoredID = decisionIDs[item.Idx]
+			}
+			if item.Idx < len(result.Decisions) {
+				dec := result.Decisions[item.Idx]
+				pi.Decision = &dec
 			}
-			// Store per-item TS mappings for per-item reactions.
-			for _, item := range thread.Items {
-				pi := &pendingItem{
-					SessionRef: evt.SessionRef,
-					OwnerUUID:  ownerUUID,
-					Kind:       item.Kind,
-					Idx:        item.Idx,
-				}
-				switch item.Kind {
-				case "decision":
-					if item.Idx < len(decisionIDs) {
-						pi.StoredID = decisionIDs[item.Idx]
-					}
-					if item.Idx < len(result.Decisions) {
-						dec := result.Decisions[item.Idx]
-						pi.Decision = &dec
-					}
-				case "pattern":
-					if item.Idx < len(patternIDs) {
-						pi.StoredID = patternIDs[item.Idx]
-					}
-					if item.Idx < len(result.Patterns) {
-						pat := result.Patterns[item.Idx]
-						pi.Pattern = &pat
-					}
-				}
-				p.pendingItems[item.TS] = pi
+		case "pattern":
+			if item.Idx < len(patternIDs) {
+				pi.StoredID = patternIDs[item.Idx]
+			}
+			if item.Idx < len(result.Patterns) {
+				pat := result.Patterns[item.Idx]
+				pi.Pattern = &pat
 			}
-			p.mu.Unlock()
 		}
+		p.pendingItems[item.TS] = pi
 	}
-
-	p.logger.Info("transcript processed",
-		"session_ref", evt.SessionRef,
-		"decisions", len(decisionIDs),
-		"patterns", len(patternIDs),
-	)
+	return thread.HeaderTS
 }
 
 // HandleReaction processes Slack reaction feedback from slack-forwarder via NATS.
@@ -179,6 +266,7 @@ func (p *Processor) HandleReaction(subject string, data []byte) {
 	evt, err := slack.ParseReactionEvent(data, p.logger)
 	if err != nil {
 		p.logger.Error("failed to parse reaction", "error", err)
+		handlerFailed(subject)
 		return
 	}
 
@@ -196,7 +284,7 @@ func (p *Processor) HandleReaction(subject string, data []byte) {
 	p.mu.Unlock()
 
 	if itemOK {
-		p.handleItemReaction(ctx, item, verdict, evt.MessageTS)
+		p.handleItemReaction(ctx, item, verdict, evt.MessageTS, evt.UserID)
 		return
 	}
 
@@ -216,36 +304,25 @@ func (p *Processor) HandleReaction(subject string, data []byte) {
 		"session_ref", review.SessionRef,
 	)
 
-	status := string(verdict)
-
 	// Update all decisions in this review.
 	for i, id := range review.DecisionIDs {
-		if err := p.store.UpdateDecisionReviewStatus(ctx, id, status, ""); err != nil {
-			p.logger.Error("failed to update decision review", "decision_id", id, "error", err)
+		var dec *extractor.DecisionEpisode
+		if i < len(review.Decisions) {
+			dec = &review.Decisions[i]
 		}
-		if verdict == slack.VerdictConfirmed || verdict == slack.VerdictRejected {
-			p.emitDecisionSignals(ctx, review, i, verdict)
+		if err := p.reviewDecision(ctx, id, review.OwnerUUID, review.SessionRef, dec, verdict, "", evt.UserID, ChannelSlack); err != nil {
+			p.logger.Error("failed to review decision", "decision_id", id, "error", err)
 		}
 	}
 
 	// Update all patterns in this review.
-	for _, id := range review.PatternIDs {
-		if err := p.store.UpdatePatternReviewStatus(ctx, id, status, ""); err != nil {
-			p.logger.Error("failed to update pattern review", "pattern_id", id, "error", err)
+	for i, id := range review.PatternIDs {
+		var pat *extractor.ReasoningPattern
+		if i < len(review.Patterns) {
+			pat = &review.Patterns[i]
 		}
-	}
-
-	if verdict == slack.VerdictConfirmed {
-		for _, pat := range review.Patterns {
-			if err := p.hermes.Publish("swarm.dredd.pattern.confirmed", map[string]any{
-				"pattern_type": pat.PatternType,
-				"summary":      pat.Summary,
-				"tags":         pat.Tags,
-				"owner_uuid":   review.OwnerUUID.String(),
-				"session_ref":  review.SessionRef,
-			}); err != nil {
-				p.logger.Error("failed to publish pattern confirmed", "error", err)
-			}
+		if err := p.reviewPattern(ctx, id, review.OwnerUUID, review.SessionRef, pat, verdict, "", evt.UserID, ChannelSlack); err != nil {
+			p.logger.Error("failed to review pattern", "pattern_id", id, "error", err)
 		}
 	}
 
@@ -257,7 +334,7 @@ func (p *Processor) HandleReaction(subject string, data []byte) {
 }
 
 // handleItemReaction processes a reaction on a single per-item thread reply.
-func (p *Processor) handleItemReaction(ctx context.Context, item *pendingItem, verdict slack.ReviewVerdict, messageTS string) {
+func (p *Processor) handleItemReaction(ctx context.Context, item *pendingItem, verdict slack.ReviewVerdict, messageTS, reviewer string) {
 	p.logger.Info("processing per-item review reaction",
 		"kind", item.Kind,
 		"verdict", string(verdict),
@@ -265,90 +342,10 @@ func (p *Processor) handleItemReaction(ctx context.Context, item *pendingItem, v
 		"session_ref", item.SessionRef,
 	)
 
-	status := string(verdict)
-
 	switch item.Kind {
 	case "decision":
-		if err := p.store.UpdateDecisionReviewStatus(ctx, item.StoredID, status, ""); err != nil {
-			p.logger.Error("failed to update decision review", "decision_id", item.StoredID, "error", err)
-		}
-		if (verdict == slack.VerdictConfirmed || verdict == slack.VerdictRejected) && item.Decision != nil {
-			correct := verdict == slack.VerdictConfirmed
-			dec := item.Decision
-
-			if dec.AgentID != "" {
-				if err := p.hermes.Publish("swarm.dredd.trust.signal", map[string]any{
-					"agent_id":    dec.AgentID,
-					"category":    dec.Category,
-					"outcome":     outcomeStr(correct),
-					"severity":    dec.Severity,
-					"session_ref": item.SessionRef,
-				}); err != nil {
-					p.logger.Error("failed to publish trust signal", "error", err)
-				}
-
-				rec, err := p.store.GetTrust(ctx, dec.AgentID, dec.Category, dec.Severity)
-				if err != nil {
-					score := trust.UpdateScoreWithSentiment(0.0, dec.Severity, correct, "")
-					total, correctCount := 1, 0
-					if correct {
-						correctCount = 1
-					}
-					if err := p.store.UpsertTrust(ctx, dec.AgentID, dec.Category, dec.Severity, score, total, correctCount, 0); err != nil {
-						p.logger.Error("failed to create trust record", "error", err)
-					}
-				} else {
-					newScore := trust.UpdateScoreWithSentiment(rec.TrustScore, dec.Severity, correct, "")
-					total := rec.TotalDecisions + 1
-					correctCount := rec.CorrectDecisions
-					if correct {
-						correctCount++
-					}
-					if err := p.store.UpsertTrust(ctx, dec.AgentID, dec.Category, dec.Severity, newScore, total, correctCount, rec.CriticalFailures); err != nil {
-						p.logger.Error("failed to update trust record", "error", err)
-					}
-				}
-			}
-
-			if dec.SignalType != "" {
-				if err := p.hermes.Publish("swarm.dredd.assignment.signal", map[string]any{
-					"signal_type": dec.SignalType,
-					"agent_id":    dec.AgentID,
-					"category":    dec.Category,
-					"severity":    dec.Severity,
-					"session_ref": item.SessionRef,
-				}); err != nil {
-					p.logger.Error("failed to publish assignment signal", "error", err)
-				}
-			}
-
-			if !correct {
-				if err := p.hermes.Publish("swarm.dredd.extraction.rejected", map[string]any{
-					"session_ref": item.SessionRef,
-					"decision":    dec.Summary,
-					"category":    dec.Category,
-				}); err != nil {
-					p.logger.Error("failed to publish extraction rejected", "error", err)
-				}
-			}
-
-			// Correction signal for prompt optimisation loop.
-			correctionType := "confirmed"
-			if !correct {
-				correctionType = "rejected"
-			}
-			if p.hermes != nil {
-				_ = p.hermes.Publish(hermes.SubjectCorrection, map[string]any{
-					"session_ref":     item.SessionRef,
-					"decision_id":     item.StoredID.String(),
-					"agent_id":        dec.AgentID,
-					"model_id":        dec.ModelID,
-					"model_tier":      dec.ModelTier,
-					"correction_type": correctionType,
-					"category":        dec.Category,
-					"severity":        dec.Severity,
-				})
-			}
+		if err := p.reviewDecision(ctx, item.StoredID, item.OwnerUUID, item.SessionRef, item.Decision, verdict, "", reviewer, ChannelSlack); err != nil {
+			p.logger.Error("failed to review decision", "decision_id", item.StoredID, "error", err)
 		}
 
 		if verdict == slack.VerdictRejected && p.slack != nil {
@@ -358,20 +355,8 @@ func (p *Processor) handleItemReaction(ctx context.Context, item *pendingItem, v
 		}
 
 	case "pattern":
-		if err := p.store.UpdatePatternReviewStatus(ctx, item.StoredID, status, ""); err != nil {
-			p.logger.Error("failed to update pattern review", "pattern_id", item.StoredID, "error", err)
-		}
-
-		if verdict == slack.VerdictConfirmed && item.Pattern != nil {
-			if err := p.hermes.Publish("swarm.dredd.pattern.confirmed", map[string]any{
-				"pattern_type": item.Pattern.PatternType,
-				"summary":      item.Pattern.Summary,
-				"tags":         item.Pattern.Tags,
-				"owner_uuid":   item.OwnerUUID.String(),
-				"session_ref":  item.SessionRef,
-			}); err != nil {
-				p.logger.Error("failed to publish pattern confirmed", "error", err)
-			}
+		if err := p.reviewPattern(ctx, item.StoredID, item.OwnerUUID, item.SessionRef, item.Pattern, verdict, "", reviewer, ChannelSlack); err != nil {
+			p.logger.Error("failed to review pattern", "pattern_id", item.StoredID, "error", err)
 		}
 
 		if verdict == slack.VerdictRejected && p.slack != nil {
@@ -382,59 +367,36 @@ func (p *Processor) handleItemReaction(ctx context.Context, item *pendingItem, v
 	}
 }
 
-func (p *Processor) emitDecisionSignals(ctx context.Context, review *pendingReview, idx int, verdict slack.ReviewVerdict) {
-	if idx >= len(review.Decisions) {
+// publishDecisionSignals emits the trust, assignment, rejection, and
+// correction events for a reviewed decision.
+func (p *Processor) publishDecisionSignals(sessionRef string, decisionID uuid.UUID, dec extractor.DecisionEpisode, correct bool) {
+	if p.hermes == nil {
 		return
 	}
-	dec := review.Decisions[idx]
-	correct := verdict == slack.VerdictConfirmed
 
 	// Trust signal.
 	if dec.AgentID != "" {
-		if err := p.hermes.Publish("swarm.dredd.trust.signal", map[string]any{
-			"agent_id":    dec.AgentID,
-			"category":    dec.Category,
-			"outcome":     outcomeStr(correct),
-			"severity":    dec.Severity,
-			"session_ref": review.SessionRef,
+		if err := p.hermes.Publish(events.SubjectTrustSignal, events.TrustSignal{
+			SchemaVersion: events.SchemaVersion,
+			AgentID:       dec.AgentID,
+			Category:      dec.Category,
+			Outcome:       outcomeStr(correct),
+			Severity:      dec.Severity,
+			SessionRef:    sessionRef,
 		}); err != nil {
 			p.logger.Error("failed to publish trust signal", "error", err)
 		}
-
-		// Update trust score in DB.
-		rec, err := p.store.GetTrust(ctx, dec.AgentID, dec.Category, dec.Severity)
-		if err != nil {
-			// First signal for this combination — create new record.
-			// Sentiment defaults to empty (modifier=1.0) until sentiment detection is wired in.
-			score := trust.UpdateScoreWithSentiment(0.0, dec.Severity, correct, "")
-			total, correctCount := 1, 0
-			if correct {
-				correctCount = 1
-			}
-			if err := p.store.UpsertTrust(ctx, dec.AgentID, dec.Category, dec.Severity, score, total, correctCount, 0); err != nil {
-				p.logger.Error("failed to create trust record", "error", err)
-			}
-		} else {
-			newScore := trust.UpdateScoreWithSentiment(rec.TrustScore, dec.Severity, correct, "")
-			total := rec.TotalDecisions + 1
-			correctCount := rec.CorrectDecisions
-			if correct {
-				correctCount++
-			}
-			if err := p.store.UpsertTrust(ctx, dec.AgentID, dec.Category, dec.Severity, newScore, total, correctCount, rec.CriticalFailures); err != nil {
-				p.logger.Error("failed to update trust record", "error", err)
-			}
-		}
 	}
 
 	// Assignment signal (reassignment, budget correction, etc.).
 	if dec.SignalType != "" {
-		if err := p.hermes.Publish("swarm.dredd.assignment.signal", map[string]any{
-			"signal_type": dec.SignalType,
-			"agent_id":    dec.AgentID,
-			"category":    dec.Category,
-			"severity":    dec.Severity,
-			"session_ref": review.SessionRef,
+		if err := p.hermes.Publish(events.SubjectAssignmentSignal, events.AssignmentSignal{
+			SchemaVersion: events.SchemaVersion,
+			SignalType:    dec.SignalType,
+			AgentID:       dec.AgentID,
+			Category:      dec.Category,
+			Severity:      dec.Severity,
+			SessionRef:    sessionRef,
 		}); err != nil {
 			p.logger.Error("failed to publish assignment signal", "error", err)
 		}
@@ -442,54 +404,156 @@ func (p *Processor) emitDecisionSignals(ctx context.Context, review *pendingRevi
 
 	// Self-training on rejections.
 	if !correct {
-		if err := p.hermes.Publish("swarm.dredd.extraction.rejected", map[string]any{
-			"session_ref": review.SessionRef,
-			"decision":    dec.Summary,
-			"category":    dec.Category,
+		if err := p.hermes.Publish(events.SubjectExtractionRejected, events.ExtractionRejected{
+			SchemaVersion: events.SchemaVersion,
+			SessionRef:    sessionRef,
+			Decision:      dec.Summary,
+			Category:      dec.Category,
 		}); err != nil {
 			p.logger.Error("failed to publish extraction rejected", "error", err)
 		}
 	}
 
 	// Correction signal for prompt optimisation loop.
-	correctionType := "confirmed"
+	correctionType := events.CorrectionConfirmed
 	if !correct {
-		correctionType = "rejected"
-	}
-	if p.hermes != nil {
-		_ = p.hermes.Publish(hermes.SubjectCorrection, map[string]any{
-			"session_ref":     review.SessionRef,
-			"decision_id":     review.DecisionIDs[idx].String(),
-			"agent_id":        dec.AgentID,
-			"model_id":        dec.ModelID,
-			"model_tier":      dec.ModelTier,
-			"correction_type": correctionType,
-			"category":        dec.Category,
-			"severity":        dec.Severity,
-		})
+		correctionType = events.CorrectionRejected
+	}
+	if err := p.hermes.Publish(events.SubjectCorrection, events.CorrectionSignal{
+		SchemaVersion:  events.SchemaVersion,
+		SessionRef:     sessionRef,
+		DecisionID:     decisionID.String(),
+		AgentID:        dec.AgentID,
+		ModelID:        dec.ModelID,
+		ModelTier:      dec.ModelTier,
+		CorrectionType: correctionType,
+		Category:       dec.Category,
+		Severity:       dec.Severity,
+	}); err != nil {
+		p.logger.Error("failed to publish correction signal", "error", err)
 	}
 }
 
-func (p *Processor) persist(ctx context.Context, result *extractor.ExtractionResult) ([]uuid.UUID, []uuid.UUID, error) {
-	var decisionIDs []uuid.UUID
+// publishPatternConfirmed emits a pattern confirmation event.
+func (p *Processor) publishPatternConfirmed(ownerUUID uuid.UUID, sessionRef string, pat extractor.ReasoningPattern) {
+	if p.hermes == nil {
+		return
+	}
+	if err := p.hermes.Publish(events.SubjectPatternConfirmed, events.PatternConfirmed{
+		SchemaVersion: events.SchemaVersion,
+		PatternType:   pat.PatternType,
+		Summary:       pat.Summary,
+		Tags:          pat.Tags,
+		OwnerUUID:     ownerUUID.String(),
+		SessionRef:    sessionRef,
+	}); err != nil {
+		p.logger.Error("failed to publish pattern confirmed", "error", err)
+	}
+}
+
+// persist writes the extraction's decisions and patterns, filling in res's
+// IDs, and returns the part of the extraction to post for review: everything
+// but the items recorded as occurrences of existing ones. An item that recurs
+// one written earlier in the same extraction resolves to the same ID, which
+// res lists once.
+func (p *Processor) persist(ctx context.Context, result *extractor.ExtractionResult, res *ProcessResult) (reviewBatch, error) {
+	review := *result
+	review.Decisions, review.Patterns = nil, nil
+	batch := reviewBatch{result: &review}
+	seen := make(map[uuid.UUID]bool)
+
 	for _, d := range result.Decisions {
-		id, err := p.store.WriteDecisionEpisode(ctx, result.OwnerUUID, result.SessionRef, "dredd", d)
+		var matched bool
+		opts := p.writeOpts(ctx, d.SituationText, d.Summary, &matched)
+		id, err := p.store.WriteDecisionEpisode(ctx, result.OwnerUUID, result.SessionRef, "dredd", d, opts)
 		if err != nil {
-			return nil, nil, fmt.Errorf("write decision: %w", err)
+			return reviewBatch{}, fmt.Errorf("write decision: %w", err)
+		}
+		p.decisionStored(id, result.OwnerUUID, result.SessionRef, "dredd", d, matched)
+		if !seen[id] {
+			res.DecisionIDs = append(res.DecisionIDs, id)
+			if matched {
+				res.RecurringIDs = append(res.RecurringIDs, id)
+			}
+		}
+		seen[id] = true
+		if !matched {
+			review.Decisions = append(review.Decisions, d)
+			batch.decisionIDs = append(batch.decisionIDs, id)
 		}
-		decisionIDs = append(decisionIDs, id)
 	}
 
-	var patternIDs []uuid.UUID
 	for _, pat := range result.Patterns {
-		id, err := p.store.WriteReasoningPattern(ctx, result.OwnerUUID, result.SessionRef, pat)
+		var matched bool
+		opts := p.writeOpts(ctx, pat.ConversationArc, pat.Summary, &matched)
+		id, err := p.store.WriteReasoningPattern(ctx, result.OwnerUUID, result.SessionRef, pat, opts)
 		if err != nil {
-			return nil, nil, fmt.Errorf("write pattern: %w", err)
+			return reviewBatch{}, fmt.Errorf("write pattern: %w", err)
+		}
+		p.bus.Publish(bus.KindPatternStored, result.OwnerUUID, bus.PatternStored{
+			ID:          id,
+			SessionRef:  result.SessionRef,
+			PatternType: pat.PatternType,
+			Summary:     pat.Summary,
+			Recurrence:  matched,
+		})
+		if !seen[id] {
+			res.PatternIDs = append(res.PatternIDs, id)
+			if matched {
+				res.RecurringIDs = append(res.RecurringIDs, id)
+			}
+		}
+		seen[id] = true
+		if !matched {
+			review.Patterns = append(review.Patterns, pat)
+			batch.patternIDs = append(batch.patternIDs, id)
 		}
-		patternIDs = append(patternIDs, id)
 	}
 
-	return decisionIDs, patternIDs, nil
+	return batch, nil
+}
+
+// writeOpts embeds an extraction for writing, from text or fallback when text
+// is empty. Without an embedder, or if embedding fails, the extraction is
+// written without an embedding and never matched.
+func (p *Processor) writeOpts(ctx context.Context, text, fallback string, matched *bool) store.WriteOpts {
+	opts := store.WriteOpts{Matched: matched}
+	if p.embedder == nil {
+		return opts
+	}
+	if text == "" {
+		text = fallback
+	}
+	vec, err := p.embedder.Embed(ctx, text)
+	if err != nil {
+		p.logger.Warn("failed to embed extraction", "error", err)
+		return opts
+	}
+	opts.Embedding = vec
+	opts.MatchThreshold = p.matchAbove
+	return opts
+}
+
+// reviewBatch is the part of an extraction posted for review, with the stored
+// IDs aligned to its decisions and patterns.
+type reviewBatch struct {
+	result      *extractor.ExtractionResult
+	decisionIDs []uuid.UUID
+	patternIDs  []uuid.UUID
+}
+
+// decisionStored announces a newly written decision on the event bus.
+func (p *Processor) decisionStored(id, ownerUUID uuid.UUID, sessionRef, source string, d extractor.DecisionEpisode, recurrence bool) {
+	p.bus.Publish(bus.KindDecisionStored, ownerUUID, bus.DecisionStored{
+		ID:         id,
+		SessionRef: sessionRef,
+		Source:     source,
+		Domain:     d.Domain,
+		Category:   d.Category,
+		Severity:   d.Severity,
+		Summary:    d.Summary,
+		Recurrence: recurrence,
+	})
 }
 
 func (p *Processor) fetchTranscript(ctx context.Context, evt extractor.TranscriptEvent) (string, error) {
@@ -502,47 +566,61 @@ func (p *Processor) fetchTranscript(ctx context.Context, evt extractor.Transcrip
 	if p.chronicleURL == "" {
 		return "", fmt.Errorf("no transcript in event payload and CHRONICLE_URL not configured for session %s", evt.SessionID)
 	}
+	return chronicle.New(p.chronicleURL).Transcript(ctx, evt.SessionID)
+}
 
-	url := fmt.Sprintf("%s/api/v1/events?trace_id=%s", p.chronicleURL, evt.SessionID)
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
-	if err != nil {
-		return "", fmt.Errorf("build chronicle request: %w", err)
-	}
-
-	resp, err := http.DefaultClient.Do(req)
-	if err != nil {
-		return "", fmt.Errorf("chronicle request failed: %w", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("chronicle returned %d for session %s", resp.StatusCode, evt.SessionID)
-	}
-
-	body, err := io.ReadAll(resp.Body)
+// updateTrust applies a correct/incorrect signal to an agent's trust record
+// for a category/severity, creating the record on the first signal.
+func (p *Processor) updateTrust(ctx context.Context, agentID, category, severity string, correct bool) {
+	rec, err := p.store.GetTrust(ctx, agentID, category, severity)
 	if err != nil {
-		return "", fmt.Errorf("read chronicle response: %w", err)
+		score := trust.UpdateScoreWithSentiment(0.0, severity, correct, "")
+		total, correctCount := 1, 0
+		if correct {
+			correctCount = 1
+		}
+		if err := p.store.UpsertTrust(ctx, agentID, category, severity, score, total, correctCount, 0); err != nil {
+			p.logger.Error("failed to create trust record", "error", err)
+			return
+		}
+		p.trustChanged(agentID, category, severity, correct, 0, score)
+		return
 	}
 
-	// Parse events and reconstruct transcript text.
-	var events []struct {
-		Metadata json.RawMessage `json:"metadata"`
+	newScore := trust.UpdateScoreWithSentiment(rec.TrustScore, severity, correct, "")
+	total := rec.TotalDecisions + 1
+	correctCount := rec.CorrectDecisions
+	if correct {
+		correctCount++
 	}
-	if err := json.Unmarshal(body, &events); err != nil {
-		return "", fmt.Errorf("parse chronicle events: %w", err)
+	if err := p.store.UpsertTrust(ctx, agentID, category, severity, newScore, total, correctCount, rec.CriticalFailures); err != nil {
+		p.logger.Error("failed to update trust record", "error", err)
+		return
 	}
+	p.trustChanged(agentID, category, severity, correct, rec.TrustScore, newScore)
+}
 
-	if len(events) == 0 {
-		return "", fmt.Errorf("no events found in chronicle for session %s", evt.SessionID)
-	}
+// trustChanged records a written trust update in the metrics and on the bus.
+func (p *Processor) trustChanged(agentID, category, severity string, correct bool, previous, score float64) {
+	metrics.TrustUpdates.WithLabelValues(outcomeStr(correct)).Inc()
+	p.bus.Publish(bus.KindTrustChanged, uuid.Nil, bus.TrustChanged{
+		AgentID:       agentID,
+		Category:      category,
+		Severity:      severity,
+		Correct:       correct,
+		PreviousScore: previous,
+		Score:         score,
+	})
+}
 
-	// Return the raw events JSON as transcript input for the extractor.
-	return string(body), nil
+// handlerFailed counts a NATS message its handler could not process.
+func handlerFailed(subject string) {
+	metrics.NATSHandlerErrors.WithLabelValues(subject).Inc()
 }
 
 func outcomeStr(correct bool) string {
 	if correct {
-		return "correct"
+		return events.OutcomeCorrect
 	}
-	return "incorrect"
+	return events.OutcomeIncorrect
 }
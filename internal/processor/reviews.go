@@ -0,0 +1,130 @@
+package processor
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/MikeSquared-Agency/dredd/internal/bus"
+	"github.com/MikeSquared-Agency/dredd/internal/extractor"
+	"github.com/MikeSquared-Agency/dredd/internal/metrics"
+	"github.com/MikeSquared-Agency/dredd/internal/slack"
+	"github.com/MikeSquared-Agency/dredd/internal/store"
+)
+
+// Review channels recorded in the review log.
+const (
+	ChannelSlack = "slack"
+	ChannelAPI   = "api"
+)
+
+// Review applies a verdict to a stored decision or pattern by ID, loading the
+// extraction from the database. It runs the same status, trust, and event
+// updates as a Slack reaction. Unknown IDs return an error satisfying
+// store.IsNotFound.
+func (p *Processor) Review(ctx context.Context, kind string, id uuid.UUID, verdict, note, reviewer string) error {
+	v := slack.ReviewVerdict(verdict)
+	if v != slack.VerdictConfirmed && v != slack.VerdictRejected && v != slack.VerdictSkipped {
+		return fmt.Errorf("invalid verdict %q", verdict)
+	}
+
+	switch kind {
+	case "decision":
+		d, err := p.store.GetDecisionDetail(ctx, id)
+		if err != nil {
+			return err
+		}
+		dec := extractor.DecisionEpisode{
+			Domain:     d.Domain,
+			Category:   d.Category,
+			Severity:   d.Severity,
+			Summary:    d.Summary,
+			AgentID:    d.AgentID,
+			SignalType: d.SignalType,
+			ModelID:    d.ModelID,
+			ModelTier:  d.ModelTier,
+		}
+		return p.reviewDecision(ctx, id, d.DecidedBy, d.SessionRef, &dec, v, note, reviewer, ChannelAPI)
+
+	case "pattern":
+		row, err := p.store.GetPatternByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		pat := extractor.ReasoningPattern{
+			PatternType:     row.PatternType,
+			Summary:         row.Summary,
+			ConversationArc: row.ConversationArc,
+			Tags:            row.Tags,
+			Confidence:      row.Confidence,
+		}
+		return p.reviewPattern(ctx, id, row.OwnerUUID, row.SessionRef, &pat, v, note, reviewer, ChannelAPI)
+
+	default:
+		return fmt.Errorf("invalid kind %q", kind)
+	}
+}
+
+// reviewDecision records a verdict on a decision and, for confirmations and
+// rejections, updates the agent's trust and emits the decision signals.
+// dec may be nil when the extraction is unavailable; only the status is updated.
+// Trust and signals follow only once the verdict is stored.
+func (p *Processor) reviewDecision(ctx context.Context, id, ownerUUID uuid.UUID, sessionRef string, dec *extractor.DecisionEpisode, verdict slack.ReviewVerdict, note, reviewer, channel string) error {
+	if err := p.store.UpdateDecisionReviewStatus(ctx, id, string(verdict), note); err != nil {
+		return fmt.Errorf("update decision review: %w", err)
+	}
+	if err := p.logReview(ctx, "decision", id, ownerUUID, verdict, note, reviewer, channel); err != nil {
+		return err
+	}
+
+	if dec == nil || (verdict != slack.VerdictConfirmed && verdict != slack.VerdictRejected) {
+		return nil
+	}
+	correct := verdict == slack.VerdictConfirmed
+
+	if dec.AgentID != "" {
+		p.updateTrust(ctx, dec.AgentID, dec.Category, dec.Severity, correct)
+	}
+	p.publishDecisionSignals(sessionRef, id, *dec, correct)
+	return nil
+}
+
+// reviewPattern records a verdict on a pattern and announces confirmations
+// once the verdict is stored.
+func (p *Processor) reviewPattern(ctx context.Context, id, ownerUUID uuid.UUID, sessionRef string, pat *extractor.ReasoningPattern, verdict slack.ReviewVerdict, note, reviewer, channel string) error {
+	if err := p.store.UpdatePatternReviewStatus(ctx, id, string(verdict), note); err != nil {
+		return fmt.Errorf("update pattern review: %w", err)
+	}
+	if err := p.logReview(ctx, "pattern", id, ownerUUID, verdict, note, reviewer, channel); err != nil {
+		return err
+	}
+
+	if verdict == slack.VerdictConfirmed && pat != nil {
+		p.publishPatternConfirmed(ownerUUID, sessionRef, *pat)
+	}
+	return nil
+}
+
+// logReview appends the verdict to the review log, then counts and announces
+// it.
+func (p *Processor) logReview(ctx context.Context, kind string, id, ownerUUID uuid.UUID, verdict slack.ReviewVerdict, note, reviewer, channel string) error {
+	if err := p.store.RecordReview(ctx, store.ReviewEntry{
+		TargetKind: kind,
+		TargetID:   id,
+		Verdict:    string(verdict),
+		Note:       note,
+		Reviewer:   reviewer,
+		Channel:    channel,
+	}); err != nil {
+		return fmt.Errorf("record review: %w", err)
+	}
+	metrics.ReviewVerdicts.WithLabelValues(kind, string(verdict), channel).Inc()
+	p.bus.Publish(bus.KindReviewApplied, ownerUUID, bus.ReviewApplied{
+		Kind:     kind,
+		ID:       id,
+		Verdict:  string(verdict),
+		Reviewer: reviewer,
+		Channel:  channel,
+	})
+	return nil
+}
@@ -0,0 +1,205 @@
+package refinement
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"math"
+	"sort"
+)
+
+// DefaultMinClusterSize is the fewest patterns a cluster needs to be
+// reported; a single pattern is an anecdote, not a trend.
+const DefaultMinClusterSize = 2
+
+// agglomerate groups vectors by average-linkage agglomerative clustering:
+// the two clusters with the highest mean pairwise cosine similarity merge
+// until no pair reaches threshold. It returns groups of indexes into vectors.
+//
+// It uses the nearest-neighbour chain algorithm, which gives the same
+// clusters as repeatedly merging the globally most similar pair in O(n²)
+// time and memory. Ties go to the lowest index, so the result depends only
+// on the order of vectors, which callers fix. Callers also bound n; see
+// MaxScanPatterns.
+func agglomerate(vectors [][]float32, threshold float64) [][]int {
+	n := len(vectors)
+	sim := make([][]float64, n)
+	for i := range sim {
+		sim[i] = make([]float64, n)
+		for j := 0; j < i; j++ {
+			sim[i][j] = cosineSimilarity(vectors[i], vectors[j])
+			sim[j][i] = sim[i][j]
+		}
+	}
+
+	members := make([][]int, n)
+	active := make([]bool, n)
+	for i := range members {
+		members[i] = []int{i}
+		active[i] = true
+	}
+	remaining := n
+
+	var groups [][]int
+	finish := func(i int) {
+		groups = append(groups, members[i])
+		active[i] = false
+		remaining--
+	}
+
+	var chain []int
+	for remaining > 0 {
+		if len(chain) == 0 {
+			for i := range active {
+				if active[i] {
+					chain = append(chain, i)
+					break
+				}
+			}
+		}
+		a := chain[len(chain)-1]
+
+		// Nearest active neighbour of a, preferring the previous chain link on
+		// a tie so reciprocal pairs are found.
+		b, best := -1, math.Inf(-1)
+		for c := range active {
+			if active[c] && c != a && sim[a][c] > best {
+				b, best = c, sim[a][c]
+			}
+		}
+		if b == -1 {
+			finish(a)
+			chain = chain[:0]
+			continue
+		}
+		if len(chain) >= 2 && sim[a][chain[len(chain)-2]] == best {
+			b = chain[len(chain)-2]
+		}
+		if len(chain) < 2 || b != chain[len(chain)-2] {
+			chain = append(chain, b)
+			continue
+		}
+
+		// a and b are each other's nearest neighbours.
+		chain = chain[:len(chain)-2]
+		if best < threshold {
+			// Average linkage never raises similarity on merging, so neither
+			// can reach threshold with anything else.
+			finish(a)
+			finish(b)
+			continue
+		}
+		na, nb := float64(len(members[a])), float64(len(members[b]))
+		for c := range active {
+			if active[c] && c != a && c != b {
+				sim[a][c] = (na*sim[a][c] + nb*sim[b][c]) / (na + nb)
+				sim[c][a] = sim[a][c]
+			}
+		}
+		members[a] = append(members[a], members[b]...)
+		active[b] = false
+		remaining--
+	}
+	return groups
+}
+
+// centroid returns the mean of the unit-normalised vectors at idx.
+func centroid(vectors [][]float32, idx []int) []float32 {
+	var sum []float64
+	for _, i := range idx {
+		v := vectors[i]
+		norm := 0.0
+		for _, x := range v {
+			norm += float64(x) * float64(x)
+		}
+		if norm == 0 {
+			continue
+		}
+		norm = math.Sqrt(norm)
+		if sum == nil {
+			sum = make([]float64, len(v))
+		}
+		if len(v) != len(sum) {
+			continue
+		}
+		for k, x := range v {
+			sum[k] += float64(x) / norm
+		}
+	}
+	if sum == nil {
+		return nil
+	}
+	c := make([]float32, len(sum))
+	for k := range sum {
+		c[k] = float32(sum[k] / float64(len(idx)))
+	}
+	return c
+}
+
+// cohesion is the mean pairwise cosine similarity of the vectors at idx, 1
+// for a single vector.
+func cohesion(vectors [][]float32, idx []int) float64 {
+	if len(idx) < 2 {
+		return 1
+	}
+	total, pairs := 0.0, 0
+	for x := 0; x < len(idx); x++ {
+		for y := x + 1; y < len(idx); y++ {
+			total += cosineSimilarity(vectors[idx[x]], vectors[idx[y]])
+			pairs++
+		}
+	}
+	return total / float64(pairs)
+}
+
+// clusterID derives a cluster's ID from its pattern type and its oldest
+// pattern. New patterns joining a cluster in later scans do not change it, so
+// the same cluster keeps its ID from scan to scan. The oldest pattern is the
+// oldest in the scan's window, though, so scans with different since values
+// can give one cluster different IDs; Matches falls back to member overlap
+// for that reason.
+func clusterID(patternType string, oldest patternRecord) string {
+	sum := sha256.Sum256([]byte(patternType + ":" + oldest.ID))
+	return hex.EncodeToString(sum[:8])
+}
+
+// buildCluster summarises the patterns at idx: members ordered by similarity
+// to the centroid, the closest as representative.
+func buildCluster(patternType string, patterns []patternRecord, vectors [][]float32, idx []int) PatternCluster {
+	c := centroid(vectors, idx)
+	closeness := make(map[int]float64, len(idx))
+	for _, i := range idx {
+		closeness[i] = cosineSimilarity(vectors[i], c)
+	}
+	ordered := append([]int(nil), idx...)
+	sort.SliceStable(ordered, func(x, y int) bool {
+		return closeness[ordered[x]] > closeness[ordered[y]]
+	})
+
+	oldest := patterns[idx[0]]
+	members := make([]ClusterPattern, len(ordered))
+	for k, i := range ordered {
+		p := patterns[i]
+		if p.CreatedAt.Before(oldest.CreatedAt) || (p.CreatedAt.Equal(oldest.CreatedAt) && p.ID < oldest.ID) {
+			oldest = p
+		}
+		members[k] = ClusterPattern{
+			ID:              p.ID,
+			Summary:         p.Summary,
+			ConversationArc: p.ConversationArc,
+			Tags:            p.Tags,
+			Confidence:      p.Confidence,
+			CreatedAt:       p.CreatedAt,
+		}
+	}
+
+	rep := patterns[ordered[0]]
+	return PatternCluster{
+		ID:               clusterID(patternType, oldest),
+		PatternType:      patternType,
+		Count:            len(idx),
+		Summary:          rep.Summary,
+		RepresentativeID: rep.ID,
+		Cohesion:         cohesion(vectors, idx),
+		Patterns:         members,
+	}
+}
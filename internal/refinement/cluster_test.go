@@ -0,0 +1,107 @@
+package refinement
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+	"time"
+)
+
+func record(id string, created time.Time, embedding ...float32) patternRecord {
+	return patternRecord{ID: id, PatternType: "correction", Summary: "summary " + id, CreatedAt: created, Embedding: embedding}
+}
+
+func TestAgglomerate_OrderIndependent(t *testing.T) {
+	// b sits between a and c, nearer a. A greedy pass seeded with b takes
+	// both; one seeded with a leaves c out. Average linkage pairs a and b
+	// whatever the order.
+	at := func(angle float64) []float32 {
+		return []float32{float32(math.Cos(angle)), float32(math.Sin(angle))}
+	}
+	vectors := map[string][]float32{
+		"a": at(0),
+		"b": at(0.25),
+		"c": at(0.6),
+		"d": at(math.Pi / 2),
+	}
+	threshold := math.Cos(0.45)
+
+	groupsFor := func(order []string) map[string]int {
+		var vs [][]float32
+		for _, id := range order {
+			vs = append(vs, vectors[id])
+		}
+		sizes := map[string]int{}
+		for _, g := range agglomerate(vs, threshold) {
+			for _, i := range g {
+				sizes[order[i]] = len(g)
+			}
+		}
+		return sizes
+	}
+
+	first := groupsFor([]string{"a", "b", "c", "d"})
+	second := groupsFor([]string{"b", "d", "c", "a"})
+	for id := range vectors {
+		if first[id] != second[id] {
+			t.Errorf("%s: cluster size %d in one order, %d in the other", id, first[id], second[id])
+		}
+	}
+	if first["a"] != 2 || first["b"] != 2 || first["c"] != 1 || first["d"] != 1 {
+		t.Errorf("expected {a, b}, {c}, {d}, got sizes %v", first)
+	}
+}
+
+func TestClusterPatterns(t *testing.T) {
+	t0 := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	patterns := []patternRecord{
+		record("p3", t0.Add(3*time.Hour), 1, 0.2),
+		record("p1", t0.Add(1*time.Hour), 1, 0.1),
+		record("p2", t0.Add(2*time.Hour), 1, 0),
+		record("p4", t0, 0, 1), // far from the rest
+	}
+	d := &Detector{}
+
+	clusters, err := d.clusterPatterns(patterns, 0.9, 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(clusters) != 1 {
+		t.Fatalf("expected 1 cluster above the minimum size, got %d", len(clusters))
+	}
+	c := clusters[0]
+	if c.Count != 3 || len(c.Patterns) != 3 {
+		t.Fatalf("expected 3 patterns, got %+v", c)
+	}
+	if c.RepresentativeID != "p1" || c.Summary != "summary p1" || c.Patterns[0].ID != "p1" {
+		t.Errorf("expected the middle pattern as representative, got %s", c.RepresentativeID)
+	}
+	if c.Cohesion <= 0.9 || c.Cohesion > 1 {
+		t.Errorf("unexpected cohesion %v", c.Cohesion)
+	}
+
+	// A new pattern joining the cluster keeps its ID.
+	joined := append([]patternRecord{record("p5", t0.Add(5*time.Hour), 1, 0.15)}, patterns...)
+	again, _ := d.clusterPatterns(joined, 0.9, 2)
+	if len(again) != 1 || again[0].Count != 4 || again[0].ID != c.ID {
+		t.Errorf("expected the grown cluster to keep ID %s, got %+v", c.ID, again)
+	}
+
+	if got, _ := d.clusterPatterns(patterns, 0.9, 1); len(got) != 2 {
+		t.Errorf("expected the outlier as its own cluster with min size 1, got %d clusters", len(got))
+	}
+}
+
+func TestClusterPatterns_TooMany(t *testing.T) {
+	t0 := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	patterns := make([]patternRecord, MaxScanPatterns+1)
+	for i := range patterns {
+		patterns[i] = record(fmt.Sprintf("p%d", i), t0, 1, 0)
+	}
+
+	_, err := (&Detector{}).clusterPatterns(patterns, 0.9, 2)
+	if !errors.Is(err, ErrTooManyPatterns) {
+		t.Errorf("expected ErrTooManyPatterns, got %v", err)
+	}
+}
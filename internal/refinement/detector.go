@@ -2,40 +2,59 @@ package refinement
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math"
-	"strconv"
-	"strings"
+	"sort"
 	"time"
 
 	"github.com/MikeSquared-Agency/dredd/internal/store"
 )
 
+// MaxScanPatterns is the most patterns of one type a scan clusters.
+// Clustering holds every pairwise similarity, so memory grows with the square
+// of this; a larger scan is rejected and should be narrowed with since.
+const MaxScanPatterns = 2000
+
+// ErrTooManyPatterns is returned by FindClusters when a pattern type has more
+// than MaxScanPatterns patterns to cluster.
+var ErrTooManyPatterns = errors.New("too many patterns to cluster")
+
 // PatternCluster represents a group of similar patterns
 type PatternCluster struct {
-	PatternType string            `json:"pattern_type"`
-	Count       int               `json:"count"`
-	Summary     string            `json:"summary"`
-	SOULSection string            `json:"soul_section"`
-	Patterns    []ClusterPattern  `json:"patterns"`
+	ID               string           `json:"id"` // stable across scans over the same window while the oldest pattern stays in the cluster
+	PatternType      string           `json:"pattern_type"`
+	Count            int              `json:"count"`
+	Summary          string           `json:"summary"`           // the representative's summary
+	RepresentativeID string           `json:"representative_id"` // pattern closest to the centroid
+	Cohesion         float64          `json:"cohesion"`          // mean pairwise cosine similarity
+	SOULSlug         string           `json:"soul_slug,omitempty"`
+	SOULSection      string           `json:"soul_section"`
+	Patterns         []ClusterPattern `json:"patterns"`              // closest to the centroid first
+	ProposalID       string           `json:"proposal_id,omitempty"` // set once the cluster is proposed
+	Draft            *Draft           `json:"draft,omitempty"`       // LLM-drafted section edit, when drafted
 }
 
 // ClusterPattern represents a single pattern within a cluster
 type ClusterPattern struct {
-	ID          string    `json:"id"`
-	Summary     string    `json:"summary"`
-	Confidence  float64   `json:"confidence"`
-	CreatedAt   time.Time `json:"created_at"`
+	ID              string    `json:"id"`
+	Summary         string    `json:"summary"`
+	ConversationArc string    `json:"conversation_arc"`
+	Tags            []string  `json:"tags,omitempty"`
+	Confidence      float64   `json:"confidence"`
+	CreatedAt       time.Time `json:"created_at"`
 }
 
 // patternRecord is an internal type for processing database records
 type patternRecord struct {
-	ID          string
-	PatternType string
-	Summary     string
-	Confidence  float64
-	CreatedAt   time.Time
-	Embedding   []float64
+	ID              string
+	PatternType     string
+	Summary         string
+	ConversationArc string
+	Tags            []string
+	Confidence      float64
+	CreatedAt       time.Time
+	Embedding       []float32
 }
 
 // Detector finds and clusters confirmed reasoning patterns for SOUL refinement
@@ -48,195 +67,95 @@ func NewDetector(store *store.Store) *Detector {
 	return &Detector{store: store}
 }
 
-// FindClusters finds confirmed patterns and groups them by embedding similarity
-func (d *Detector) FindClusters(ctx context.Context, since *time.Time, threshold float64) ([]PatternCluster, error) {
+// FindClusters finds confirmed patterns and groups them by embedding
+// similarity, reporting clusters of at least minSize patterns
+func (d *Detector) FindClusters(ctx context.Context, since *time.Time, threshold float64, minSize int) ([]PatternCluster, error) {
 	if threshold <= 0 || threshold > 1 {
 		threshold = 0.85 // Default cosine similarity threshold
 	}
-
-	// Build query with optional time filter
-	query := `
-		SELECT 
-			id::text,
-			pattern_type,
-			summary,
-			dredd_confidence,
-			created_at,
-			arc_embedding
-		FROM reasoning_patterns 
-		WHERE pattern_type IN ('correction', 'pushback', 'reframing')
-		AND review_status = 'confirmed'
-		AND dredd_confidence > 0.8`
-	
-	args := []interface{}{}
-	if since != nil {
-		query += " AND created_at >= $1"
-		args = append(args, *since)
-	}
-	
-	query += " ORDER BY created_at DESC"
-
-	rows, err := d.store.Query(ctx, query, args...)
-	if err != nil {
-		return nil, fmt.Errorf("query confirmed patterns: %w", err)
+	if minSize < 1 {
+		minSize = DefaultMinClusterSize
 	}
-	defer rows.Close()
 
-	// Collect all patterns
+	// Only patterns that correct the agent drive refinements, and only
+	// confident, reviewed ones.
+	minConfidence := 0.8
 	var patterns []patternRecord
-	for rows.Next() {
-		var p patternRecord
-		var embeddingStr string
-		
-		err := rows.Scan(&p.ID, &p.PatternType, &p.Summary, &p.Confidence, &p.CreatedAt, &embeddingStr)
+	for _, patternType := range []string{"correction", "pushback", "reframing"} {
+		rows, err := d.store.PatternEmbeddings(ctx, store.PatternFilter{
+			PatternType:   patternType,
+			ReviewStatus:  "confirmed",
+			MinConfidence: &minConfidence,
+			Since:         since,
+		})
 		if err != nil {
-			return nil, fmt.Errorf("scan pattern row: %w", err)
-		}
-		
-		// Parse embedding - simple parser for pgvector format [0.1,0.2,0.3]
-		if embeddingStr != "" {
-			embedding, parseErr := parsePgVector(embeddingStr)
-			if parseErr != nil {
-				// Skip patterns with invalid embeddings
-				continue
-			}
-			p.Embedding = embedding
+			return nil, err
 		}
-		
-		patterns = append(patterns, p)
-	}
-	
-	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("iterate pattern rows: %w", err)
-	}
-
-	// Group patterns by type and similarity
-	clusters := d.clusterPatterns(patterns, threshold)
-	
-	// Map to SOUL sections
-	mapper := NewMapper()
-	for i := range clusters {
-		sections := mapper.MapPatternToSections(clusters[i].PatternType)
-		if len(sections) > 0 {
-			clusters[i].SOULSection = sections[0] // Use first section for now
+		for _, r := range rows {
+			patterns = append(patterns, patternRecord{
+				ID:              r.ID.String(),
+				PatternType:     r.PatternType,
+				Summary:         r.Summary,
+				ConversationArc: r.ConversationArc,
+				Tags:            r.Tags,
+				Confidence:      r.Confidence,
+				CreatedAt:       r.CreatedAt,
+				Embedding:       r.Embedding,
+			})
 		}
 	}
 
-	return clusters, nil
+	// Group patterns by type and similarity; Mapper.Target assigns sections.
+	return d.clusterPatterns(patterns, threshold, minSize)
 }
 
-// clusterPatterns groups patterns by type and embedding similarity
-func (d *Detector) clusterPatterns(patterns []patternRecord, threshold float64) []PatternCluster {
+// clusterPatterns groups patterns by type, then clusters each type by
+// embedding similarity. Clusters come largest first. It returns
+// ErrTooManyPatterns rather than cluster more than MaxScanPatterns of a type.
+func (d *Detector) clusterPatterns(patterns []patternRecord, threshold float64, minSize int) ([]PatternCluster, error) {
 	// Group by pattern type first
 	typeGroups := make(map[string][]patternRecord)
 	for _, p := range patterns {
 		typeGroups[p.PatternType] = append(typeGroups[p.PatternType], p)
 	}
-
-	var clusters []PatternCluster
-	
 	for patternType, typePatterns := range typeGroups {
-		// For each type, cluster by embedding similarity
-		typeClusters := d.clusterByEmbedding(typePatterns, threshold)
-		
-		for _, cluster := range typeClusters {
-			if len(cluster) == 0 {
-				continue
-			}
-			
-			// Use the first (most recent) pattern's summary as cluster summary
-			clusterSummary := cluster[0].Summary
-			
-			// Convert to cluster format
-			clusterPatterns := make([]ClusterPattern, len(cluster))
-			for i, p := range cluster {
-				clusterPatterns[i] = ClusterPattern{
-					ID:         p.ID,
-					Summary:    p.Summary,
-					Confidence: p.Confidence,
-					CreatedAt:  p.CreatedAt,
-				}
-			}
-			
-			clusters = append(clusters, PatternCluster{
-				PatternType: patternType,
-				Count:       len(cluster),
-				Summary:     clusterSummary,
-				Patterns:    clusterPatterns,
-			})
+		if len(typePatterns) > MaxScanPatterns {
+			return nil, fmt.Errorf("%w: %d %s patterns, at most %d; narrow the scan with since",
+				ErrTooManyPatterns, len(typePatterns), patternType, MaxScanPatterns)
 		}
 	}
 
-	return clusters
-}
+	var clusters []PatternCluster
+	for patternType, typePatterns := range typeGroups {
+		// Fix the order so the clusters do not depend on the query's.
+		sort.Slice(typePatterns, func(i, j int) bool { return typePatterns[i].ID < typePatterns[j].ID })
 
-// clusterByEmbedding clusters patterns by cosine similarity of embeddings
-func (d *Detector) clusterByEmbedding(patterns []patternRecord, threshold float64) [][]patternRecord {
-	if len(patterns) == 0 {
-		return nil
-	}
-	
-	// Simple clustering: for each pattern, find others within threshold
-	// This is a basic approach - could be improved with proper clustering algorithms
-	var clusters [][]patternRecord
-	used := make(map[string]bool)
-	
-	for _, p := range patterns {
-		if used[p.ID] {
-			continue
+		vectors := make([][]float32, len(typePatterns))
+		for i, p := range typePatterns {
+			vectors[i] = p.Embedding
 		}
-		
-		cluster := []patternRecord{p}
-		used[p.ID] = true
-		
-		// Find similar patterns
-		for _, other := range patterns {
-			if used[other.ID] || other.ID == p.ID {
+		for _, idx := range agglomerate(vectors, threshold) {
+			if len(idx) < minSize {
 				continue
 			}
-			
-			similarity := cosineSimilarity(p.Embedding, other.Embedding)
-			if similarity >= threshold {
-				cluster = append(cluster, other)
-				used[other.ID] = true
-			}
+			clusters = append(clusters, buildCluster(patternType, typePatterns, vectors, idx))
 		}
-		
-		clusters = append(clusters, cluster)
 	}
-	
-	return clusters
-}
-
-// Utility functions
 
-// parsePgVector parses a pgvector string like "[0.1,0.2,0.3]" into []float64
-func parsePgVector(s string) ([]float64, error) {
-	if len(s) < 2 || s[0] != '[' || s[len(s)-1] != ']' {
-		return nil, fmt.Errorf("invalid vector format")
-	}
-	
-	s = s[1 : len(s)-1] // Remove brackets
-	if s == "" {
-		return []float64{}, nil
-	}
-	
-	parts := strings.Split(s, ",")
-	result := make([]float64, len(parts))
-	
-	for i, part := range parts {
-		val, err := strconv.ParseFloat(strings.TrimSpace(part), 64)
-		if err != nil {
-			return nil, fmt.Errorf("parse float %q: %w", part, err)
+	sort.Slice(clusters, func(i, j int) bool {
+		if clusters[i].Count != clusters[j].Count {
+			return clusters[i].Count > clusters[j].Count
 		}
-		result[i] = val
-	}
-	
-	return result, nil
+		return clusters[i].ID < clusters[j].ID
+	})
+	return clusters, nil
 }
 
-// cosineSimilarity calculates cosine similarity between two vectors
-func cosineSimilarity(a, b []float64) float64 {
+// Utility functions
+
+// cosineSimilarity calculates cosine similarity between two vectors,
+// accumulating in float64
+func cosineSimilarity(a, b []float32) float64 {
 	if len(a) != len(b) || len(a) == 0 {
 		return 0.0
 	}
@@ -244,9 +163,10 @@ func cosineSimilarity(a, b []float64) float64 {
 	var dotProduct, normA, normB float64
 	
 	for i := 0; i < len(a); i++ {
-		dotProduct += a[i] * b[i]
-		normA += a[i] * a[i]
-		normB += b[i] * b[i]
+		x, y := float64(a[i]), float64(b[i])
+		dotProduct += x * y
+		normA += x * x
+		normB += y * y
 	}
 	
 	if normA == 0.0 || normB == 0.0 {
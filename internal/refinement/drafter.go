@@ -0,0 +1,189 @@
+package refinement
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/MikeSquared-Agency/dredd/internal/anthropic"
+)
+
+// Limits on how much of a cluster goes into the drafting prompt.
+const (
+	maxDraftArcs   = 8
+	maxDraftArcLen = 1500
+)
+
+// Draft is a proposed edit of one SOUL section.
+type Draft struct {
+	Section   string `json:"section"`
+	Diff      string `json:"diff"` // unified diff against the SOUL document
+	Rationale string `json:"rationale"`
+}
+
+// Drafter asks the LLM to rewrite the SOUL section a cluster maps to,
+// grounded in the cluster's conversation arcs, and turns the rewrite into a
+// diff. The LLM returns the revised section rather than a diff so the diff
+// always applies to the document it was drafted against.
+type Drafter struct {
+	llm    *anthropic.Client
+	source SOULSource
+	logger *slog.Logger
+}
+
+// NewDrafter creates a drafter reading SOUL documents from source.
+func NewDrafter(llm *anthropic.Client, source SOULSource, logger *slog.Logger) *Drafter {
+	return &Drafter{llm: llm, source: source, logger: logger}
+}
+
+type draftResponse struct {
+	Section   string `json:"section"`
+	Rationale string `json:"rationale"`
+}
+
+// Draft drafts an edit of the cluster's SOUL section in the slug's document.
+func (d *Drafter) Draft(ctx context.Context, cluster PatternCluster, slug string) (*Draft, error) {
+	if cluster.SOULSection == "" {
+		return nil, fmt.Errorf("cluster has no target section")
+	}
+	doc, err := d.source.Load(ctx, slug)
+	if err != nil {
+		return nil, err
+	}
+
+	doc = strings.TrimSuffix(doc, "\n")
+	lines := strings.Split(doc, "\n")
+	start, end, found := Section(doc, cluster.SOULSection)
+	for end > start && strings.TrimSpace(lines[end-1]) == "" {
+		end-- // leave the blank lines before the next heading alone
+	}
+	current := strings.Join(lines[start:end], "\n")
+	if !found {
+		current = "(This section does not exist yet. Write it, starting with a markdown heading.)"
+	}
+
+	var arcs strings.Builder
+	for i, p := range cluster.Patterns {
+		if i == maxDraftArcs {
+			break
+		}
+		arc := p.ConversationArc
+		if len(arc) > maxDraftArcLen {
+			arc = arc[:maxDraftArcLen] + "…"
+		}
+		fmt.Fprintf(&arcs, "### Pattern %d: %s\n%s\n\n", i+1, p.Summary, arc)
+	}
+
+	prompt := fmt.Sprintf(draftUserPrompt, slug, cluster.SOULSection, current, cluster.Count, cluster.PatternType, arcs.String())
+	raw, err := d.llm.Complete(ctx, draftSystemPrompt, []anthropic.Message{{Role: "user", Content: prompt}}, 4096)
+	if err != nil {
+		return nil, fmt.Errorf("llm draft: %w", err)
+	}
+
+	var resp draftResponse
+	if err := json.Unmarshal([]byte(stripFence(raw)), &resp); err != nil {
+		d.logger.Error("failed to parse draft response", "error", err, "raw", raw)
+		return nil, fmt.Errorf("parse draft: %w", err)
+	}
+	revised := strings.Split(strings.TrimRight(resp.Section, "\n"), "\n")
+	if strings.TrimSpace(resp.Section) == "" {
+		revised = nil
+	}
+	if !found && start > 0 {
+		revised = append([]string{""}, revised...) // separate the new section
+	}
+
+	diff := UnifiedDiff(slug+".md", lines[start:end], revised, start)
+	if diff == "" {
+		return nil, fmt.Errorf("draft does not change section %s", cluster.SOULSection)
+	}
+	return &Draft{Section: cluster.SOULSection, Diff: diff, Rationale: resp.Rationale}, nil
+}
+
+// stripFence removes a markdown code fence around a JSON reply.
+func stripFence(s string) string {
+	s = strings.TrimSpace(s)
+	if !strings.HasPrefix(s, "```") {
+		return s
+	}
+	s = strings.TrimPrefix(s, "```json")
+	s = strings.TrimPrefix(s, "```")
+	return strings.TrimSpace(strings.TrimSuffix(s, "```"))
+}
+
+// UnifiedDiff returns a single-hunk unified diff replacing before with after,
+// where before starts at 0-based line offset in the file. It returns "" when
+// the two are equal.
+func UnifiedDiff(name string, before, after []string, offset int) string {
+	n, m := len(before), len(after)
+	// lcs[i][j] is the longest common subsequence of before[i:] and after[j:].
+	lcs := make([][]int, n+1)
+	for i := range lcs {
+		lcs[i] = make([]int, m+1)
+	}
+	for i := n - 1; i >= 0; i-- {
+		for j := m - 1; j >= 0; j-- {
+			if before[i] == after[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+	if lcs[0][0] == n && n == m {
+		return ""
+	}
+
+	var body strings.Builder
+	i, j := 0, 0
+	for i < n || j < m {
+		switch {
+		case i < n && j < m && before[i] == after[j]:
+			body.WriteString(" " + before[i] + "\n")
+			i++
+			j++
+		case i < n && (j == m || lcs[i+1][j] >= lcs[i][j+1]):
+			body.WriteString("-" + before[i] + "\n")
+			i++
+		default:
+			body.WriteString("+" + after[j] + "\n")
+			j++
+		}
+	}
+
+	// An empty range is numbered by the line it follows.
+	from, to := offset+1, offset+1
+	if n == 0 {
+		from = offset
+	}
+	if m == 0 {
+		to = offset
+	}
+	return fmt.Sprintf("--- a/%s\n+++ b/%s\n@@ -%d,%d +%d,%d @@\n%s", name, name, from, n, to, m, body.String())
+}
+
+const draftSystemPrompt = `You edit SOUL documents: the markdown files that define an AI agent's character, judgement and working style.
+
+You are given one section of a SOUL and a cluster of conversations in which the human corrected, pushed back on, or reframed the agent. Rewrite the section so the agent would not need that correction again.
+
+Rules:
+- Change only what the conversations justify. Keep the section's voice, structure and heading, and keep everything that is still right.
+- Prefer concrete, behavioural guidance over abstractions. Quote or paraphrase the human where it makes the rule clearer.
+- Do not mention the conversations, patterns or this process in the section itself.
+
+Respond with a single JSON object and nothing else:
+{"section": "<the full revised section, heading included, in markdown>", "rationale": "<one or two sentences on what changed and which conversations justify it>"}`
+
+const draftUserPrompt = `SOUL: %s
+Section: %s
+
+Current section:
+<section>
+%s
+</section>
+
+%d %s patterns, with the conversations they came from:
+
+%s`
@@ -0,0 +1,105 @@
+package refinement
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/MikeSquared-Agency/dredd/internal/anthropic"
+)
+
+func TestUnifiedDiff(t *testing.T) {
+	before := []string{"## Anti-patterns", "- Guessing at requirements."}
+	after := []string{"## Anti-patterns", "- Guessing at requirements.", "- Picking the quick fix."}
+
+	got := UnifiedDiff("kai-soul.md", before, after, 8)
+	want := "--- a/kai-soul.md\n+++ b/kai-soul.md\n@@ -9,2 +9,3 @@\n ## Anti-patterns\n - Guessing at requirements.\n+- Picking the quick fix.\n"
+	if got != want {
+		t.Errorf("UnifiedDiff() =\n%s\nwant\n%s", got, want)
+	}
+
+	if UnifiedDiff("kai-soul.md", before, before, 0) != "" {
+		t.Error("expected no diff for equal sections")
+	}
+
+	// A new section is inserted after the last line.
+	got = UnifiedDiff("kai-soul.md", nil, []string{"## Thinking Mode"}, 14)
+	if !strings.Contains(got, "@@ -14,0 +15,1 @@\n+## Thinking Mode\n") {
+		t.Errorf("unexpected insertion diff:\n%s", got)
+	}
+}
+
+func draftServer(t *testing.T, reply string, prompt *string) *anthropic.Client {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Messages []anthropic.Message `json:"messages"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+		*prompt = req.Messages[0].Content
+		json.NewEncoder(w).Encode(map[string]any{
+			"content": []map[string]any{{"type": "text", "text": reply}},
+		})
+	}))
+	t.Cleanup(srv.Close)
+
+	llm := anthropic.NewClient("test-key", "test-model")
+	llm.SetTestTransport(srv.URL)
+	return llm
+}
+
+func TestDrafter_Draft(t *testing.T) {
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, "kai-soul.md"), []byte(testSOUL+"\n"), 0o644)
+
+	reply, _ := json.Marshal(map[string]string{
+		"section":   "## Philosophy\nShip small, and ask before guessing.",
+		"rationale": "Three corrections about guessing.",
+	})
+	var prompt string
+	llm := draftServer(t, "```json\n"+string(reply)+"\n```", &prompt)
+	d := NewDrafter(llm, NewSOULSource(dir), slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	cluster := PatternCluster{
+		PatternType: "correction",
+		Count:       1,
+		SOULSection: "philosophy",
+		Patterns:    []ClusterPattern{{Summary: "Stop guessing", ConversationArc: "Mike: ask me first"}},
+	}
+	draft, err := d.Draft(context.Background(), cluster, "kai-soul")
+	if err != nil {
+		t.Fatalf("Draft failed: %v", err)
+	}
+
+	if !strings.Contains(prompt, "Mike: ask me first") || !strings.Contains(prompt, "Ship small.") {
+		t.Errorf("expected the arc and current section in the prompt, got:\n%s", prompt)
+	}
+	want := "@@ -3,2 +3,2 @@\n ## Philosophy\n-Ship small.\n+Ship small, and ask before guessing.\n"
+	if !strings.Contains(draft.Diff, want) {
+		t.Errorf("unexpected diff:\n%s", draft.Diff)
+	}
+	if draft.Rationale != "Three corrections about guessing." || draft.Section != "philosophy" {
+		t.Errorf("unexpected draft: %+v", draft)
+	}
+}
+
+func TestDrafter_NoChange(t *testing.T) {
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, "kai-soul.md"), []byte(testSOUL), 0o644)
+
+	reply, _ := json.Marshal(map[string]string{"section": "## Philosophy\nShip small."})
+	var prompt string
+	d := NewDrafter(draftServer(t, string(reply), &prompt), NewSOULSource(dir), slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	_, err := d.Draft(context.Background(), PatternCluster{PatternType: "correction", SOULSection: "philosophy"}, "kai-soul")
+	if err == nil {
+		t.Error("expected an error for an unchanged section")
+	}
+}
@@ -1,11 +1,26 @@
 package refinement
 
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// DefaultSOULSlug is the SOUL targeted when neither the request nor the
+// mapping file names one.
+const DefaultSOULSlug = "kai-soul"
+
+// fallbackSOUL is the mapping file key whose rules apply to SOULs it does
+// not list.
+const fallbackSOUL = "*"
+
 // Mapper maps pattern types to SOUL sections for refinement targeting
 type Mapper struct {
 	mapping map[string][]string
+	tags    map[string][]string
 }
 
-// NewMapper creates a new SOUL section mapper
+// NewMapper creates a new SOUL section mapper with the built-in rules
 func NewMapper() *Mapper {
 	return &Mapper{
 		mapping: map[string][]string{
@@ -29,3 +44,121 @@ func (m *Mapper) MapPatternToSections(patternType string) []string {
 	copy(result, sections)
 	return result
 }
+
+// Sections returns every section a cluster should be proposed against: the
+// sections of its pattern type, then those of each tag rule that at least
+// half of its patterns carry, without duplicates.
+func (m *Mapper) Sections(cluster PatternCluster) []string {
+	var sections []string
+	seen := map[string]bool{}
+	add := func(s []string) {
+		for _, section := range s {
+			if !seen[section] {
+				seen[section] = true
+				sections = append(sections, section)
+			}
+		}
+	}
+
+	add(m.mapping[cluster.PatternType])
+
+	counts := map[string]int{}
+	for _, p := range cluster.Patterns {
+		for _, tag := range p.Tags {
+			counts[tag]++
+		}
+	}
+	// Walk the patterns rather than the map so the order is stable.
+	for _, p := range cluster.Patterns {
+		for _, tag := range p.Tags {
+			if rule, ok := m.tags[tag]; ok && 2*counts[tag] >= len(cluster.Patterns) {
+				add(rule)
+			}
+		}
+	}
+	return sections
+}
+
+// Target returns one copy of each cluster per section it maps to in the
+// given SOUL. Clusters that map to no section are left out.
+func (m *Mapper) Target(clusters []PatternCluster, soulSlug string) []PatternCluster {
+	var targeted []PatternCluster
+	for _, c := range clusters {
+		for _, section := range m.Sections(c) {
+			c.SOULSlug = soulSlug
+			c.SOULSection = section
+			targeted = append(targeted, c)
+		}
+	}
+	return targeted
+}
+
+// Mapping holds the section rules of each target SOUL.
+type Mapping struct {
+	DefaultSOUL string
+	souls       map[string]*Mapper
+}
+
+// DefaultMapping applies the built-in rules to every SOUL.
+func DefaultMapping() *Mapping {
+	return &Mapping{
+		DefaultSOUL: DefaultSOULSlug,
+		souls:       map[string]*Mapper{fallbackSOUL: NewMapper()},
+	}
+}
+
+// mappingFile is the on-disk form of a Mapping:
+//
+//	{
+//	  "default_soul": "kai-soul",
+//	  "souls": {
+//	    "kai-soul": {
+//	      "pattern_types": {"correction": ["thinking_mode", "anti_patterns"]},
+//	      "tags": {"security": ["security"]}
+//	    },
+//	    "*": {"pattern_types": {"pushback": ["anti_patterns"]}}
+//	  }
+//	}
+//
+// The "*" entry, when present, applies to SOULs not listed by name.
+type mappingFile struct {
+	DefaultSOUL string `json:"default_soul"`
+	Souls       map[string]struct {
+		PatternTypes map[string][]string `json:"pattern_types"`
+		Tags         map[string][]string `json:"tags"`
+	} `json:"souls"`
+}
+
+// LoadMapping reads a mapping file.
+func LoadMapping(path string) (*Mapping, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read SOUL mapping: %w", err)
+	}
+	var f mappingFile
+	if err := json.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("parse SOUL mapping %s: %w", path, err)
+	}
+	if len(f.Souls) == 0 {
+		return nil, fmt.Errorf("SOUL mapping %s defines no SOULs", path)
+	}
+
+	m := &Mapping{DefaultSOUL: f.DefaultSOUL, souls: make(map[string]*Mapper, len(f.Souls))}
+	if m.DefaultSOUL == "" {
+		m.DefaultSOUL = DefaultSOULSlug
+	}
+	for slug, rules := range f.Souls {
+		m.souls[slug] = &Mapper{mapping: rules.PatternTypes, tags: rules.Tags}
+	}
+	return m, nil
+}
+
+// For returns the mapper for a SOUL, falling back to the "*" rules. ok is
+// false when the SOUL has no rules.
+func (m *Mapping) For(soulSlug string) (*Mapper, bool) {
+	if mapper, ok := m.souls[soulSlug]; ok {
+		return mapper, true
+	}
+	mapper, ok := m.souls[fallbackSOUL]
+	return mapper, ok
+}
@@ -1,6 +1,8 @@
 package refinement
 
 import (
+	"os"
+	"path/filepath"
 	"reflect"
 	"testing"
 )
@@ -71,3 +73,109 @@ func TestMapper_MapPatternToSections_ImmutableReturn(t *testing.T) {
 		t.Errorf("MapPatternToSections should return immutable copies, got %v", sections2)
 	}
 }
+
+func TestMapper_Sections(t *testing.T) {
+	mapper := &Mapper{
+		mapping: map[string][]string{"correction": {"thinking_mode", "anti_patterns"}},
+		tags:    map[string][]string{"security": {"security", "anti_patterns"}, "tone": {"voice"}},
+	}
+	cluster := PatternCluster{PatternType: "correction", Patterns: []ClusterPattern{
+		{ID: "a", Tags: []string{"security"}},
+		{ID: "b", Tags: []string{"security", "tone"}},
+		{ID: "c"},
+	}}
+
+	// security is on two of three patterns; tone on only one.
+	expected := []string{"thinking_mode", "anti_patterns", "security"}
+	if got := mapper.Sections(cluster); !reflect.DeepEqual(got, expected) {
+		t.Errorf("Sections() = %v, want %v", got, expected)
+	}
+}
+
+func TestMapper_Target(t *testing.T) {
+	clusters := []PatternCluster{
+		{ID: "c1", PatternType: "correction"},
+		{ID: "c2", PatternType: "unknown"},
+		{ID: "c3", PatternType: "pushback"},
+	}
+
+	targeted := NewMapper().Target(clusters, "kai-soul")
+	var got []string
+	for _, c := range targeted {
+		if c.SOULSlug != "kai-soul" {
+			t.Errorf("cluster %s: expected kai-soul, got %q", c.ID, c.SOULSlug)
+		}
+		got = append(got, c.ID+"/"+c.SOULSection)
+	}
+	expected := []string{"c1/thinking_mode", "c1/anti_patterns", "c3/anti_patterns"}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("Target() = %v, want %v", got, expected)
+	}
+}
+
+func TestLoadMapping(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "mapping.json")
+	if err := os.WriteFile(path, []byte(`{
+		"default_soul": "ops-soul",
+		"souls": {
+			"ops-soul": {"pattern_types": {"correction": ["runbooks"]}, "tags": {"security": ["security"]}},
+			"*": {"pattern_types": {"pushback": ["anti_patterns"]}}
+		}
+	}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	m, err := LoadMapping(path)
+	if err != nil {
+		t.Fatalf("LoadMapping: %v", err)
+	}
+	if m.DefaultSOUL != "ops-soul" {
+		t.Errorf("expected default ops-soul, got %q", m.DefaultSOUL)
+	}
+
+	ops, ok := m.For("ops-soul")
+	if !ok || !reflect.DeepEqual(ops.MapPatternToSections("correction"), []string{"runbooks"}) {
+		t.Errorf("unexpected ops-soul rules: %+v", ops)
+	}
+	other, ok := m.For("kai-soul")
+	if !ok || !reflect.DeepEqual(other.MapPatternToSections("pushback"), []string{"anti_patterns"}) {
+		t.Errorf("expected kai-soul to fall back to the * rules, got %+v", other)
+	}
+}
+
+func TestLoadMapping_NoFallback(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "mapping.json")
+	if err := os.WriteFile(path, []byte(`{"souls": {"ops-soul": {"pattern_types": {"correction": ["runbooks"]}}}}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	m, err := LoadMapping(path)
+	if err != nil {
+		t.Fatalf("LoadMapping: %v", err)
+	}
+	if m.DefaultSOUL != DefaultSOULSlug {
+		t.Errorf("expected default %s, got %q", DefaultSOULSlug, m.DefaultSOUL)
+	}
+	if _, ok := m.For("kai-soul"); ok {
+		t.Error("expected no rules for an unlisted SOUL without a * entry")
+	}
+}
+
+func TestLoadMapping_Invalid(t *testing.T) {
+	dir := t.TempDir()
+	for name, body := range map[string]string{
+		"empty.json":  `{"souls": {}}`,
+		"broken.json": `{"souls":`,
+	} {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(body), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := LoadMapping(path); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+	if _, err := LoadMapping(filepath.Join(dir, "missing.json")); err == nil {
+		t.Error("missing file: expected an error")
+	}
+}
@@ -0,0 +1,65 @@
+package refinement
+
+import (
+	"github.com/MikeSquared-Agency/dredd/internal/store"
+)
+
+// MatchOverlap is the fraction of a cluster's patterns, or of a proposal's,
+// that must be shared for the cluster to count as that proposal again.
+const MatchOverlap = 0.5
+
+// SuppressingStatuses are the proposal statuses that stop a scan from
+// re-proposing a matching cluster: open proposals are already under review
+// and rejected ones were turned down. Applied proposals do not suppress, so
+// patterns that keep clustering after a change was applied resurface.
+var SuppressingStatuses = []string{store.ProposalProposed, store.ProposalAccepted, store.ProposalRejected}
+
+// Matches reports whether cluster is substantially the proposal again: the
+// same SOUL and section, and either the same cluster ID or the same pattern
+// type with more than MatchOverlap of the cluster's patterns among the
+// proposal's members, or of the proposal's members in the cluster. Overlap
+// both ways matters because cluster IDs depend on the scan window: a scan
+// over a longer window can find the proposal's cluster grown under a new ID.
+// A cluster mapped to several sections is matched per section.
+func Matches(cluster PatternCluster, p store.RefinementProposal) bool {
+	if cluster.SOULSlug != p.TargetSOULSlug || cluster.SOULSection != p.TargetSection {
+		return false
+	}
+	if cluster.ID != "" && cluster.ID == p.ClusterID {
+		return true
+	}
+	if cluster.PatternType != p.PatternType || len(cluster.Patterns) == 0 {
+		return false
+	}
+	members := make(map[string]bool, len(p.PatternIDs))
+	for _, id := range p.PatternIDs {
+		members[id.String()] = true
+	}
+	shared := 0
+	for _, cp := range cluster.Patterns {
+		if members[cp.ID] {
+			shared++
+		}
+	}
+	return float64(shared)/float64(len(cluster.Patterns)) > MatchOverlap ||
+		float64(shared)/float64(len(members)) > MatchOverlap
+}
+
+// Suppress drops clusters that match any of proposals and returns the rest
+// with the number dropped.
+func Suppress(clusters []PatternCluster, proposals []store.RefinementProposal) ([]PatternCluster, int) {
+	var kept []PatternCluster
+	for _, c := range clusters {
+		matched := false
+		for _, p := range proposals {
+			if Matches(c, p) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			kept = append(kept, c)
+		}
+	}
+	return kept, len(clusters) - len(kept)
+}
@@ -0,0 +1,99 @@
+package refinement
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/MikeSquared-Agency/dredd/internal/store"
+)
+
+func clusterOf(patternType string, ids ...uuid.UUID) PatternCluster {
+	c := PatternCluster{PatternType: patternType, Count: len(ids)}
+	for _, id := range ids {
+		c.Patterns = append(c.Patterns, ClusterPattern{ID: id.String()})
+	}
+	return c
+}
+
+func TestMatches(t *testing.T) {
+	a, b, c, d := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	proposal := store.RefinementProposal{ClusterID: "c1", PatternType: "correction", PatternIDs: []uuid.UUID{a, b, c}}
+
+	tests := []struct {
+		name    string
+		cluster PatternCluster
+		want    bool
+	}{
+		{"same members", clusterOf("correction", a, b, c), true},
+		{"grown by one", clusterOf("correction", a, b, c, d), true},
+		{"half overlap", clusterOf("correction", a, d), false},
+		{"grown under a wider window", clusterOf("correction", a, b, c, uuid.New(), uuid.New(), uuid.New(), uuid.New()), true},
+		{"other type", clusterOf("pushback", a, b, c), false},
+		{"empty", clusterOf("correction"), false},
+		{"same cluster ID", PatternCluster{ID: "c1", PatternType: "correction", Patterns: []ClusterPattern{{ID: d.String()}}}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Matches(tt.cluster, proposal); got != tt.want {
+				t.Errorf("Matches() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMatches_PerSection(t *testing.T) {
+	a, b := uuid.New(), uuid.New()
+	proposal := store.RefinementProposal{
+		ClusterID: "c1", PatternType: "correction", PatternIDs: []uuid.UUID{a, b},
+		TargetSOULSlug: "kai-soul", TargetSection: "thinking_mode",
+	}
+
+	cluster := clusterOf("correction", a, b)
+	cluster.ID = "c1"
+	cluster.SOULSlug = "kai-soul"
+
+	cluster.SOULSection = "thinking_mode"
+	if !Matches(cluster, proposal) {
+		t.Error("expected a match on the proposal's section")
+	}
+	cluster.SOULSection = "anti_patterns"
+	if Matches(cluster, proposal) {
+		t.Error("expected no match on another section")
+	}
+	cluster.SOULSlug, cluster.SOULSection = "ops-soul", "thinking_mode"
+	if Matches(cluster, proposal) {
+		t.Error("expected no match on another SOUL")
+	}
+}
+
+func TestSuppress(t *testing.T) {
+	a, b, c := uuid.New(), uuid.New(), uuid.New()
+	proposals := []store.RefinementProposal{{PatternType: "correction", PatternIDs: []uuid.UUID{a, b}}}
+
+	kept, suppressed := Suppress([]PatternCluster{
+		clusterOf("correction", a, b),
+		clusterOf("correction", c),
+	}, proposals)
+
+	if suppressed != 1 || len(kept) != 1 || kept[0].Patterns[0].ID != c.String() {
+		t.Errorf("expected only the new cluster kept, got %d suppressed and %+v", suppressed, kept)
+	}
+}
+
+func TestProposal(t *testing.T) {
+	a := uuid.New()
+	cluster := clusterOf("pushback", a)
+	cluster.Patterns = append(cluster.Patterns, ClusterPattern{ID: "not-a-uuid"})
+	cluster.SOULSection = "Boundaries"
+
+	p := Proposal(cluster, "kai-soul")
+	if p.PatternType != "pushback" || p.TargetSOULSlug != "kai-soul" || p.TargetSection != "Boundaries" {
+		t.Errorf("unexpected proposal: %+v", p)
+	}
+	if len(p.PatternIDs) != 1 || p.PatternIDs[0] != a {
+		t.Errorf("expected only the valid pattern ID, got %v", p.PatternIDs)
+	}
+	if p.ProposedChange == "" {
+		t.Error("expected a proposed change")
+	}
+}
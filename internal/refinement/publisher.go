@@ -4,38 +4,54 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/google/uuid"
+	"github.com/MikeSquared-Agency/dredd/internal/bus"
+	"github.com/MikeSquared-Agency/dredd/internal/events"
 	"github.com/MikeSquared-Agency/dredd/internal/hermes"
+	"github.com/MikeSquared-Agency/dredd/internal/store"
 )
 
 // RefinementEvent represents a pattern refinement proposal
-type RefinementEvent struct {
-	Patterns        []PatternProposal `json:"patterns"`
-	TargetSOULSlug  string            `json:"target_soul_slug"`
-	TargetSection   string            `json:"target_section"`
-	ProposedChange  string            `json:"proposed_change"`
-	ClusterSize     int               `json:"cluster_size"`
-	Timestamp       time.Time         `json:"timestamp"`
-}
+type RefinementEvent = events.RefinementProposed
 
 // PatternProposal represents a pattern within a refinement proposal
-type PatternProposal struct {
-	ID          string  `json:"id"`
-	Summary     string  `json:"summary"`
-	PatternType string  `json:"pattern_type"`
-	Confidence  float64 `json:"confidence"`
-}
+type PatternProposal = events.PatternProposal
 
-// Publisher publishes pattern refinement events to NATS
+// Publisher publishes pattern refinement events to NATS and the event bus
 type Publisher struct {
 	hermes *hermes.Client
+	bus    *bus.Bus
+}
+
+// NewPublisher creates a new refinement event publisher. b may be nil.
+func NewPublisher(hermes *hermes.Client, b *bus.Bus) *Publisher {
+	return &Publisher{hermes: hermes, bus: b}
 }
 
-// NewPublisher creates a new refinement event publisher
-func NewPublisher(hermes *hermes.Client) *Publisher {
-	return &Publisher{hermes: hermes}
+// Proposal builds the stored proposal for a cluster.
+func Proposal(cluster PatternCluster, targetSOULSlug string) store.RefinementProposal {
+	ids := make([]uuid.UUID, 0, len(cluster.Patterns))
+	for _, pattern := range cluster.Patterns {
+		if id, err := uuid.Parse(pattern.ID); err == nil {
+			ids = append(ids, id)
+		}
+	}
+	p := store.RefinementProposal{
+		ClusterID:      cluster.ID,
+		PatternType:    cluster.PatternType,
+		PatternIDs:     ids,
+		TargetSOULSlug: targetSOULSlug,
+		TargetSection:  cluster.SOULSection,
+		ProposedChange: proposedChange(cluster),
+	}
+	if cluster.Draft != nil {
+		p.SectionDiff = cluster.Draft.Diff
+	}
+	return p
 }
 
-// PublishRefinementProposal publishes a pattern refinement proposal to NATS
+// PublishRefinementProposal publishes a pattern refinement proposal to NATS.
+// cluster.ProposalID, when set, links the event to the stored proposal.
 func (p *Publisher) PublishRefinementProposal(cluster PatternCluster, targetSOULSlug string) error {
 	// Convert cluster patterns to proposals
 	proposals := make([]PatternProposal, len(cluster.Patterns))
@@ -50,17 +66,36 @@ func (p *Publisher) PublishRefinementProposal(cluster PatternCluster, targetSOUL
 
 	// Create refinement event
 	event := RefinementEvent{
+		SchemaVersion:  events.SchemaVersion,
+		ProposalID:     cluster.ProposalID,
+		ClusterID:      cluster.ID,
 		Patterns:       proposals,
 		TargetSOULSlug: targetSOULSlug,
 		TargetSection:  cluster.SOULSection,
-		ProposedChange: generateProposedChange(cluster),
+		ProposedChange: proposedChange(cluster),
 		ClusterSize:    cluster.Count,
 		Timestamp:      time.Now().UTC(),
 	}
 
+	if cluster.Draft != nil {
+		event.SectionDiff = cluster.Draft.Diff
+	}
+
 	// Publish to NATS
-	subject := "pattern.refinement.proposed"
-	return p.hermes.Publish(subject, event)
+	if err := p.hermes.Publish(events.SubjectRefinementProposed, event); err != nil {
+		return err
+	}
+	p.bus.Publish(bus.KindRefinementProposed, uuid.Nil, event)
+	return nil
+}
+
+// proposedChange describes the change: the draft's rationale when the
+// cluster was drafted, otherwise a summary of the cluster.
+func proposedChange(cluster PatternCluster) string {
+	if cluster.Draft != nil && cluster.Draft.Rationale != "" {
+		return cluster.Draft.Rationale
+	}
+	return generateProposedChange(cluster)
 }
 
 // generateProposedChange creates a human-readable description of the proposed change
@@ -0,0 +1,143 @@
+package refinement
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// SOULSource loads the current SOUL document for a slug.
+type SOULSource interface {
+	Load(ctx context.Context, slug string) (string, error)
+}
+
+// NewSOULSource returns a source for spec: an http(s) base URL, fetched as
+// <url>/<slug>.md, or a directory holding <slug>.md files. It returns nil
+// for an empty spec.
+func NewSOULSource(spec string) SOULSource {
+	switch {
+	case spec == "":
+		return nil
+	case strings.HasPrefix(spec, "http://") || strings.HasPrefix(spec, "https://"):
+		return &HTTPSource{BaseURL: strings.TrimRight(spec, "/"), client: &http.Client{Timeout: 30 * time.Second}}
+	default:
+		return DirSource(spec)
+	}
+}
+
+// DirSource reads <dir>/<slug>.md.
+type DirSource string
+
+// Load reads the SOUL document for slug.
+func (d DirSource) Load(ctx context.Context, slug string) (string, error) {
+	if err := validSlug(slug); err != nil {
+		return "", err
+	}
+	b, err := os.ReadFile(filepath.Join(string(d), slug+".md"))
+	if err != nil {
+		return "", fmt.Errorf("read SOUL %s: %w", slug, err)
+	}
+	return string(b), nil
+}
+
+// HTTPSource fetches <BaseURL>/<slug>.md.
+type HTTPSource struct {
+	BaseURL string
+	client  *http.Client
+}
+
+// Load fetches the SOUL document for slug.
+func (h *HTTPSource) Load(ctx context.Context, slug string) (string, error) {
+	if err := validSlug(slug); err != nil {
+		return "", err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, h.BaseURL+"/"+slug+".md", nil)
+	if err != nil {
+		return "", fmt.Errorf("create request: %w", err)
+	}
+	resp, err := h.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("fetch SOUL %s: %w", slug, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("read SOUL %s: %w", slug, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("fetch SOUL %s: status %d", slug, resp.StatusCode)
+	}
+	return string(body), nil
+}
+
+// validSlug rejects slugs that could escape the source's directory or path.
+func validSlug(slug string) error {
+	if slug == "" || strings.ContainsAny(slug, `/\`) || strings.Contains(slug, "..") {
+		return fmt.Errorf("invalid SOUL slug %q", slug)
+	}
+	return nil
+}
+
+// Section locates a markdown section by name. A heading matches when its
+// title, lowercased with runs of other characters as underscores, equals
+// name, so "## Anti-patterns" matches "anti_patterns". The section runs from
+// the heading to the next heading of the same or a higher level. start and
+// end are line indexes, end exclusive; ok is false when there is no match,
+// in which case start and end are both the line count.
+func Section(doc, name string) (start, end int, ok bool) {
+	lines := strings.Split(doc, "\n")
+	level := 0
+	for i, line := range lines {
+		l, title := heading(line)
+		if l == 0 {
+			continue
+		}
+		if level == 0 {
+			if sectionKey(title) == name {
+				start, level = i, l
+			}
+			continue
+		}
+		if l <= level {
+			return start, i, true
+		}
+	}
+	if level != 0 {
+		return start, len(lines), true
+	}
+	return len(lines), len(lines), false
+}
+
+// heading returns the level and title of a markdown ATX heading, or 0.
+func heading(line string) (int, string) {
+	level := 0
+	for level < len(line) && line[level] == '#' {
+		level++
+	}
+	if level == 0 || level > 6 || level == len(line) || line[level] != ' ' {
+		return 0, ""
+	}
+	return level, strings.TrimSpace(line[level:])
+}
+
+// sectionKey normalises a heading title to a mapper section name.
+func sectionKey(title string) string {
+	var b strings.Builder
+	underscore := false
+	for _, r := range strings.ToLower(title) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			b.WriteRune(r)
+			underscore = false
+		} else if !underscore && b.Len() > 0 {
+			b.WriteByte('_')
+			underscore = true
+		}
+	}
+	return strings.TrimSuffix(b.String(), "_")
+}
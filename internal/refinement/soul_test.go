@@ -0,0 +1,99 @@
+package refinement
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const testSOUL = `# Kai
+
+## Philosophy
+Ship small.
+
+## Anti-patterns
+- Guessing at requirements.
+
+### Examples
+- Asking twice.
+
+## Interaction Modes
+Direct.`
+
+func TestSection(t *testing.T) {
+	lines := strings.Split(testSOUL, "\n")
+	tests := []struct {
+		name      string
+		wantFirst string
+		wantLen   int
+		wantOK    bool
+	}{
+		{"philosophy", "## Philosophy", 3, true},
+		{"anti_patterns", "## Anti-patterns", 6, true}, // includes the nested heading
+		{"interaction_modes", "## Interaction Modes", 2, true},
+		{"thinking_mode", "", 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start, end, ok := Section(testSOUL, tt.name)
+			if ok != tt.wantOK || end-start != tt.wantLen {
+				t.Fatalf("Section() = %d, %d, %v", start, end, ok)
+			}
+			if ok && lines[start] != tt.wantFirst {
+				t.Errorf("expected section to start at %q, got %q", tt.wantFirst, lines[start])
+			}
+			if !ok && start != len(lines) {
+				t.Errorf("expected a missing section to point past the end, got %d", start)
+			}
+		})
+	}
+}
+
+func TestDirSource(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "kai-soul.md"), []byte(testSOUL), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	src := NewSOULSource(dir)
+
+	doc, err := src.Load(context.Background(), "kai-soul")
+	if err != nil || doc != testSOUL {
+		t.Fatalf("Load() = %q, %v", doc, err)
+	}
+	if _, err := src.Load(context.Background(), "../kai-soul"); err == nil {
+		t.Error("expected a path-escaping slug to be rejected")
+	}
+	if _, err := src.Load(context.Background(), "missing"); err == nil {
+		t.Error("expected an error for a missing SOUL")
+	}
+}
+
+func TestHTTPSource(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/souls/kai-soul.md" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(testSOUL))
+	}))
+	defer srv.Close()
+	src := NewSOULSource(srv.URL + "/souls/")
+
+	doc, err := src.Load(context.Background(), "kai-soul")
+	if err != nil || doc != testSOUL {
+		t.Fatalf("Load() = %q, %v", doc, err)
+	}
+	if _, err := src.Load(context.Background(), "other"); err == nil {
+		t.Error("expected an error for a 404")
+	}
+}
+
+func TestNewSOULSource_Empty(t *testing.T) {
+	if NewSOULSource("") != nil {
+		t.Error("expected no source for an empty spec")
+	}
+}
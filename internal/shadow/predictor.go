@@ -0,0 +1,184 @@
+// Package shadow predicts human gate verdicts from precedent without acting on them.
+package shadow
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// Gate verdicts, matching the decision types captured by the processor.
+const (
+	VerdictApproved         = "approved"
+	VerdictChangesRequested = "changes_requested"
+	VerdictBlocked          = "blocked"
+)
+
+// Category is the agent_trust and autonomy category gate predictions are scored under.
+const Category = "gate_approval"
+
+// priorWeight scales the stage-level verdict distribution relative to a
+// single perfectly similar neighbour.
+const priorWeight = 0.5
+
+// Severity is the severity gate verdicts are trusted under when the gate does
+// not say. It must not depend on the verdict, or the trust record for a
+// severity would only ever see the verdicts that map to it.
+const Severity = "routine"
+
+// GateSeverity returns the gate's own severity if it is a known one, and
+// Severity otherwise.
+func GateSeverity(severity string) string {
+	switch severity {
+	case "routine", "significant", "critical":
+		return severity
+	}
+	return Severity
+}
+
+// Example is a past gate decision: the evidence it was made on, the
+// reviewer's reasoning, and the human verdict.
+type Example struct {
+	Text      string
+	Reasoning string
+	Verdict   string
+}
+
+// Prediction is a shadow verdict with the evidence it was based on.
+type Prediction struct {
+	Verdict    string  `json:"verdict"`
+	Confidence float64 `json:"confidence"`
+	Neighbours int     `json:"neighbours"`
+	Rationale  string  `json:"rationale"`
+}
+
+// neighbour is an example scored against the submission being predicted.
+type neighbour struct {
+	Example
+	similarity float64
+}
+
+// Predict votes on a verdict for text using the k most similar examples,
+// weighted by similarity, plus the stage's historical verdict distribution as
+// a prior. An example is compared on its evidence and its reasoning, since a
+// reviewer's objection often names what a new submission lacks. With no
+// precedent at all it predicts approval with zero confidence.
+func Predict(text string, examples []Example, priors map[string]int, k int) Prediction {
+	query := tokenize(text)
+
+	var neighbours []neighbour
+	for _, ex := range examples {
+		sim := jaccard(query, tokenize(ex.Text+"\n"+ex.Reasoning))
+		if sim > 0 {
+			neighbours = append(neighbours, neighbour{Example: ex, similarity: sim})
+		}
+	}
+	sort.SliceStable(neighbours, func(i, j int) bool {
+		return neighbours[i].similarity > neighbours[j].similarity
+	})
+	if k > 0 && len(neighbours) > k {
+		neighbours = neighbours[:k]
+	}
+
+	scores := make(map[string]float64)
+	for _, n := range neighbours {
+		scores[n.Verdict] += n.similarity
+	}
+
+	priorTotal := 0
+	for _, n := range priors {
+		priorTotal += n
+	}
+	for verdict, n := range priors {
+		scores[verdict] += priorWeight * float64(n) / float64(priorTotal)
+	}
+
+	if len(scores) == 0 {
+		return Prediction{
+			Verdict:   VerdictApproved,
+			Rationale: "no precedent at this stage",
+		}
+	}
+
+	var best string
+	var bestScore, sum float64
+	for _, verdict := range []string{VerdictApproved, VerdictChangesRequested, VerdictBlocked} {
+		sum += scores[verdict]
+		if scores[verdict] > bestScore {
+			best, bestScore = verdict, scores[verdict]
+		}
+	}
+
+	return Prediction{
+		Verdict:    best,
+		Confidence: bestScore / sum,
+		Neighbours: len(neighbours),
+		Rationale:  rationale(best, neighbours, priors, priorTotal),
+	}
+}
+
+// rationale explains a prediction in one line for reviewers.
+func rationale(verdict string, neighbours []neighbour, priors map[string]int, priorTotal int) string {
+	var parts []string
+	if len(neighbours) > 0 {
+		agree := 0
+		for _, n := range neighbours {
+			if n.Verdict == verdict {
+				agree++
+			}
+		}
+		parts = append(parts, fmt.Sprintf("%d of %d similar gate decisions were %s (closest %.2f was %s)",
+			agree, len(neighbours), verdict, neighbours[0].similarity, neighbours[0].Verdict))
+		if r := strings.Join(strings.Fields(neighbours[0].Reasoning), " "); r != "" {
+			if len(r) > maxReasoning {
+				r = r[:maxReasoning] + "…"
+			}
+			parts = append(parts, fmt.Sprintf("closest reasoning: %q", r))
+		}
+	}
+	if priorTotal > 0 {
+		parts = append(parts, fmt.Sprintf("%d of %d past gate decisions at this stage were %s",
+			priors[verdict], priorTotal, verdict))
+	}
+	return strings.Join(parts, "; ")
+}
+
+// maxReasoning caps how much of the closest decision's reasoning a rationale quotes.
+const maxReasoning = 160
+
+// stopwords are dropped before comparing submissions.
+var stopwords = map[string]bool{
+	"the": true, "and": true, "for": true, "with": true, "that": true, "this": true,
+	"are": true, "was": true, "were": true, "has": true, "have": true, "from": true,
+	"not": true, "but": true, "all": true, "its": true, "into": true, "been": true,
+}
+
+// tokenize lowercases text and returns its set of meaningful words.
+func tokenize(text string) map[string]bool {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	set := make(map[string]bool, len(words))
+	for _, w := range words {
+		if len(w) < 3 || stopwords[w] {
+			continue
+		}
+		set[w] = true
+	}
+	return set
+}
+
+// jaccard returns the overlap between two token sets.
+func jaccard(a, b map[string]bool) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+	inter := 0
+	for w := range a {
+		if b[w] {
+			inter++
+		}
+	}
+	return float64(inter) / float64(len(a)+len(b)-inter)
+}
@@ -0,0 +1,116 @@
+package shadow
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestPredict_NoPrecedent(t *testing.T) {
+	p := Predict("all tests passing", nil, nil, 5)
+	if p.Verdict != VerdictApproved {
+		t.Errorf("expected approved, got %q", p.Verdict)
+	}
+	if p.Confidence != 0 {
+		t.Errorf("expected zero confidence, got %f", p.Confidence)
+	}
+	if p.Neighbours != 0 {
+		t.Errorf("expected no neighbours, got %d", p.Neighbours)
+	}
+}
+
+func TestPredict_NearestNeighboursWin(t *testing.T) {
+	examples := []Example{
+		{Text: "CI green build test vet lint passed", Verdict: VerdictApproved},
+		{Text: "CI green build test lint passed on branch", Verdict: VerdictApproved},
+		{Text: "missing migration rollback plan for schema change", Verdict: VerdictChangesRequested},
+		{Text: "secrets committed to repository credentials leaked", Verdict: VerdictBlocked},
+	}
+
+	p := Predict("schema change without a rollback plan or migration notes", examples, nil, 3)
+	if p.Verdict != VerdictChangesRequested {
+		t.Errorf("expected changes_requested, got %q (%s)", p.Verdict, p.Rationale)
+	}
+
+	p = Predict("CI green: build, test, lint passed", examples, nil, 3)
+	if p.Verdict != VerdictApproved {
+		t.Errorf("expected approved, got %q (%s)", p.Verdict, p.Rationale)
+	}
+	if p.Confidence <= 0.5 {
+		t.Errorf("expected majority confidence, got %f", p.Confidence)
+	}
+}
+
+func TestPredict_PriorOnly(t *testing.T) {
+	priors := map[string]int{VerdictApproved: 2, VerdictBlocked: 8}
+
+	p := Predict("unrelated evidence", nil, priors, 5)
+	if p.Verdict != VerdictBlocked {
+		t.Errorf("expected blocked from prior, got %q", p.Verdict)
+	}
+	if math.Abs(p.Confidence-0.8) > 1e-9 {
+		t.Errorf("expected confidence 0.8, got %f", p.Confidence)
+	}
+}
+
+func TestPredict_LimitsToK(t *testing.T) {
+	examples := []Example{
+		{Text: "alpha beta gamma", Verdict: VerdictApproved},
+		{Text: "alpha beta delta", Verdict: VerdictApproved},
+		{Text: "alpha epsilon zeta", Verdict: VerdictBlocked},
+	}
+
+	p := Predict("alpha beta gamma", examples, nil, 2)
+	if p.Neighbours != 2 {
+		t.Errorf("expected 2 neighbours, got %d", p.Neighbours)
+	}
+	if p.Verdict != VerdictApproved || p.Confidence != 1.0 {
+		t.Errorf("expected unanimous approval, got %q at %f", p.Verdict, p.Confidence)
+	}
+}
+
+func TestPredict_MatchesReasoning(t *testing.T) {
+	examples := []Example{
+		{Text: "PR opened, tests added", Reasoning: "no rollback plan for the schema migration", Verdict: VerdictChangesRequested},
+		{Text: "PR opened, docs updated", Verdict: VerdictApproved},
+	}
+
+	p := Predict("schema migration included, rollback plan pending", examples, nil, 1)
+	if p.Verdict != VerdictChangesRequested {
+		t.Fatalf("expected changes_requested from the reviewer's reasoning, got %q (%s)", p.Verdict, p.Rationale)
+	}
+	if !strings.Contains(p.Rationale, "no rollback plan") {
+		t.Errorf("expected the rationale to quote the closest reasoning, got %q", p.Rationale)
+	}
+}
+
+func TestGateSeverity(t *testing.T) {
+	for in, want := range map[string]string{"critical": "critical", "significant": "significant", "": Severity, "blocked": Severity} {
+		if got := GateSeverity(in); got != want {
+			t.Errorf("GateSeverity(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestJaccard(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b string
+		want float64
+	}{
+		{"identical", "tests passing", "tests passing", 1.0},
+		{"disjoint", "tests passing", "schema broken", 0.0},
+		{"half overlap", "tests passing", "tests failing", 1.0 / 3.0},
+		{"stopwords ignored", "the tests and", "tests", 1.0},
+		{"empty", "", "tests", 0.0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := jaccard(tokenize(tt.a), tokenize(tt.b))
+			if math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("jaccard(%q, %q) = %f, want %f", tt.a, tt.b, got, tt.want)
+			}
+		})
+	}
+}
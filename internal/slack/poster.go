@@ -12,6 +12,7 @@ import (
 	"time"
 
 	"github.com/MikeSquared-Agency/dredd/internal/extractor"
+	"github.com/MikeSquared-Agency/dredd/internal/metrics"
 )
 
 const defaultPostMessageURL = "https://slack.com/api/chat.postMessage"
@@ -105,7 +106,13 @@ func (p *Poster) PostReviewSummary(ctx context.Context, result *extractor.Extrac
 
 // postMessage sends a single Slack message, optionally as a thread reply.
 // Returns the message TS.
-func (p *Poster) postMessage(ctx context.Context, text, threadTS string) (string, error) {
+func (p *Poster) postMessage(ctx context.Context, text, threadTS string) (_ string, err error) {
+	defer func() {
+		if err != nil {
+			metrics.SlackPostFailures.WithLabelValues("message").Inc()
+		}
+	}()
+
 	payload := map[string]any{
 		"channel": p.channel,
 		"text":    text,
@@ -171,7 +178,13 @@ func (p *Poster) postMessage(ctx context.Context, text, threadTS string) (string
 }
 
 // PostThread posts a threaded reply to a message.
-func (p *Poster) PostThread(ctx context.Context, threadTS, text string) error {
+func (p *Poster) PostThread(ctx context.Context, threadTS, text string) (err error) {
+	defer func() {
+		if err != nil {
+			metrics.SlackPostFailures.WithLabelValues("thread").Inc()
+		}
+	}()
+
 	body, err := json.Marshal(map[string]any{
 		"channel":   p.channel,
 		"thread_ts": threadTS,
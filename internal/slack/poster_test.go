@@ -10,7 +10,9 @@ import (
 	"testing"
 
 	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/MikeSquared-Agency/dredd/internal/extractor"
+	"github.com/MikeSquared-Agency/dredd/internal/metrics"
 )
 
 func TestFormatReviewMessage_WithDecisionsAndPatterns(t *testing.T) {
@@ -239,10 +241,16 @@ func TestPostReviewSummary_SlackError(t *testing.T) {
 		OwnerUUID:  uuid.New(),
 	}
 
+	failures := metrics.SlackPostFailures.WithLabelValues("message")
+	before := testutil.ToFloat64(failures)
+
 	_, err := p.PostReviewSummary(context.Background(), result, "Test", "cc", "1m")
 	if err == nil {
 		t.Fatal("expected error for slack error response")
 	}
+	if got := testutil.ToFloat64(failures) - before; got != 1 {
+		t.Errorf("expected 1 recorded post failure, got %v", got)
+	}
 }
 
 func containsStr(s, substr string) bool {
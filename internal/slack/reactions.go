@@ -4,6 +4,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"log/slog"
+
+	"github.com/MikeSquared-Agency/dredd/internal/events"
 )
 
 // ReactionEvent is the structure received from slack-forwarder via NATS.
@@ -41,9 +43,7 @@ func ParseReaction(reaction string) ReviewVerdict {
 // ParseReactionEvent parses a NATS message payload from slack-forwarder into a ReactionEvent.
 func ParseReactionEvent(data []byte, logger *slog.Logger) (*ReactionEvent, error) {
 	// The slack-forwarder publishes events with metadata in a wrapper.
-	var wrapper struct {
-		Metadata map[string]string `json:"metadata"`
-	}
+	var wrapper events.SlackReaction
 	if err := json.Unmarshal(data, &wrapper); err != nil {
 		return nil, fmt.Errorf("parse reaction wrapper: %w", err)
 	}
@@ -0,0 +1,136 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AutonomyState is the stored autonomy mode for a category/severity pair.
+type AutonomyState struct {
+	Category  string    `json:"category"`
+	Severity  string    `json:"severity"`
+	Mode      string    `json:"mode"`
+	Pinned    bool      `json:"pinned"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// AutonomyTransition is a single recorded mode change.
+type AutonomyTransition struct {
+	ID             uuid.UUID `json:"id"`
+	Category       string    `json:"category"`
+	Severity       string    `json:"severity"`
+	FromMode       string    `json:"from_mode"`
+	ToMode         string    `json:"to_mode"`
+	Trigger        string    `json:"trigger"`
+	Actor          string    `json:"actor"`
+	Reason         string    `json:"reason,omitempty"`
+	TrustScore     float64   `json:"trust_score"`
+	TotalDecisions int       `json:"total_decisions"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+// GetAutonomyState fetches the autonomy mode for a category/severity pair.
+// Returns pgx.ErrNoRows if no mode has been recorded yet.
+func (s *Store) GetAutonomyState(ctx context.Context, category, severity string) (*AutonomyState, error) {
+	row := s.pool.QueryRow(ctx, `
+		SELECT category, severity, mode, pinned, updated_at
+		FROM autonomy_modes
+		WHERE category = $1 AND severity = $2`,
+		category, severity,
+	)
+
+	var a AutonomyState
+	if err := row.Scan(&a.Category, &a.Severity, &a.Mode, &a.Pinned, &a.UpdatedAt); err != nil {
+		return nil, err
+	}
+	return &a, nil
+}
+
+// ListAutonomyStates returns every stored autonomy mode.
+func (s *Store) ListAutonomyStates(ctx context.Context) ([]AutonomyState, error) {
+	rows, err := s.pool.Query(ctx, `
+		SELECT category, severity, mode, pinned, updated_at
+		FROM autonomy_modes
+		ORDER BY category, severity`)
+	if err != nil {
+		return nil, fmt.Errorf("query autonomy modes: %w", err)
+	}
+	defer rows.Close()
+
+	var states []AutonomyState
+	for rows.Next() {
+		var a AutonomyState
+		if err := rows.Scan(&a.Category, &a.Severity, &a.Mode, &a.Pinned, &a.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("scan autonomy mode: %w", err)
+		}
+		states = append(states, a)
+	}
+	return states, rows.Err()
+}
+
+// RecordAutonomyTransition stores the new mode for the transition's category/severity
+// and appends the transition to the audit log in a single transaction.
+func (s *Store) RecordAutonomyTransition(ctx context.Context, t AutonomyTransition, pinned bool) error {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO autonomy_modes (id, category, severity, mode, pinned, updated_at)
+		VALUES ($1, $2, $3, $4, $5, now())
+		ON CONFLICT (category, severity)
+		DO UPDATE SET mode = $4, pinned = $5, updated_at = now()`,
+		uuid.New(), t.Category, t.Severity, t.ToMode, pinned,
+	)
+	if err != nil {
+		return fmt.Errorf("upsert autonomy mode: %w", err)
+	}
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO autonomy_transitions (id, category, severity, from_mode, to_mode, trigger, actor, reason, trust_score, total_decisions, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)`,
+		t.ID, t.Category, t.Severity, t.FromMode, t.ToMode, t.Trigger, t.Actor, t.Reason, t.TrustScore, t.TotalDecisions, t.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("insert autonomy transition: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit: %w", err)
+	}
+	return nil
+}
+
+// ListAutonomyTransitions returns recent transitions, newest first.
+// Empty category or severity matches all values.
+func (s *Store) ListAutonomyTransitions(ctx context.Context, category, severity string, limit int) ([]AutonomyTransition, error) {
+	rows, err := s.pool.Query(ctx, `
+		SELECT id, category, severity, from_mode, to_mode, trigger, actor, coalesce(reason, ''),
+		       coalesce(trust_score, 0), coalesce(total_decisions, 0), created_at
+		FROM autonomy_transitions
+		WHERE ($1 = '' OR category = $1) AND ($2 = '' OR severity = $2)
+		ORDER BY created_at DESC
+		LIMIT $3`,
+		category, severity, limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("query autonomy transitions: %w", err)
+	}
+	defer rows.Close()
+
+	var transitions []AutonomyTransition
+	for rows.Next() {
+		var t AutonomyTransition
+		if err := rows.Scan(&t.ID, &t.Category, &t.Severity, &t.FromMode, &t.ToMode, &t.Trigger, &t.Actor, &t.Reason,
+			&t.TrustScore, &t.TotalDecisions, &t.CreatedAt); err != nil {
+			return nil, fmt.Errorf("scan autonomy transition: %w", err)
+		}
+		transitions = append(transitions, t)
+	}
+	return transitions, rows.Err()
+}
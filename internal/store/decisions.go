@@ -3,19 +3,45 @@ package store
 import (
 	"context"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/MikeSquared-Agency/dredd/internal/extractor"
 )
 
 // WriteOpts holds optional parameters for store write operations.
 type WriteOpts struct {
-	// Embedding is an optional vector embedding. If non-nil, it will be included in the INSERT.
-	Embedding []float64
+	// Embedding is an optional vector embedding, stored as NULL when nil.
+	Embedding []float32
+	// MatchThreshold, when above zero and Embedding is set, checks the nearest
+	// live record first: at or above this cosine similarity the write is
+	// recorded as another occurrence of that record instead of inserted, and
+	// the record's ID is returned.
+	MatchThreshold float64
+	// Matched, when non-nil, is set to whether the write was recorded as an
+	// occurrence of an existing record.
+	Matched *bool
+}
+
+// matching reports whether the write should look for an existing record.
+func (o WriteOpts) matching() bool {
+	return o.MatchThreshold > 0 && o.Embedding != nil
+}
+
+// setMatched reports the outcome of the write to the caller, if asked.
+func (o WriteOpts) setMatched(matched bool) {
+	if o.Matched != nil {
+		*o.Matched = matched
+	}
 }
 
 // WriteDecisionEpisode writes a full decision episode across the Decision Engine tables.
 // Tables: decisions, decision_context, decision_options, decision_reasoning, decision_tags.
+// With WriteOpts.MatchThreshold, a recurrence of one of the owner's decisions
+// in the same domain and category only counts an occurrence and adds the
+// session to it.
 func (s *Store) WriteDecisionEpisode(ctx context.Context, ownerUUID uuid.UUID, sessionRef, source string, ep extractor.DecisionEpisode, opts ...WriteOpts) (uuid.UUID, error) {
 	tx, err := s.pool.Begin(ctx)
 	if err != nil {
@@ -28,22 +54,37 @@ func (s *Store) WriteDecisionEpisode(ctx context.Context, ownerUUID uuid.UUID, s
 	if len(opts) > 0 {
 		opt = opts[0]
 	}
+	opt.setMatched(false)
+
+	if opt.matching() {
+		id, ok, err := findOccurrence(ctx, tx, occurrenceTarget{
+			table:  "decisions",
+			column: "embedding",
+			filter: "decided_by = $2 AND domain = $3 AND category = $4",
+			args:   []any{ownerUUID, ep.Domain, ep.Category},
+		}, opt.Embedding, opt.MatchThreshold)
+		if err != nil {
+			return uuid.Nil, err
+		}
+		if ok {
+			if err := recordOccurrence(ctx, tx, "decisions", id, sessionRef, ""); err != nil {
+				return uuid.Nil, err
+			}
+			if err := tx.Commit(ctx); err != nil {
+				return uuid.Nil, fmt.Errorf("commit: %w", err)
+			}
+			opt.setMatched(true)
+			return id, nil
+		}
+	}
 
 	// 1. Insert decision
 	decisionID := uuid.New()
-	if opt.Embedding != nil {
-		_, err = tx.Exec(ctx, `
-			INSERT INTO decisions (id, domain, category, severity, source, decided_by, summary, session_ref, embedding, model_id, model_tier, created_at)
-			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, now())`,
-			decisionID, ep.Domain, ep.Category, ep.Severity, source, ownerUUID.String(), ep.Summary, sessionRef, pgVector(opt.Embedding), ep.ModelID, ep.ModelTier,
-		)
-	} else {
-		_, err = tx.Exec(ctx, `
-			INSERT INTO decisions (id, domain, category, severity, source, decided_by, summary, session_ref, model_id, model_tier, created_at)
-			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, now())`,
-			decisionID, ep.Domain, ep.Category, ep.Severity, source, ownerUUID.String(), ep.Summary, sessionRef, ep.ModelID, ep.ModelTier,
-		)
-	}
+	_, err = tx.Exec(ctx, `
+		INSERT INTO decisions (id, domain, category, severity, source, decided_by, summary, session_ref, embedding, model_id, model_tier, agent_id, signal_type, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, now())`,
+		decisionID, ep.Domain, ep.Category, ep.Severity, source, ownerUUID.String(), ep.Summary, sessionRef, opt.Embedding, ep.ModelID, ep.ModelTier, ep.AgentID, ep.SignalType,
+	)
 	if err != nil {
 		return uuid.Nil, fmt.Errorf("insert decision: %w", err)
 	}
@@ -108,3 +149,327 @@ func (s *Store) UpdateDecisionReviewStatus(ctx context.Context, decisionID uuid.
 	)
 	return err
 }
+
+// DecisionDetail is a decision joined with its context, options, reasoning,
+// tags, and outcomes from the Decision Engine tables.
+type DecisionDetail struct {
+	ID           uuid.UUID             `json:"id"`
+	Domain       string                `json:"domain"`
+	Category     string                `json:"category"`
+	Severity     string                `json:"severity"`
+	Source       string                `json:"source"`
+	DecidedBy    uuid.UUID             `json:"decided_by"`
+	Summary      string                `json:"summary"`
+	SessionRef   string                `json:"session_ref"`
+	ReviewStatus string                `json:"review_status"`
+	ReviewNote   string                `json:"review_note,omitempty"`
+	ReviewedAt   *time.Time            `json:"reviewed_at,omitempty"`
+	ModelID      string                `json:"model_id,omitempty"`
+	ModelTier    string                `json:"model_tier,omitempty"`
+	AgentID      string                `json:"agent_id,omitempty"`
+	SignalType   string                `json:"signal_type,omitempty"`
+	CreatedAt    time.Time             `json:"created_at"`
+	Occurrences  int                   `json:"occurrence_count"`       // times seen: itself, recurrences at write time and records merged in by dedup
+	SessionRefs  []string              `json:"session_refs,omitempty"` // sessions it was seen in, once it has recurred or been merged
+	Situation    string                `json:"situation"`
+	Options      []DecisionOptionRow   `json:"options"`
+	Reasoning    *DecisionReasoningRow `json:"reasoning,omitempty"`
+	Tags         []string              `json:"tags"`
+	Outcomes     []DecisionOutcomeRow  `json:"outcomes"`
+	Similarity   float64               `json:"similarity,omitempty"` // set by vector searches
+}
+
+// DecisionOptionRow is an alternative considered for a decision.
+type DecisionOptionRow struct {
+	OptionKey  string   `json:"option_key"`
+	ProSignals []string `json:"pro_signals"`
+	ConSignals []string `json:"con_signals"`
+	WasChosen  bool     `json:"was_chosen"`
+}
+
+// DecisionReasoningRow captures why a decision was made.
+type DecisionReasoningRow struct {
+	Factors       []string `json:"factors"`
+	Tradeoffs     []string `json:"tradeoffs"`
+	ReasoningText string   `json:"reasoning_text"`
+}
+
+// DecisionOutcomeRow records how a decision turned out.
+type DecisionOutcomeRow struct {
+	OutcomeText    string     `json:"outcome_text"`
+	OutcomeQuality string     `json:"outcome_quality,omitempty"` // positive | negative | neutral
+	MeasuredAt     *time.Time `json:"measured_at,omitempty"`
+}
+
+// GetDecisionDetail fetches a single decision with all its child rows.
+// Returns pgx.ErrNoRows if the decision does not exist.
+func (s *Store) GetDecisionDetail(ctx context.Context, id uuid.UUID) (*DecisionDetail, error) {
+	details, err := s.GetDecisionDetails(ctx, []uuid.UUID{id})
+	if err != nil {
+		return nil, err
+	}
+	if len(details) == 0 {
+		return nil, pgx.ErrNoRows
+	}
+	return &details[0], nil
+}
+
+// GetDecisionDetails fetches decisions with all their child rows, preserving
+// the order of ids. IDs that do not exist are skipped.
+func (s *Store) GetDecisionDetails(ctx context.Context, ids []uuid.UUID) ([]DecisionDetail, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	byID := make(map[uuid.UUID]*DecisionDetail, len(ids))
+
+	// 1. Decisions with their situation text
+	rows, err := s.pool.Query(ctx, `
+		SELECT d.id, d.domain, d.category, d.severity, d.source, d.decided_by, d.summary,
+		       coalesce(d.session_ref, ''), coalesce(d.review_status, 'pending'), coalesce(d.review_note, ''), d.reviewed_at,
+		       coalesce(d.model_id, ''), coalesce(d.model_tier, ''), coalesce(d.agent_id, ''), coalesce(d.signal_type, ''), d.created_at,
+		       d.occurrence_count, d.session_refs,
+		       coalesce((SELECT c.situation_text FROM decision_context c WHERE c.decision_id = d.id ORDER BY c.created_at LIMIT 1), '')
+		FROM decisions d
+		WHERE d.id = ANY($1)`, ids)
+	if err != nil {
+		return nil, fmt.Errorf("query decisions: %w", err)
+	}
+	for rows.Next() {
+		d := &DecisionDetail{Options: []DecisionOptionRow{}, Tags: []string{}, Outcomes: []DecisionOutcomeRow{}}
+		if err := rows.Scan(&d.ID, &d.Domain, &d.Category, &d.Severity, &d.Source, &d.DecidedBy, &d.Summary,
+			&d.SessionRef, &d.ReviewStatus, &d.ReviewNote, &d.ReviewedAt,
+			&d.ModelID, &d.ModelTier, &d.AgentID, &d.SignalType, &d.CreatedAt,
+			&d.Occurrences, &d.SessionRefs, &d.Situation); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("scan decision: %w", err)
+		}
+		byID[d.ID] = d
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate decisions: %w", err)
+	}
+
+	// 2. Options
+	rows, err = s.pool.Query(ctx, `
+		SELECT decision_id, option_key, coalesce(pro_signals, '{}'), coalesce(con_signals, '{}'), was_chosen
+		FROM decision_options
+		WHERE decision_id = ANY($1)
+		ORDER BY created_at`, ids)
+	if err != nil {
+		return nil, fmt.Errorf("query options: %w", err)
+	}
+	for rows.Next() {
+		var decisionID uuid.UUID
+		var o DecisionOptionRow
+		if err := rows.Scan(&decisionID, &o.OptionKey, &o.ProSignals, &o.ConSignals, &o.WasChosen); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("scan option: %w", err)
+		}
+		if d, ok := byID[decisionID]; ok {
+			d.Options = append(d.Options, o)
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate options: %w", err)
+	}
+
+	// 3. Reasoning
+	rows, err = s.pool.Query(ctx, `
+		SELECT decision_id, coalesce(factors, '{}'), coalesce(tradeoffs, '{}'), reasoning_text
+		FROM decision_reasoning
+		WHERE decision_id = ANY($1)
+		ORDER BY created_at`, ids)
+	if err != nil {
+		return nil, fmt.Errorf("query reasoning: %w", err)
+	}
+	for rows.Next() {
+		var decisionID uuid.UUID
+		var r DecisionReasoningRow
+		if err := rows.Scan(&decisionID, &r.Factors, &r.Tradeoffs, &r.ReasoningText); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("scan reasoning: %w", err)
+		}
+		if d, ok := byID[decisionID]; ok && d.Reasoning == nil {
+			d.Reasoning = &r
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate reasoning: %w", err)
+	}
+
+	// 4. Tags
+	rows, err = s.pool.Query(ctx, `
+		SELECT decision_id, tag
+		FROM decision_tags
+		WHERE decision_id = ANY($1)
+		ORDER BY created_at`, ids)
+	if err != nil {
+		return nil, fmt.Errorf("query tags: %w", err)
+	}
+	for rows.Next() {
+		var decisionID uuid.UUID
+		var tag string
+		if err := rows.Scan(&decisionID, &tag); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("scan tag: %w", err)
+		}
+		if d, ok := byID[decisionID]; ok {
+			d.Tags = append(d.Tags, tag)
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate tags: %w", err)
+	}
+
+	// 5. Outcomes
+	rows, err = s.pool.Query(ctx, `
+		SELECT decision_id, outcome_text, coalesce(outcome_quality, ''), measured_at
+		FROM decision_outcomes
+		WHERE decision_id = ANY($1)
+		ORDER BY created_at`, ids)
+	if err != nil {
+		return nil, fmt.Errorf("query outcomes: %w", err)
+	}
+	for rows.Next() {
+		var decisionID uuid.UUID
+		var o DecisionOutcomeRow
+		if err := rows.Scan(&decisionID, &o.OutcomeText, &o.OutcomeQuality, &o.MeasuredAt); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("scan outcome: %w", err)
+		}
+		if d, ok := byID[decisionID]; ok {
+			d.Outcomes = append(d.Outcomes, o)
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate outcomes: %w", err)
+	}
+
+	details := make([]DecisionDetail, 0, len(byID))
+	for _, id := range ids {
+		if d, ok := byID[id]; ok {
+			details = append(details, *d)
+		}
+	}
+	return details, nil
+}
+
+// Cursor is a keyset position in a created_at DESC, id DESC listing.
+type Cursor struct {
+	CreatedAt time.Time
+	ID        uuid.UUID
+}
+
+// DecisionFilter narrows a decision listing. Zero values match everything.
+type DecisionFilter struct {
+	Domain         string
+	Category       string
+	Severity       string
+	ReviewStatus   string
+	Source         string
+	ModelID        string
+	Tag            string
+	Owner          uuid.UUID
+	Since          *time.Time // inclusive
+	Until          *time.Time // exclusive
+	IncludeDeduped bool
+	After          *Cursor // return rows strictly older than this position
+	Limit          int
+}
+
+// ListDecisions returns decisions newest first with their child rows, and a
+// cursor for the next page (nil when there are no more rows).
+func (s *Store) ListDecisions(ctx context.Context, f DecisionFilter) ([]DecisionDetail, *Cursor, error) {
+	if f.Limit <= 0 {
+		f.Limit = 50
+	}
+
+	var where []string
+	var args []any
+	arg := func(v any) string {
+		args = append(args, v)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	if !f.IncludeDeduped {
+		where = append(where, "d.deduped_at IS NULL")
+	}
+	if f.Domain != "" {
+		where = append(where, "d.domain = "+arg(f.Domain))
+	}
+	if f.Category != "" {
+		where = append(where, "d.category = "+arg(f.Category))
+	}
+	if f.Severity != "" {
+		where = append(where, "d.severity = "+arg(f.Severity))
+	}
+	if f.ReviewStatus != "" {
+		where = append(where, "coalesce(d.review_status, 'pending') = "+arg(f.ReviewStatus))
+	}
+	if f.Source != "" {
+		where = append(where, "d.source = "+arg(f.Source))
+	}
+	if f.ModelID != "" {
+		where = append(where, "d.model_id = "+arg(f.ModelID))
+	}
+	if f.Tag != "" {
+		where = append(where, "EXISTS (SELECT 1 FROM decision_tags t WHERE t.decision_id = d.id AND t.tag = "+arg(f.Tag)+")")
+	}
+	if f.Owner != uuid.Nil {
+		where = append(where, "d.decided_by = "+arg(f.Owner))
+	}
+	if f.Since != nil {
+		where = append(where, "d.created_at >= "+arg(*f.Since))
+	}
+	if f.Until != nil {
+		where = append(where, "d.created_at < "+arg(*f.Until))
+	}
+	if f.After != nil {
+		where = append(where, fmt.Sprintf("(d.created_at, d.id) < (%s, %s)", arg(f.After.CreatedAt), arg(f.After.ID)))
+	}
+
+	query := "SELECT d.id, d.created_at FROM decisions d"
+	if len(where) > 0 {
+		query += " WHERE " + strings.Join(where, " AND ")
+	}
+	// Fetch one extra row to learn whether another page exists.
+	query += " ORDER BY d.created_at DESC, d.id DESC LIMIT " + arg(f.Limit+1)
+
+	rows, err := s.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("list decisions: %w", err)
+	}
+	defer rows.Close()
+
+	var ids []uuid.UUID
+	var positions []Cursor
+	for rows.Next() {
+		var c Cursor
+		if err := rows.Scan(&c.ID, &c.CreatedAt); err != nil {
+			return nil, nil, fmt.Errorf("scan decision: %w", err)
+		}
+		ids = append(ids, c.ID)
+		positions = append(positions, c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, fmt.Errorf("iterate decisions: %w", err)
+	}
+
+	var next *Cursor
+	if len(ids) > f.Limit {
+		ids = ids[:f.Limit]
+		next = &positions[f.Limit-1]
+	}
+
+	details, err := s.GetDecisionDetails(ctx, ids)
+	if err != nil {
+		return nil, nil, err
+	}
+	return details, next, nil
+}
@@ -2,7 +2,9 @@ package store
 
 import (
 	"context"
+	"fmt"
 
+	"github.com/google/uuid"
 	"github.com/MikeSquared-Agency/dredd/internal/dedup"
 	"log/slog"
 )
@@ -13,13 +15,68 @@ func (s *Store) GetDeduplicator(logger *slog.Logger) *dedup.Deduplicator {
 }
 
 // DeduplicateReasoningPatterns performs deduplication on reasoning patterns.
-func (s *Store) DeduplicateReasoningPatterns(ctx context.Context, threshold float64, execute bool, logger *slog.Logger) (*dedup.DeduResult, error) {
+func (s *Store) DeduplicateReasoningPatterns(ctx context.Context, opts dedup.Options, logger *slog.Logger) (*dedup.DeduResult, error) {
 	deduper := s.GetDeduplicator(logger)
-	return deduper.DeduplicateReasoningPatterns(ctx, threshold, execute)
+	return deduper.DeduplicateReasoningPatterns(ctx, opts)
 }
 
 // DeduplicateDecisions performs deduplication on decisions.
-func (s *Store) DeduplicateDecisions(ctx context.Context, threshold float64, execute bool, logger *slog.Logger) (*dedup.DeduResult, error) {
+func (s *Store) DeduplicateDecisions(ctx context.Context, opts dedup.Options, logger *slog.Logger) (*dedup.DeduResult, error) {
 	deduper := s.GetDeduplicator(logger)
-	return deduper.DeduplicateDecisions(ctx, threshold, execute)
+	return deduper.DeduplicateDecisions(ctx, opts)
+}
+
+// Deduplicate runs deduplication on table ("patterns", "decisions" or "all"),
+// reporting progress to the optional progress callback. Results are returned
+// in table order.
+func (s *Store) Deduplicate(ctx context.Context, table string, opts dedup.Options, logger *slog.Logger, progress dedup.ProgressFunc) ([]*dedup.DeduResult, error) {
+	var results []*dedup.DeduResult
+
+	if table == "patterns" || table == "all" {
+		result, err := s.GetDeduplicator(logger).WithProgress(progress).DeduplicateReasoningPatterns(ctx, opts)
+		if err != nil {
+			return results, fmt.Errorf("deduplicate reasoning patterns: %w", err)
+		}
+		results = append(results, result)
+	}
+
+	if table == "decisions" || table == "all" {
+		result, err := s.GetDeduplicator(logger).WithProgress(progress).DeduplicateDecisions(ctx, opts)
+		if err != nil {
+			return results, fmt.Errorf("deduplicate decisions: %w", err)
+		}
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+// ListDedupRuns returns executed dedup runs, newest first.
+func (s *Store) ListDedupRuns(ctx context.Context, limit int) ([]dedup.Run, error) {
+	return s.GetDeduplicator(slog.Default()).ListRuns(ctx, limit)
+}
+
+// GetDedupRun returns a dedup run with its clusters.
+func (s *Store) GetDedupRun(ctx context.Context, id uuid.UUID) (*dedup.Run, error) {
+	return s.GetDeduplicator(slog.Default()).GetRun(ctx, id)
+}
+
+// RestoreDedupRun reverses every merge made by a dedup run.
+func (s *Store) RestoreDedupRun(ctx context.Context, id uuid.UUID, logger *slog.Logger) (*dedup.RestoreResult, error) {
+	return s.GetDeduplicator(logger).RestoreRun(ctx, id)
+}
+
+// RestoreDedupRecord reverses the most recent merge involving a record.
+func (s *Store) RestoreDedupRecord(ctx context.Context, id uuid.UUID, logger *slog.Logger) (*dedup.RestoreResult, error) {
+	return s.GetDeduplicator(logger).RestoreRecord(ctx, id)
+}
+
+// VectorIndexes returns the vector indexes on a dedup table's embedding column.
+func (s *Store) VectorIndexes(ctx context.Context, table string) ([]dedup.VectorIndex, error) {
+	return s.GetDeduplicator(slog.Default()).ListIndexes(ctx, table)
+}
+
+// CreateVectorIndex builds a vector index on a dedup table's embedding column.
+func (s *Store) CreateVectorIndex(ctx context.Context, table string, opts dedup.IndexOptions, logger *slog.Logger) (*dedup.VectorIndex, error) {
+	return s.GetDeduplicator(logger).CreateIndex(ctx, table, opts)
 }
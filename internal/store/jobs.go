@@ -0,0 +1,127 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/MikeSquared-Agency/dredd/internal/jobs"
+)
+
+// CreateJob inserts a new background job, leased to the calling process.
+func (s *Store) CreateJob(ctx context.Context, j jobs.Job) error {
+	_, err := s.pool.Exec(ctx, `
+		INSERT INTO jobs (id, kind, status, params, created_at, lease_expires_at)
+		VALUES ($1, $2, $3, $4, $5, now() + make_interval(secs => $6))`,
+		j.ID, j.Kind, j.Status, nullJSON(j.Params), j.CreatedAt, jobs.Lease.Seconds(),
+	)
+	if err != nil {
+		return fmt.Errorf("insert job: %w", err)
+	}
+	return nil
+}
+
+// UpdateJob writes the status, progress and outcome of a job.
+func (s *Store) UpdateJob(ctx context.Context, j jobs.Job) error {
+	var progress []byte
+	if j.Progress != nil {
+		var err error
+		if progress, err = json.Marshal(j.Progress); err != nil {
+			return fmt.Errorf("marshal progress: %w", err)
+		}
+	}
+	_, err := s.pool.Exec(ctx, `
+		UPDATE jobs SET status = $1, progress = $2, result = $3, error = nullif($4, ''),
+			started_at = $5, finished_at = $6
+		WHERE id = $7`,
+		j.Status, nullJSON(progress), nullJSON(j.Result), j.Error, j.StartedAt, j.FinishedAt, j.ID,
+	)
+	if err != nil {
+		return fmt.Errorf("update job: %w", err)
+	}
+	return nil
+}
+
+// GetJob fetches a job by ID.
+func (s *Store) GetJob(ctx context.Context, id uuid.UUID) (*jobs.Job, error) {
+	var j jobs.Job
+	var params, progress, result []byte
+	err := s.pool.QueryRow(ctx, `
+		SELECT id, kind, status, params, progress, result, coalesce(error, ''), created_at, started_at, finished_at
+		FROM jobs WHERE id = $1`, id,
+	).Scan(&j.ID, &j.Kind, &j.Status, &params, &progress, &result, &j.Error, &j.CreatedAt, &j.StartedAt, &j.FinishedAt)
+	if err != nil {
+		return nil, err
+	}
+	j.Params = params
+	j.Result = result
+	if progress != nil {
+		j.Progress = &jobs.Progress{}
+		if err := json.Unmarshal(progress, j.Progress); err != nil {
+			return nil, fmt.Errorf("unmarshal progress: %w", err)
+		}
+	}
+	return &j, nil
+}
+
+// RenewJobs extends the leases of jobs still running in the calling process,
+// and returns those another process has asked to cancel.
+func (s *Store) RenewJobs(ctx context.Context, ids []uuid.UUID) ([]uuid.UUID, error) {
+	rows, err := s.pool.Query(ctx, `
+		UPDATE jobs SET lease_expires_at = now() + make_interval(secs => $2)
+		WHERE id = ANY($1) AND status IN ('queued', 'running')
+		RETURNING id, cancel_requested`,
+		ids, jobs.Lease.Seconds(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("renew jobs: %w", err)
+	}
+	defer rows.Close()
+
+	var cancel []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		var requested bool
+		if err := rows.Scan(&id, &requested); err != nil {
+			return nil, fmt.Errorf("scan job: %w", err)
+		}
+		if requested {
+			cancel = append(cancel, id)
+		}
+	}
+	return cancel, rows.Err()
+}
+
+// RequestJobCancel asks the process running a job to cancel it at its next
+// lease renewal. It reports false if the job is not running under a live lease.
+func (s *Store) RequestJobCancel(ctx context.Context, id uuid.UUID) (bool, error) {
+	tag, err := s.pool.Exec(ctx, `
+		UPDATE jobs SET cancel_requested = true
+		WHERE id = $1 AND status IN ('queued', 'running') AND lease_expires_at >= now()`, id)
+	if err != nil {
+		return false, fmt.Errorf("request job cancel: %w", err)
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+// AbandonJobs marks queued or running jobs whose lease has expired as failed:
+// the process that ran them has gone without recording how they ended. Jobs
+// leased by live processes, this one or other replicas, are left alone.
+func (s *Store) AbandonJobs(ctx context.Context) (int64, error) {
+	tag, err := s.pool.Exec(ctx, `
+		UPDATE jobs SET status = 'failed', error = 'interrupted: lease expired', finished_at = now()
+		WHERE status IN ('queued', 'running') AND (lease_expires_at IS NULL OR lease_expires_at < now())`)
+	if err != nil {
+		return 0, fmt.Errorf("abandon jobs: %w", err)
+	}
+	return tag.RowsAffected(), nil
+}
+
+// nullJSON maps empty JSON to SQL NULL.
+func nullJSON(b []byte) any {
+	if len(b) == 0 {
+		return nil
+	}
+	return string(b)
+}
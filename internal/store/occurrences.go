@@ -0,0 +1,82 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// occurrenceProbes is the number of ivfflat lists searched by a write-time
+// neighbour lookup; see the dedup scanner for the batch equivalent.
+const occurrenceProbes = 10
+
+// occurrenceTarget describes where to look for an existing record that a new
+// extraction recurs. filter restricts the candidates, with its parameters
+// numbered from $2 ($1 is the embedding).
+type occurrenceTarget struct {
+	table  string
+	column string
+	filter string
+	args   []any
+}
+
+// findOccurrence returns the live record of the target nearest to vec, if its
+// cosine similarity is at least threshold. The record is locked for the rest
+// of tx so a concurrent write cannot lose its occurrence. Rejected records are
+// never matched, so an extraction like one a reviewer rejected is reviewed
+// afresh.
+func findOccurrence(ctx context.Context, tx pgx.Tx, t occurrenceTarget, vec []float32, threshold float64) (uuid.UUID, bool, error) {
+	if _, err := tx.Exec(ctx, `SELECT set_config('ivfflat.probes', $1, true)`, fmt.Sprint(occurrenceProbes)); err != nil {
+		return uuid.Nil, false, fmt.Errorf("tune index search: %w", err)
+	}
+
+	where := t.column + ` IS NOT NULL AND deduped_at IS NULL AND review_status IS DISTINCT FROM 'rejected'`
+	if t.filter != "" {
+		where += ` AND ` + t.filter
+	}
+	var id uuid.UUID
+	var similarity float64
+	err := tx.QueryRow(ctx, `
+		SELECT id, 1 - (`+t.column+` <=> $1) FROM `+t.table+`
+		WHERE `+where+`
+		ORDER BY `+t.column+` <=> $1
+		LIMIT 1
+		FOR UPDATE`,
+		append([]any{vec}, t.args...)...,
+	).Scan(&id, &similarity)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return uuid.Nil, false, nil
+	}
+	if err != nil {
+		return uuid.Nil, false, fmt.Errorf("find nearest %s: %w", t.table, err)
+	}
+	if similarity < threshold {
+		return uuid.Nil, false, nil
+	}
+	return id, true, nil
+}
+
+// recordOccurrence counts another occurrence of an existing record and adds
+// the session it recurred in. extra is appended to the SET clause, with its
+// parameters numbered from $3.
+func recordOccurrence(ctx context.Context, tx pgx.Tx, table string, id uuid.UUID, sessionRef, extra string, args ...any) error {
+	// As when dedup merges records, one that has not recurred yet stands for
+	// its own session.
+	set := `
+		occurrence_count = occurrence_count + 1,
+		session_refs = array(
+			SELECT DISTINCT ref
+			FROM unnest(CASE WHEN cardinality(session_refs) > 0 THEN session_refs ELSE array[session_ref] END || $2::text) ref
+			WHERE coalesce(ref, '') <> ''
+			ORDER BY ref)`
+	if extra != "" {
+		set += `, ` + extra
+	}
+	if _, err := tx.Exec(ctx, `UPDATE `+table+` SET `+set+` WHERE id = $1`, append([]any{id, sessionRef}, args...)...); err != nil {
+		return fmt.Errorf("record %s occurrence: %w", table, err)
+	}
+	return nil
+}
@@ -3,38 +3,65 @@ package store
 import (
 	"context"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/MikeSquared-Agency/dredd/internal/extractor"
 )
 
-// WriteReasoningPattern inserts a reasoning pattern extraction.
+// WriteReasoningPattern inserts a reasoning pattern extraction. With
+// WriteOpts.MatchThreshold, a recurrence of one of the owner's patterns of the
+// same type instead counts an occurrence, adds the session, and keeps the
+// higher confidence.
 func (s *Store) WriteReasoningPattern(ctx context.Context, ownerUUID uuid.UUID, sessionRef string, p extractor.ReasoningPattern, opts ...WriteOpts) (uuid.UUID, error) {
 	var opt WriteOpts
 	if len(opts) > 0 {
 		opt = opts[0]
 	}
+	opt.setMatched(false)
 
-	id := uuid.New()
-	if opt.Embedding != nil {
-		_, err := s.pool.Exec(ctx, `
-			INSERT INTO reasoning_patterns (id, owner_uuid, session_ref, pattern_type, summary, conversation_arc, tags, dredd_confidence, embedding, review_status)
-			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, 'pending')`,
-			id, ownerUUID, sessionRef, p.PatternType, p.Summary, p.ConversationArc, p.Tags, p.Confidence, pgVector(opt.Embedding),
-		)
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("begin tx: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	if opt.matching() {
+		id, ok, err := findOccurrence(ctx, tx, occurrenceTarget{
+			table:  "reasoning_patterns",
+			column: "arc_embedding",
+			filter: "owner_uuid = $2 AND pattern_type = $3",
+			args:   []any{ownerUUID, p.PatternType},
+		}, opt.Embedding, opt.MatchThreshold)
 		if err != nil {
-			return uuid.Nil, fmt.Errorf("insert reasoning pattern: %w", err)
+			return uuid.Nil, err
 		}
-	} else {
-		_, err := s.pool.Exec(ctx, `
-			INSERT INTO reasoning_patterns (id, owner_uuid, session_ref, pattern_type, summary, conversation_arc, tags, dredd_confidence, review_status)
-			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, 'pending')`,
-			id, ownerUUID, sessionRef, p.PatternType, p.Summary, p.ConversationArc, p.Tags, p.Confidence,
-		)
-		if err != nil {
-			return uuid.Nil, fmt.Errorf("insert reasoning pattern: %w", err)
+		if ok {
+			if err := recordOccurrence(ctx, tx, "reasoning_patterns", id, sessionRef, "dredd_confidence = greatest(dredd_confidence, $3)", p.Confidence); err != nil {
+				return uuid.Nil, err
+			}
+			if err := tx.Commit(ctx); err != nil {
+				return uuid.Nil, fmt.Errorf("commit: %w", err)
+			}
+			opt.setMatched(true)
+			return id, nil
 		}
 	}
+
+	id := uuid.New()
+	_, err = tx.Exec(ctx, `
+		INSERT INTO reasoning_patterns (id, owner_uuid, session_ref, pattern_type, summary, conversation_arc, tags, dredd_confidence, arc_embedding, review_status)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, 'pending')`,
+		id, ownerUUID, sessionRef, p.PatternType, p.Summary, p.ConversationArc, p.Tags, p.Confidence, opt.Embedding,
+	)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("insert reasoning pattern: %w", err)
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return uuid.Nil, fmt.Errorf("commit: %w", err)
+	}
 	return id, nil
 }
 
@@ -48,28 +75,226 @@ func (s *Store) UpdatePatternReviewStatus(ctx context.Context, patternID uuid.UU
 	return err
 }
 
+// patternColumns is the column list scanned by scanPattern.
+const patternColumns = `id, owner_uuid, session_ref, pattern_type, summary, conversation_arc, coalesce(tags, '{}'),
+	dredd_confidence, coalesce(review_status, 'pending'), coalesce(review_note, ''), reviewed_at, created_at,
+	occurrence_count, session_refs`
+
 // GetPatternByID fetches a reasoning pattern by ID.
 func (s *Store) GetPatternByID(ctx context.Context, id uuid.UUID) (*PatternRow, error) {
 	row := s.pool.QueryRow(ctx, `
-		SELECT id, owner_uuid, session_ref, pattern_type, summary, conversation_arc, tags, dredd_confidence, review_status
+		SELECT `+patternColumns+`
 		FROM reasoning_patterns WHERE id = $1`, id)
+	return scanPattern(row)
+}
 
+// scanPattern scans patternColumns, followed by any extra selected columns.
+func scanPattern(row pgx.Row, extra ...any) (*PatternRow, error) {
 	var p PatternRow
-	err := row.Scan(&p.ID, &p.OwnerUUID, &p.SessionRef, &p.PatternType, &p.Summary, &p.ConversationArc, &p.Tags, &p.Confidence, &p.ReviewStatus)
-	if err != nil {
+	dest := []any{&p.ID, &p.OwnerUUID, &p.SessionRef, &p.PatternType, &p.Summary, &p.ConversationArc, &p.Tags,
+		&p.Confidence, &p.ReviewStatus, &p.ReviewNote, &p.ReviewedAt, &p.CreatedAt, &p.Occurrences, &p.SessionRefs}
+	if err := row.Scan(append(dest, extra...)...); err != nil {
 		return nil, err
 	}
 	return &p, nil
 }
 
+// PatternRow is a stored reasoning pattern.
 type PatternRow struct {
-	ID              uuid.UUID
-	OwnerUUID       uuid.UUID
-	SessionRef      string
-	PatternType     string
-	Summary         string
-	ConversationArc string
-	Tags            []string
-	Confidence      float64
-	ReviewStatus    string
+	ID              uuid.UUID  `json:"id"`
+	OwnerUUID       uuid.UUID  `json:"owner_uuid"`
+	SessionRef      string     `json:"session_ref"`
+	PatternType     string     `json:"pattern_type"`
+	Summary         string     `json:"summary"`
+	ConversationArc string     `json:"conversation_arc"`
+	Tags            []string   `json:"tags"`
+	Confidence      float64    `json:"confidence"`
+	ReviewStatus    string     `json:"review_status"`
+	ReviewNote      string     `json:"review_note,omitempty"`
+	ReviewedAt      *time.Time `json:"reviewed_at,omitempty"`
+	CreatedAt       time.Time  `json:"created_at"`
+	Occurrences     int        `json:"occurrence_count"`       // times seen: itself, recurrences at write time and records merged in by dedup
+	SessionRefs     []string   `json:"session_refs,omitempty"` // sessions it was seen in, once it has recurred or been merged
+	Similarity      float64    `json:"similarity,omitempty"`   // set by vector searches
+}
+
+// PatternFilter narrows a pattern listing or search. Zero values match everything.
+type PatternFilter struct {
+	PatternType    string
+	ReviewStatus   string
+	Tag            string
+	Owner          uuid.UUID
+	MinConfidence  *float64
+	MaxConfidence  *float64
+	Since          *time.Time // inclusive
+	Until          *time.Time // exclusive
+	IncludeDeduped bool
+	After          *Cursor // ListPatterns only
+	Limit          int
+}
+
+// conditions renders f as SQL predicates over reasoning_patterns, appending
+// bind values to args.
+func (f PatternFilter) conditions(args *[]any) []string {
+	arg := func(v any) string {
+		*args = append(*args, v)
+		return fmt.Sprintf("$%d", len(*args))
+	}
+
+	var where []string
+	if !f.IncludeDeduped {
+		where = append(where, "deduped_at IS NULL")
+	}
+	if f.PatternType != "" {
+		where = append(where, "pattern_type = "+arg(f.PatternType))
+	}
+	if f.ReviewStatus != "" {
+		where = append(where, "coalesce(review_status, 'pending') = "+arg(f.ReviewStatus))
+	}
+	if f.Tag != "" {
+		where = append(where, arg(f.Tag)+" = ANY(tags)")
+	}
+	if f.Owner != uuid.Nil {
+		where = append(where, "owner_uuid = "+arg(f.Owner))
+	}
+	if f.MinConfidence != nil {
+		where = append(where, "dredd_confidence >= "+arg(*f.MinConfidence))
+	}
+	if f.MaxConfidence != nil {
+		where = append(where, "dredd_confidence <= "+arg(*f.MaxConfidence))
+	}
+	if f.Since != nil {
+		where = append(where, "created_at >= "+arg(*f.Since))
+	}
+	if f.Until != nil {
+		where = append(where, "created_at < "+arg(*f.Until))
+	}
+	return where
+}
+
+// ListPatterns returns reasoning patterns newest first, and a cursor for the
+// next page (nil when there are no more rows).
+func (s *Store) ListPatterns(ctx context.Context, f PatternFilter) ([]PatternRow, *Cursor, error) {
+	if f.Limit <= 0 {
+		f.Limit = 50
+	}
+
+	var args []any
+	where := f.conditions(&args)
+	if f.After != nil {
+		args = append(args, f.After.CreatedAt, f.After.ID)
+		where = append(where, fmt.Sprintf("(created_at, id) < ($%d, $%d)", len(args)-1, len(args)))
+	}
+
+	query := "SELECT " + patternColumns + " FROM reasoning_patterns"
+	if len(where) > 0 {
+		query += " WHERE " + strings.Join(where, " AND ")
+	}
+	// Fetch one extra row to learn whether another page exists.
+	args = append(args, f.Limit+1)
+	query += fmt.Sprintf(" ORDER BY created_at DESC, id DESC LIMIT $%d", len(args))
+
+	rows, err := s.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("list patterns: %w", err)
+	}
+	defer rows.Close()
+
+	var patterns []PatternRow
+	for rows.Next() {
+		p, err := scanPattern(rows)
+		if err != nil {
+			return nil, nil, fmt.Errorf("scan pattern: %w", err)
+		}
+		patterns = append(patterns, *p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, fmt.Errorf("iterate patterns: %w", err)
+	}
+
+	var next *Cursor
+	if len(patterns) > f.Limit {
+		patterns = patterns[:f.Limit]
+		last := patterns[f.Limit-1]
+		next = &Cursor{CreatedAt: last.CreatedAt, ID: last.ID}
+	}
+	return patterns, next, nil
+}
+
+// SearchPatterns returns the patterns whose arc embedding is nearest to
+// embedding by cosine similarity, most similar first.
+func (s *Store) SearchPatterns(ctx context.Context, embedding []float32, minSimilarity float64, f PatternFilter) ([]PatternRow, error) {
+	if f.Limit <= 0 {
+		f.Limit = 10
+	}
+
+	args := []any{embedding}
+	where := append(f.conditions(&args), "arc_embedding IS NOT NULL")
+	args = append(args, minSimilarity)
+	where = append(where, fmt.Sprintf("1 - (arc_embedding <=> $1::vector) >= $%d", len(args)))
+	args = append(args, f.Limit)
+
+	rows, err := s.pool.Query(ctx, `
+		SELECT `+patternColumns+`, 1 - (arc_embedding <=> $1::vector) AS similarity
+		FROM reasoning_patterns
+		WHERE `+strings.Join(where, " AND ")+`
+		ORDER BY arc_embedding <=> $1::vector
+		LIMIT `+fmt.Sprintf("$%d", len(args)), args...)
+	if err != nil {
+		return nil, fmt.Errorf("search patterns: %w", err)
+	}
+	defer rows.Close()
+
+	var patterns []PatternRow
+	for rows.Next() {
+		var sim float64
+		p, err := scanPattern(rows, &sim)
+		if err != nil {
+			return nil, fmt.Errorf("scan pattern: %w", err)
+		}
+		p.Similarity = sim
+		patterns = append(patterns, *p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate patterns: %w", err)
+	}
+	return patterns, nil
+}
+
+// PatternEmbedding is a reasoning pattern with its arc embedding.
+type PatternEmbedding struct {
+	PatternRow
+	Embedding []float32
+}
+
+// PatternEmbeddings returns the patterns matching f that have an arc
+// embedding, oldest first. f.After and f.Limit are ignored: callers that
+// compare embeddings need the whole set.
+func (s *Store) PatternEmbeddings(ctx context.Context, f PatternFilter) ([]PatternEmbedding, error) {
+	var args []any
+	where := append(f.conditions(&args), "arc_embedding IS NOT NULL")
+
+	rows, err := s.pool.Query(ctx, `
+		SELECT `+patternColumns+`, arc_embedding
+		FROM reasoning_patterns
+		WHERE `+strings.Join(where, " AND ")+`
+		ORDER BY created_at, id`, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query pattern embeddings: %w", err)
+	}
+	defer rows.Close()
+
+	var patterns []PatternEmbedding
+	for rows.Next() {
+		var vec []float32
+		p, err := scanPattern(rows, &vec)
+		if err != nil {
+			return nil, fmt.Errorf("scan pattern embedding: %w", err)
+		}
+		patterns = append(patterns, PatternEmbedding{PatternRow: *p, Embedding: vec})
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate pattern embeddings: %w", err)
+	}
+	return patterns, nil
 }
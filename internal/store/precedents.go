@@ -0,0 +1,141 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// PrecedentFilter narrows a precedent search.
+type PrecedentFilter struct {
+	Domain        string
+	Category      string
+	Limit         int
+	MinSimilarity float64
+}
+
+const (
+	// precedentCandidates is how many nearest rows each per-column query
+	// fetches per requested precedent, since filters apply after the index
+	// scan and the merge drops decisions the other column ranks.
+	precedentCandidates = 4
+
+	// precedentProbes is the number of ivfflat lists a precedent search scans.
+	precedentProbes = 10
+)
+
+// SearchPrecedents returns the confirmed decisions nearest to embedding by
+// cosine similarity, using the situation embedding where one exists and the
+// decision embedding otherwise. Results are ordered most similar first.
+//
+// Each column is searched by its own nearest-neighbour query so it can use
+// that column's index; the candidates are merged here.
+func (s *Store) SearchPrecedents(ctx context.Context, embedding []float32, f PrecedentFilter) ([]DecisionDetail, error) {
+	if f.Limit <= 0 {
+		f.Limit = 5
+	}
+
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin precedent search: %w", err)
+	}
+	defer tx.Rollback(ctx)
+	if _, err := tx.Exec(ctx, `SELECT set_config('ivfflat.probes', $1, true)`, fmt.Sprint(precedentProbes)); err != nil {
+		return nil, fmt.Errorf("tune index search: %w", err)
+	}
+
+	args := []any{embedding, f.Domain, f.Category, f.Limit * precedentCandidates}
+	const confirmed = `
+		d.review_status = 'confirmed'
+		AND d.deduped_at IS NULL
+		AND ($2 = '' OR d.domain = $2)
+		AND ($3 = '' OR d.category = $3)`
+
+	// A decision's situation is the one on its earliest context that has one.
+	situations, err := nearestPrecedents(ctx, tx, "situation", `
+		SELECT c.decision_id, 1 - (c.situation_embedding <=> $1::vector)
+		FROM decision_context c
+		JOIN decisions d ON d.id = c.decision_id
+		WHERE c.situation_embedding IS NOT NULL
+		  AND NOT EXISTS (
+		      SELECT 1 FROM decision_context e
+		      WHERE e.decision_id = c.decision_id AND e.situation_embedding IS NOT NULL
+		        AND e.created_at < c.created_at)
+		  AND `+confirmed+`
+		ORDER BY c.situation_embedding <=> $1::vector
+		LIMIT $4`, args)
+	if err != nil {
+		return nil, err
+	}
+	decisions, err := nearestPrecedents(ctx, tx, "decision", `
+		SELECT d.id, 1 - (d.embedding <=> $1::vector)
+		FROM decisions d
+		WHERE d.embedding IS NOT NULL
+		  AND NOT EXISTS (
+		      SELECT 1 FROM decision_context c
+		      WHERE c.decision_id = d.id AND c.situation_embedding IS NOT NULL)
+		  AND `+confirmed+`
+		ORDER BY d.embedding <=> $1::vector
+		LIMIT $4`, args)
+	if err != nil {
+		return nil, err
+	}
+
+	similarity := make(map[uuid.UUID]float64)
+	var ids []uuid.UUID
+	for _, c := range append(situations, decisions...) {
+		if c.similarity < f.MinSimilarity {
+			continue
+		}
+		if prev, ok := similarity[c.id]; ok {
+			similarity[c.id] = max(prev, c.similarity)
+			continue
+		}
+		similarity[c.id] = c.similarity
+		ids = append(ids, c.id)
+	}
+	sort.SliceStable(ids, func(i, j int) bool { return similarity[ids[i]] > similarity[ids[j]] })
+	if len(ids) > f.Limit {
+		ids = ids[:f.Limit]
+	}
+
+	details, err := s.GetDecisionDetails(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	for i := range details {
+		details[i].Similarity = similarity[details[i].ID]
+	}
+	return details, nil
+}
+
+type precedentCandidate struct {
+	id         uuid.UUID
+	similarity float64
+}
+
+// nearestPrecedents runs one per-column nearest-neighbour query returning
+// (decision id, similarity) rows.
+func nearestPrecedents(ctx context.Context, tx pgx.Tx, column, query string, args []any) ([]precedentCandidate, error) {
+	rows, err := tx.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("search precedents by %s: %w", column, err)
+	}
+	defer rows.Close()
+
+	var candidates []precedentCandidate
+	for rows.Next() {
+		var c precedentCandidate
+		if err := rows.Scan(&c.id, &c.similarity); err != nil {
+			return nil, fmt.Errorf("scan precedent: %w", err)
+		}
+		candidates = append(candidates, c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate precedents: %w", err)
+	}
+	return candidates, nil
+}
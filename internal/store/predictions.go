@@ -0,0 +1,258 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// GatePrediction is a shadow-mode prediction of a gate verdict.
+type GatePrediction struct {
+	ID               uuid.UUID  `json:"id"`
+	ItemID           string     `json:"item_id"`
+	Stage            string     `json:"stage"`
+	Category         string     `json:"category"`
+	Evidence         string     `json:"evidence"`
+	PredictedVerdict string     `json:"predicted_verdict"`
+	Confidence       float64    `json:"confidence"`
+	Rationale        string     `json:"rationale"`
+	Neighbours       int        `json:"neighbours"`
+	ActualVerdict    string     `json:"actual_verdict,omitempty"`
+	Agreed           *bool      `json:"agreed,omitempty"`
+	PredictedAt      time.Time  `json:"predicted_at"`
+	ResolvedAt       *time.Time `json:"resolved_at,omitempty"`
+}
+
+// AgreementStat summarises how often shadow predictions matched the human verdict.
+type AgreementStat struct {
+	Stage         string  `json:"stage"`
+	Category      string  `json:"category"`
+	Predictions   int     `json:"predictions"`
+	Resolved      int     `json:"resolved"`
+	Agreed        int     `json:"agreed"`
+	AgreementRate float64 `json:"agreement_rate"`
+}
+
+const gatePredictionColumns = `id, item_id, stage, category, evidence, predicted_verdict, confidence, coalesce(rationale, ''),
+	neighbours, coalesce(actual_verdict, ''), agreed, predicted_at, resolved_at`
+
+func scanGatePrediction(row pgx.Row) (*GatePrediction, error) {
+	var p GatePrediction
+	err := row.Scan(&p.ID, &p.ItemID, &p.Stage, &p.Category, &p.Evidence, &p.PredictedVerdict, &p.Confidence, &p.Rationale,
+		&p.Neighbours, &p.ActualVerdict, &p.Agreed, &p.PredictedAt, &p.ResolvedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+// GetOpenGatePrediction returns the unresolved prediction for an item/stage.
+// Returns pgx.ErrNoRows if there is none.
+func (s *Store) GetOpenGatePrediction(ctx context.Context, itemID, stage string) (*GatePrediction, error) {
+	return scanGatePrediction(s.pool.QueryRow(ctx, `
+		SELECT `+gatePredictionColumns+`
+		FROM gate_predictions
+		WHERE item_id = $1 AND stage = $2 AND resolved_at IS NULL
+		ORDER BY predicted_at DESC
+		LIMIT 1`,
+		itemID, stage,
+	))
+}
+
+// SaveGatePrediction inserts a prediction, or updates it in place if p.ID
+// refers to an existing open prediction.
+func (s *Store) SaveGatePrediction(ctx context.Context, p *GatePrediction) error {
+	if p.ID == uuid.Nil {
+		p.ID = uuid.New()
+	}
+	_, err := s.pool.Exec(ctx, `
+		INSERT INTO gate_predictions (id, item_id, stage, category, evidence, predicted_verdict, confidence, rationale, neighbours, predicted_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, now())
+		ON CONFLICT (id)
+		DO UPDATE SET
+			evidence = $5,
+			predicted_verdict = $6,
+			confidence = $7,
+			rationale = $8,
+			neighbours = $9,
+			predicted_at = now()`,
+		p.ID, p.ItemID, p.Stage, p.Category, p.Evidence, p.PredictedVerdict, p.Confidence, p.Rationale, p.Neighbours,
+	)
+	if err != nil {
+		return fmt.Errorf("save gate prediction: %w", err)
+	}
+	return nil
+}
+
+// ResolveGatePredictions scores every open prediction for an item/stage against
+// the human verdict and returns the resolved rows. An empty stage matches any stage.
+func (s *Store) ResolveGatePredictions(ctx context.Context, itemID, stage, actualVerdict string) ([]GatePrediction, error) {
+	rows, err := s.pool.Query(ctx, `
+		UPDATE gate_predictions
+		SET actual_verdict = $3, agreed = (predicted_verdict = $3), resolved_at = now()
+		WHERE item_id = $1 AND ($2 = '' OR stage = $2) AND resolved_at IS NULL
+		RETURNING `+gatePredictionColumns,
+		itemID, stage, actualVerdict,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("resolve gate predictions: %w", err)
+	}
+	defer rows.Close()
+
+	var resolved []GatePrediction
+	for rows.Next() {
+		p, err := scanGatePrediction(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan gate prediction: %w", err)
+		}
+		resolved = append(resolved, *p)
+	}
+	return resolved, rows.Err()
+}
+
+// ListGatePredictions returns predictions, newest first. Empty filters match all.
+func (s *Store) ListGatePredictions(ctx context.Context, itemID, stage string, resolvedOnly bool, limit int) ([]GatePrediction, error) {
+	rows, err := s.pool.Query(ctx, `
+		SELECT `+gatePredictionColumns+`
+		FROM gate_predictions
+		WHERE ($1 = '' OR item_id = $1) AND ($2 = '' OR stage = $2) AND (NOT $3 OR resolved_at IS NOT NULL)
+		ORDER BY predicted_at DESC
+		LIMIT $4`,
+		itemID, stage, resolvedOnly, limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("query gate predictions: %w", err)
+	}
+	defer rows.Close()
+
+	var predictions []GatePrediction
+	for rows.Next() {
+		p, err := scanGatePrediction(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan gate prediction: %w", err)
+		}
+		predictions = append(predictions, *p)
+	}
+	return predictions, rows.Err()
+}
+
+// GateDecision is a past human gate verdict with the evidence it was given on
+// and the reviewer's reasoning.
+type GateDecision struct {
+	ItemID    string    `json:"item_id"`
+	Stage     string    `json:"stage"`
+	Verdict   string    `json:"verdict"`
+	Evidence  string    `json:"evidence"`  // evidence submitted for the item/stage before the verdict
+	Reasoning string    `json:"reasoning"` // review note and outcomes recorded on the decision
+	DecidedAt time.Time `json:"decided_at"`
+}
+
+// ListGateDecisions returns the gate decisions captured from Slack at a stage,
+// newest first. The evidence is what the shadow prediction had gathered for
+// the item by the time of the verdict, or else the versioned evidence
+// decisions stored for it.
+func (s *Store) ListGateDecisions(ctx context.Context, stage string, limit int) ([]GateDecision, error) {
+	rows, err := s.pool.Query(ctx, `
+		SELECT coalesce(d.session_ref, ''), d.category, v.tag,
+		       coalesce(
+		         (SELECT p.evidence FROM gate_predictions p
+		          WHERE p.item_id = d.session_ref AND p.stage = d.category AND p.predicted_at <= d.created_at
+		          ORDER BY p.predicted_at DESC LIMIT 1),
+		         (SELECT string_agg(c.situation_text, E'\n' ORDER BY e.created_at)
+		          FROM decisions e JOIN decision_context c ON c.decision_id = e.id
+		          WHERE e.domain = 'gate_evidence' AND e.category = d.category AND e.session_ref = d.session_ref
+		            AND e.created_at <= d.created_at),
+		         ''),
+		       concat_ws(E'\n', nullif(d.review_note, ''),
+		         (SELECT string_agg(o.outcome_text, E'\n' ORDER BY o.created_at) FROM decision_outcomes o WHERE o.decision_id = d.id)),
+		       d.created_at
+		FROM decisions d
+		JOIN decision_tags v ON v.decision_id = d.id AND v.tag IN ('approved', 'changes_requested', 'blocked')
+		WHERE d.domain = 'gate' AND d.category = $1
+		ORDER BY d.created_at DESC
+		LIMIT $2`,
+		stage, limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("query gate decisions: %w", err)
+	}
+	defer rows.Close()
+
+	var decisions []GateDecision
+	for rows.Next() {
+		var g GateDecision
+		if err := rows.Scan(&g.ItemID, &g.Stage, &g.Verdict, &g.Evidence, &g.Reasoning, &g.DecidedAt); err != nil {
+			return nil, fmt.Errorf("scan gate decision: %w", err)
+		}
+		decisions = append(decisions, g)
+	}
+	return decisions, rows.Err()
+}
+
+// CountGateVerdicts returns how often each verdict was given at a gate stage,
+// based on the gate decisions captured from Slack.
+func (s *Store) CountGateVerdicts(ctx context.Context, stage string) (map[string]int, error) {
+	rows, err := s.pool.Query(ctx, `
+		SELECT t.tag, count(*)
+		FROM decisions d
+		JOIN decision_tags t ON t.decision_id = d.id
+		WHERE d.domain = 'gate' AND d.category = $1
+		  AND t.tag IN ('approved', 'changes_requested', 'blocked')
+		GROUP BY t.tag`,
+		stage,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("count gate verdicts: %w", err)
+	}
+	defer rows.Close()
+
+	counts := make(map[string]int)
+	for rows.Next() {
+		var verdict string
+		var n int
+		if err := rows.Scan(&verdict, &n); err != nil {
+			return nil, fmt.Errorf("scan verdict count: %w", err)
+		}
+		counts[verdict] = n
+	}
+	return counts, rows.Err()
+}
+
+// GateAgreement returns prediction agreement rates grouped by stage and category.
+func (s *Store) GateAgreement(ctx context.Context, since *time.Time) ([]AgreementStat, error) {
+	var sinceArg any
+	if since != nil {
+		sinceArg = *since
+	}
+	rows, err := s.pool.Query(ctx, `
+		SELECT stage, category,
+		       count(*),
+		       count(*) FILTER (WHERE resolved_at IS NOT NULL),
+		       count(*) FILTER (WHERE agreed)
+		FROM gate_predictions
+		WHERE $1::timestamptz IS NULL OR predicted_at >= $1
+		GROUP BY stage, category
+		ORDER BY stage, category`,
+		sinceArg,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("query gate agreement: %w", err)
+	}
+	defer rows.Close()
+
+	var stats []AgreementStat
+	for rows.Next() {
+		var a AgreementStat
+		if err := rows.Scan(&a.Stage, &a.Category, &a.Predictions, &a.Resolved, &a.Agreed); err != nil {
+			return nil, fmt.Errorf("scan gate agreement: %w", err)
+		}
+		if a.Resolved > 0 {
+			a.AgreementRate = float64(a.Agreed) / float64(a.Resolved)
+		}
+		stats = append(stats, a)
+	}
+	return stats, rows.Err()
+}
@@ -0,0 +1,47 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// SchemaVersion is the migration version this build expects. Bump it with
+// every new migration; each migration records its version in schema_migrations.
+const SchemaVersion = 23
+
+// Ping checks that the database answers and has the pgvector extension.
+func (s *Store) Ping(ctx context.Context) error {
+	if err := s.pool.Ping(ctx); err != nil {
+		return fmt.Errorf("ping: %w", err)
+	}
+	var vector bool
+	err := s.pool.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM pg_extension WHERE extname = 'vector')`).Scan(&vector)
+	if err != nil {
+		return fmt.Errorf("check pgvector: %w", err)
+	}
+	if !vector {
+		return fmt.Errorf("pgvector extension is not installed")
+	}
+	return nil
+}
+
+// CheckSchema returns an error unless migrations up to SchemaVersion have
+// been applied.
+func (s *Store) CheckSchema(ctx context.Context) error {
+	var version int
+	err := s.pool.QueryRow(ctx, `SELECT coalesce(max(version), 0) FROM schema_migrations`).Scan(&version)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "42P01" { // undefined_table
+			return fmt.Errorf("schema_migrations table missing, want version %d", SchemaVersion)
+		}
+		return fmt.Errorf("read schema version: %w", err)
+	}
+	if version < SchemaVersion {
+		return fmt.Errorf("schema version %d, want %d", version, SchemaVersion)
+	}
+	return nil
+}
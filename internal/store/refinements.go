@@ -0,0 +1,149 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// Refinement proposal statuses. A proposal starts proposed, is accepted or
+// rejected by a reviewer, and an accepted proposal is applied once the SOUL
+// has been edited.
+const (
+	ProposalProposed = "proposed"
+	ProposalAccepted = "accepted"
+	ProposalRejected = "rejected"
+	ProposalApplied  = "applied"
+)
+
+// proposalTransitions maps each target status to the statuses it may be
+// reached from.
+var proposalTransitions = map[string][]string{
+	ProposalAccepted: {ProposalProposed},
+	ProposalRejected: {ProposalProposed, ProposalAccepted},
+	ProposalApplied:  {ProposalAccepted},
+}
+
+// ErrProposalTransition is returned when a proposal's current status does not
+// allow the requested one.
+var ErrProposalTransition = errors.New("invalid proposal status transition")
+
+// RefinementProposal is a persisted SOUL refinement proposal.
+type RefinementProposal struct {
+	ID             uuid.UUID   `json:"id"`
+	ClusterID      string      `json:"cluster_id,omitempty"` // refinement cluster the proposal was made from
+	PatternType    string      `json:"pattern_type"`
+	PatternIDs     []uuid.UUID `json:"pattern_ids"`
+	TargetSOULSlug string      `json:"target_soul_slug"`
+	TargetSection  string      `json:"target_section"`
+	ProposedChange string      `json:"proposed_change"`
+	SectionDiff    string      `json:"section_diff,omitempty"` // unified diff of the target section
+	Status         string      `json:"status"`
+	Reviewer       string      `json:"reviewer,omitempty"`
+	Note           string      `json:"note,omitempty"`
+	CreatedAt      time.Time   `json:"created_at"`
+	UpdatedAt      time.Time   `json:"updated_at"`
+}
+
+const proposalColumns = `id, coalesce(cluster_id, ''), pattern_type, pattern_ids, target_soul_slug, target_section, proposed_change,
+	coalesce(section_diff, ''), status, coalesce(reviewer, ''), coalesce(note, ''), created_at, updated_at`
+
+func scanProposal(row pgx.Row) (*RefinementProposal, error) {
+	var p RefinementProposal
+	err := row.Scan(&p.ID, &p.ClusterID, &p.PatternType, &p.PatternIDs, &p.TargetSOULSlug, &p.TargetSection, &p.ProposedChange,
+		&p.SectionDiff, &p.Status, &p.Reviewer, &p.Note, &p.CreatedAt, &p.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+// CreateRefinementProposal stores a new proposal with status proposed.
+func (s *Store) CreateRefinementProposal(ctx context.Context, p RefinementProposal) (*RefinementProposal, error) {
+	created, err := scanProposal(s.pool.QueryRow(ctx, `
+		INSERT INTO refinement_proposals (cluster_id, pattern_type, pattern_ids, target_soul_slug, target_section, proposed_change, section_diff)
+		VALUES (nullif($1, ''), $2, $3, $4, $5, $6, nullif($7, ''))
+		RETURNING `+proposalColumns,
+		p.ClusterID, p.PatternType, p.PatternIDs, p.TargetSOULSlug, p.TargetSection, p.ProposedChange, p.SectionDiff,
+	))
+	if err != nil {
+		return nil, fmt.Errorf("insert refinement proposal: %w", err)
+	}
+	return created, nil
+}
+
+// GetRefinementProposal returns one proposal, or pgx.ErrNoRows.
+func (s *Store) GetRefinementProposal(ctx context.Context, id uuid.UUID) (*RefinementProposal, error) {
+	return scanProposal(s.pool.QueryRow(ctx, `SELECT `+proposalColumns+` FROM refinement_proposals WHERE id = $1`, id))
+}
+
+// ListRefinementProposals returns proposals with any of the given statuses,
+// or every proposal when statuses is empty, newest first. limit <= 0 returns
+// every match.
+func (s *Store) ListRefinementProposals(ctx context.Context, statuses []string, limit int) ([]RefinementProposal, error) {
+	query := `SELECT ` + proposalColumns + ` FROM refinement_proposals`
+	var args []any
+	if len(statuses) > 0 {
+		args = append(args, statuses)
+		query += ` WHERE status = ANY($1)`
+	}
+	query += ` ORDER BY created_at DESC`
+	if limit > 0 {
+		args = append(args, limit)
+		query += fmt.Sprintf(` LIMIT $%d`, len(args))
+	}
+
+	rows, err := s.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("list refinement proposals: %w", err)
+	}
+	defer rows.Close()
+
+	var proposals []RefinementProposal
+	for rows.Next() {
+		p, err := scanProposal(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan refinement proposal: %w", err)
+		}
+		proposals = append(proposals, *p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate refinement proposals: %w", err)
+	}
+	return proposals, nil
+}
+
+// SetRefinementProposalStatus moves a proposal to status, recording who did
+// it and why. It returns pgx.ErrNoRows for an unknown proposal and
+// ErrProposalTransition when the current status does not allow the move.
+func (s *Store) SetRefinementProposalStatus(ctx context.Context, id uuid.UUID, status, reviewer, note string) (*RefinementProposal, error) {
+	from, ok := proposalTransitions[status]
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown status %q", ErrProposalTransition, status)
+	}
+
+	p, err := scanProposal(s.pool.QueryRow(ctx, `
+		UPDATE refinement_proposals
+		SET status = $2, reviewer = nullif($3, ''), note = nullif($4, ''), updated_at = now()
+		WHERE id = $1 AND status = ANY($5)
+		RETURNING `+proposalColumns,
+		id, status, reviewer, note, from,
+	))
+	if err == nil {
+		return p, nil
+	}
+	if !IsNotFound(err) {
+		return nil, fmt.Errorf("update refinement proposal: %w", err)
+	}
+
+	// Distinguish an unknown proposal from one in the wrong status.
+	current, gerr := s.GetRefinementProposal(ctx, id)
+	if gerr != nil {
+		return nil, gerr
+	}
+	return nil, fmt.Errorf("%w: %s to %s", ErrProposalTransition, current.Status, status)
+}
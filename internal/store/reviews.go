@@ -0,0 +1,60 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ReviewEntry is one review verdict on a decision or pattern.
+type ReviewEntry struct {
+	ID         uuid.UUID `json:"id"`
+	TargetKind string    `json:"target_kind"` // decision | pattern
+	TargetID   uuid.UUID `json:"target_id"`
+	Verdict    string    `json:"verdict"` // confirmed | rejected | skipped
+	Note       string    `json:"note,omitempty"`
+	Reviewer   string    `json:"reviewer,omitempty"`
+	Channel    string    `json:"channel"` // slack | api
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// RecordReview appends a review verdict to the review log.
+func (s *Store) RecordReview(ctx context.Context, e ReviewEntry) error {
+	_, err := s.pool.Exec(ctx, `
+		INSERT INTO review_log (target_kind, target_id, verdict, note, reviewer, channel)
+		VALUES ($1, $2, $3, $4, $5, $6)`,
+		e.TargetKind, e.TargetID, e.Verdict, e.Note, e.Reviewer, e.Channel,
+	)
+	if err != nil {
+		return fmt.Errorf("insert review: %w", err)
+	}
+	return nil
+}
+
+// ListReviews returns the review history of a decision or pattern, newest first.
+func (s *Store) ListReviews(ctx context.Context, kind string, id uuid.UUID) ([]ReviewEntry, error) {
+	rows, err := s.pool.Query(ctx, `
+		SELECT id, target_kind, target_id, verdict, coalesce(note, ''), coalesce(reviewer, ''), channel, created_at
+		FROM review_log
+		WHERE target_kind = $1 AND target_id = $2
+		ORDER BY created_at DESC`, kind, id)
+	if err != nil {
+		return nil, fmt.Errorf("list reviews: %w", err)
+	}
+	defer rows.Close()
+
+	var entries []ReviewEntry
+	for rows.Next() {
+		var e ReviewEntry
+		if err := rows.Scan(&e.ID, &e.TargetKind, &e.TargetID, &e.Verdict, &e.Note, &e.Reviewer, &e.Channel, &e.CreatedAt); err != nil {
+			return nil, fmt.Errorf("scan review: %w", err)
+		}
+		entries = append(entries, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate reviews: %w", err)
+	}
+	return entries, nil
+}
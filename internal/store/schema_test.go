@@ -0,0 +1,43 @@
+package store
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestSchemaVersion_MatchesMigrations(t *testing.T) {
+	files, err := filepath.Glob("../../migrations/*.sql")
+	if err != nil || len(files) == 0 {
+		t.Fatalf("no migrations found: %v", err)
+	}
+
+	latest := 0
+	for _, f := range files {
+		base := filepath.Base(f)
+		version, err := strconv.Atoi(strings.SplitN(base, "_", 2)[0])
+		if err != nil {
+			t.Fatalf("%s: migration name must start with its version", base)
+		}
+		latest = max(latest, version)
+
+		// Migrations after the one that created schema_migrations record themselves.
+		if version > 14 {
+			sql, err := os.ReadFile(f)
+			if err != nil {
+				t.Fatal(err)
+			}
+			want := fmt.Sprintf("insert into schema_migrations (version) values (%d)", version)
+			if !strings.Contains(string(sql), want) {
+				t.Errorf("%s: missing %q", base, want)
+			}
+		}
+	}
+
+	if latest != SchemaVersion {
+		t.Errorf("SchemaVersion = %d, latest migration is %d", SchemaVersion, latest)
+	}
+}
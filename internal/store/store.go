@@ -2,8 +2,8 @@ package store
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"strings"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
@@ -14,7 +14,12 @@ type Store struct {
 }
 
 func New(ctx context.Context, databaseURL string) (*Store, error) {
-	pool, err := pgxpool.New(ctx, databaseURL)
+	cfg, err := pgxpool.ParseConfig(databaseURL)
+	if err != nil {
+		return nil, fmt.Errorf("parse database URL: %w", err)
+	}
+	cfg.AfterConnect = registerVector
+	pool, err := pgxpool.NewWithConfig(ctx, cfg)
 	if err != nil {
 		return nil, fmt.Errorf("connect to database: %w", err)
 	}
@@ -28,17 +33,12 @@ func (s *Store) Close() {
 	s.pool.Close()
 }
 
-// pgVector formats a float64 slice as a pgvector-compatible string literal, e.g. "[0.1,0.2,0.3]".
-// This is suitable for passing to a parameterized query targeting a vector column.
-func pgVector(v []float64) string {
-	parts := make([]string, len(v))
-	for i, f := range v {
-		parts[i] = fmt.Sprintf("%g", f)
-	}
-	return "[" + strings.Join(parts, ",") + "]"
-}
-
 // Query executes a query that returns rows
 func (s *Store) Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error) {
 	return s.pool.Query(ctx, sql, args...)
 }
+
+// IsNotFound reports whether err means the requested row does not exist.
+func IsNotFound(err error) bool {
+	return errors.Is(err, pgx.ErrNoRows)
+}
@@ -4,11 +4,17 @@ package store
 
 import (
 	"context"
+	"errors"
+	"log/slog"
 	"os"
+	"reflect"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/MikeSquared-Agency/dredd/internal/dedup"
 	"github.com/MikeSquared-Agency/dredd/internal/extractor"
+	"github.com/MikeSquared-Agency/dredd/internal/jobs"
 )
 
 func setupTestStore(t *testing.T) *Store {
@@ -216,3 +222,441 @@ func TestIntegration_UpsertTrust(t *testing.T) {
 		s.pool.Exec(ctx, "DELETE FROM agent_trust WHERE agent_id = $1", agentID)
 	})
 }
+
+func TestIntegration_ListDecisions(t *testing.T) {
+	s := setupTestStore(t)
+	ctx := context.Background()
+	ownerUUID := uuid.New()
+	sessionRef := "integration-test-" + uuid.New().String()[:8]
+
+	var ids []uuid.UUID
+	for i := 0; i < 3; i++ {
+		ep := extractor.DecisionEpisode{
+			Domain:        "architecture",
+			Category:      "list_test",
+			Severity:      "routine",
+			Summary:       "Integration list decision",
+			SituationText: "Testing the decision list path",
+			Options: []extractor.DecisionOption{
+				{OptionKey: "ship", ProSignals: []string{"ready"}, WasChosen: true},
+			},
+			Reasoning: extractor.DecisionReasoning{
+				Factors:       []string{"ready"},
+				ReasoningText: "It is ready",
+			},
+			Tags:       []string{"list-test"},
+			Confidence: 0.9,
+		}
+		id, err := s.WriteDecisionEpisode(ctx, ownerUUID, sessionRef, "dredd", ep)
+		if err != nil {
+			t.Fatalf("WriteDecisionEpisode failed: %v", err)
+		}
+		ids = append(ids, id)
+	}
+	t.Cleanup(func() {
+		for _, id := range ids {
+			s.pool.Exec(ctx, "DELETE FROM decisions WHERE id = $1", id)
+		}
+	})
+
+	// Page through two at a time.
+	page, next, err := s.ListDecisions(ctx, DecisionFilter{Owner: ownerUUID, Tag: "list-test", Limit: 2})
+	if err != nil {
+		t.Fatalf("ListDecisions failed: %v", err)
+	}
+	if len(page) != 2 || next == nil {
+		t.Fatalf("expected 2 decisions and a cursor, got %d (cursor %v)", len(page), next)
+	}
+
+	rest, next, err := s.ListDecisions(ctx, DecisionFilter{Owner: ownerUUID, Tag: "list-test", Limit: 2, After: next})
+	if err != nil {
+		t.Fatalf("ListDecisions page 2 failed: %v", err)
+	}
+	if len(rest) != 1 || next != nil {
+		t.Fatalf("expected 1 decision and no cursor, got %d (cursor %v)", len(rest), next)
+	}
+
+	// Detail includes child rows.
+	d, err := s.GetDecisionDetail(ctx, ids[0])
+	if err != nil {
+		t.Fatalf("GetDecisionDetail failed: %v", err)
+	}
+	if d.Situation != "Testing the decision list path" {
+		t.Errorf("expected situation text, got %q", d.Situation)
+	}
+	if len(d.Options) != 1 || !d.Options[0].WasChosen {
+		t.Errorf("expected one chosen option, got %+v", d.Options)
+	}
+	if d.Reasoning == nil || d.Reasoning.ReasoningText != "It is ready" {
+		t.Errorf("expected reasoning, got %+v", d.Reasoning)
+	}
+	if len(d.Tags) != 1 || d.Tags[0] != "list-test" {
+		t.Errorf("expected list-test tag, got %v", d.Tags)
+	}
+
+	if _, err := s.GetDecisionDetail(ctx, uuid.New()); !IsNotFound(err) {
+		t.Errorf("expected not found for unknown id, got %v", err)
+	}
+}
+
+func TestIntegration_ListPatterns(t *testing.T) {
+	s := setupTestStore(t)
+	ctx := context.Background()
+	ownerUUID := uuid.New()
+	sessionRef := "integration-test-" + uuid.New().String()[:8]
+
+	var ids []uuid.UUID
+	for _, conf := range []float64{0.4, 0.9} {
+		id, err := s.WriteReasoningPattern(ctx, ownerUUID, sessionRef, extractor.ReasoningPattern{
+			PatternType:     "pushback",
+			Summary:         "Integration list pattern",
+			ConversationArc: "Mike: No\nAgent: OK",
+			Tags:            []string{"list-test"},
+			Confidence:      conf,
+		})
+		if err != nil {
+			t.Fatalf("WriteReasoningPattern failed: %v", err)
+		}
+		ids = append(ids, id)
+	}
+	t.Cleanup(func() {
+		for _, id := range ids {
+			s.pool.Exec(ctx, "DELETE FROM reasoning_patterns WHERE id = $1", id)
+		}
+	})
+
+	minConf := 0.8
+	patterns, next, err := s.ListPatterns(ctx, PatternFilter{Owner: ownerUUID, Tag: "list-test", MinConfidence: &minConf})
+	if err != nil {
+		t.Fatalf("ListPatterns failed: %v", err)
+	}
+	if next != nil {
+		t.Errorf("expected no next cursor, got %v", next)
+	}
+	if len(patterns) != 1 || patterns[0].ID != ids[1] {
+		t.Fatalf("expected only the high-confidence pattern, got %+v", patterns)
+	}
+}
+
+func TestIntegration_PatternEmbeddings(t *testing.T) {
+	s := setupTestStore(t)
+	ctx := context.Background()
+	ownerUUID := uuid.New()
+
+	vec := make([]float32, 1536)
+	vec[0], vec[1] = 0.6, 0.8
+	withVec, err := s.WriteReasoningPattern(ctx, ownerUUID, "integration-test-vec", extractor.ReasoningPattern{
+		PatternType: "correction", Summary: "Embedded pattern", ConversationArc: "Mike: No\nAgent: OK", Confidence: 0.9,
+	}, WriteOpts{Embedding: vec})
+	if err != nil {
+		t.Fatalf("WriteReasoningPattern failed: %v", err)
+	}
+	withoutVec, err := s.WriteReasoningPattern(ctx, ownerUUID, "integration-test-vec", extractor.ReasoningPattern{
+		PatternType: "correction", Summary: "Unembedded pattern", ConversationArc: "Mike: No\nAgent: OK", Confidence: 0.9,
+	})
+	if err != nil {
+		t.Fatalf("WriteReasoningPattern failed: %v", err)
+	}
+	t.Cleanup(func() {
+		s.pool.Exec(ctx, "DELETE FROM reasoning_patterns WHERE id = ANY($1)", []uuid.UUID{withVec, withoutVec})
+	})
+
+	patterns, err := s.PatternEmbeddings(ctx, PatternFilter{Owner: ownerUUID})
+	if err != nil {
+		t.Fatalf("PatternEmbeddings failed: %v", err)
+	}
+	if len(patterns) != 1 || patterns[0].ID != withVec {
+		t.Fatalf("expected only the embedded pattern, got %+v", patterns)
+	}
+	if got := patterns[0].Embedding; len(got) != 1536 || got[0] != 0.6 || got[1] != 0.8 {
+		t.Errorf("embedding did not round-trip: %v", got[:2])
+	}
+
+	found, err := s.SearchPatterns(ctx, vec, 0.99, PatternFilter{Owner: ownerUUID})
+	if err != nil {
+		t.Fatalf("SearchPatterns failed: %v", err)
+	}
+	if len(found) != 1 || found[0].ID != withVec {
+		t.Errorf("expected the embedded pattern from search, got %+v", found)
+	}
+}
+
+func TestIntegration_WritePatternOccurrence(t *testing.T) {
+	s := setupTestStore(t)
+	ctx := context.Background()
+	ownerUUID := uuid.New()
+
+	vec := make([]float32, 1536)
+	vec[1533] = 1
+	pattern := extractor.ReasoningPattern{
+		PatternType: "pushback", Summary: "Recurring pattern", ConversationArc: "Mike: Not again\nAgent: OK", Confidence: 0.7,
+	}
+	var matched bool
+	first, err := s.WriteReasoningPattern(ctx, ownerUUID, "integration-test-occ-1", pattern, WriteOpts{Embedding: vec, MatchThreshold: 0.95, Matched: &matched})
+	if err != nil {
+		t.Fatalf("WriteReasoningPattern failed: %v", err)
+	}
+	t.Cleanup(func() {
+		s.pool.Exec(ctx, "DELETE FROM reasoning_patterns WHERE owner_uuid = $1", ownerUUID)
+	})
+	if matched {
+		t.Fatal("expected the first write to insert")
+	}
+
+	pattern.Confidence = 0.9
+	again, err := s.WriteReasoningPattern(ctx, ownerUUID, "integration-test-occ-2", pattern, WriteOpts{Embedding: vec, MatchThreshold: 0.95, Matched: &matched})
+	if err != nil {
+		t.Fatalf("WriteReasoningPattern failed: %v", err)
+	}
+	if !matched || again != first {
+		t.Fatalf("expected an occurrence of %s, got %s (matched %v)", first, again, matched)
+	}
+
+	got, err := s.GetPatternByID(ctx, first)
+	if err != nil {
+		t.Fatalf("GetPatternByID failed: %v", err)
+	}
+	if got.Occurrences != 2 || got.Confidence != 0.9 {
+		t.Errorf("expected 2 occurrences at confidence 0.9, got %d at %v", got.Occurrences, got.Confidence)
+	}
+	if want := []string{"integration-test-occ-1", "integration-test-occ-2"}; !reflect.DeepEqual(got.SessionRefs, want) {
+		t.Errorf("expected session refs %v, got %v", want, got.SessionRefs)
+	}
+
+	// Another type is not a recurrence, however similar.
+	pattern.PatternType = "correction"
+	other, err := s.WriteReasoningPattern(ctx, ownerUUID, "integration-test-occ-3", pattern, WriteOpts{Embedding: vec, MatchThreshold: 0.95, Matched: &matched})
+	if err != nil {
+		t.Fatalf("WriteReasoningPattern failed: %v", err)
+	}
+	if matched || other == first {
+		t.Error("expected a pattern of another type to insert")
+	}
+
+	// Nor is a pattern a reviewer rejected.
+	if err := s.UpdatePatternReviewStatus(ctx, other, "rejected", ""); err != nil {
+		t.Fatalf("UpdatePatternReviewStatus failed: %v", err)
+	}
+	fresh, err := s.WriteReasoningPattern(ctx, ownerUUID, "integration-test-occ-4", pattern, WriteOpts{Embedding: vec, MatchThreshold: 0.95, Matched: &matched})
+	if err != nil {
+		t.Fatalf("WriteReasoningPattern failed: %v", err)
+	}
+	if matched || fresh == other {
+		t.Error("expected a recurrence of a rejected pattern to insert")
+	}
+}
+
+func TestIntegration_DedupNeighbourScan(t *testing.T) {
+	s := setupTestStore(t)
+	ctx := context.Background()
+	ownerUUID := uuid.New()
+
+	var ids []uuid.UUID
+	for _, tilt := range []float32{0, 0.01} {
+		vec := make([]float32, 1536)
+		vec[1535], vec[1534] = 1, tilt
+		id, err := s.WriteReasoningPattern(ctx, ownerUUID, "integration-test-dedup", extractor.ReasoningPattern{
+			PatternType: "correction", Summary: "Near-duplicate pattern", ConversationArc: "Mike: No\nAgent: OK", Confidence: 0.9,
+		}, WriteOpts{Embedding: vec})
+		if err != nil {
+			t.Fatalf("WriteReasoningPattern failed: %v", err)
+		}
+		ids = append(ids, id)
+	}
+	t.Cleanup(func() {
+		s.pool.Exec(ctx, "DELETE FROM reasoning_patterns WHERE id = ANY($1)", ids)
+	})
+
+	results, err := s.Deduplicate(ctx, "patterns", dedup.Options{Threshold: 0.99, Neighbours: 5}, slog.Default(), nil)
+	if err != nil {
+		t.Fatalf("Deduplicate failed: %v", err)
+	}
+	for _, c := range results[0].Details {
+		members := append([]uuid.UUID{c.SurvivorID}, c.DedupedIDs...)
+		if len(members) == 2 && (members[0] == ids[0] || members[0] == ids[1]) && (members[1] == ids[0] || members[1] == ids[1]) {
+			return
+		}
+	}
+	t.Errorf("expected %v as a duplicate cluster, got %+v", ids, results[0].Details)
+}
+
+func TestIntegration_RefinementProposalLifecycle(t *testing.T) {
+	s := setupTestStore(t)
+	ctx := context.Background()
+
+	p, err := s.CreateRefinementProposal(ctx, RefinementProposal{
+		PatternType:    "correction",
+		PatternIDs:     []uuid.UUID{uuid.New(), uuid.New()},
+		TargetSOULSlug: "integration-soul",
+		ProposedChange: "Integration test proposal",
+	})
+	if err != nil {
+		t.Fatalf("CreateRefinementProposal failed: %v", err)
+	}
+	if p.Status != ProposalProposed || len(p.PatternIDs) != 2 {
+		t.Fatalf("unexpected proposal: %+v", p)
+	}
+
+	// Applying before accepting is not allowed.
+	if _, err := s.SetRefinementProposalStatus(ctx, p.ID, ProposalApplied, "", ""); !errors.Is(err, ErrProposalTransition) {
+		t.Errorf("expected ErrProposalTransition, got %v", err)
+	}
+
+	accepted, err := s.SetRefinementProposalStatus(ctx, p.ID, ProposalAccepted, "mike", "good catch")
+	if err != nil {
+		t.Fatalf("accept failed: %v", err)
+	}
+	if accepted.Status != ProposalAccepted || accepted.Reviewer != "mike" || accepted.Note != "good catch" {
+		t.Errorf("unexpected accepted proposal: %+v", accepted)
+	}
+	if _, err := s.SetRefinementProposalStatus(ctx, p.ID, ProposalApplied, "mike", ""); err != nil {
+		t.Fatalf("apply failed: %v", err)
+	}
+
+	if _, err := s.SetRefinementProposalStatus(ctx, uuid.New(), ProposalAccepted, "", ""); !IsNotFound(err) {
+		t.Errorf("expected not found for unknown proposal, got %v", err)
+	}
+
+	open, err := s.ListRefinementProposals(ctx, []string{ProposalProposed, ProposalAccepted}, 0)
+	if err != nil {
+		t.Fatalf("ListRefinementProposals failed: %v", err)
+	}
+	for _, o := range open {
+		if o.ID == p.ID {
+			t.Error("applied proposal listed as open")
+		}
+	}
+}
+
+func TestIntegration_LookupAPITokenThrottlesTouch(t *testing.T) {
+	s := setupTestStore(t)
+	ctx := context.Background()
+	name := "integration-" + uuid.New().String()[:8]
+	hash := "integration-hash-" + uuid.New().String()
+
+	if _, err := s.CreateAPIToken(ctx, name, hash, []string{"read"}); err != nil {
+		t.Fatalf("CreateAPIToken failed: %v", err)
+	}
+	t.Cleanup(func() { s.RevokeAPIToken(ctx, name) })
+
+	first, err := s.LookupAPIToken(ctx, hash)
+	if err != nil {
+		t.Fatalf("LookupAPIToken failed: %v", err)
+	}
+	if first.LastUsedAt != nil {
+		t.Errorf("expected no prior use, got %v", first.LastUsedAt)
+	}
+
+	second, err := s.LookupAPIToken(ctx, hash)
+	if err != nil {
+		t.Fatalf("LookupAPIToken failed: %v", err)
+	}
+	third, err := s.LookupAPIToken(ctx, hash)
+	if err != nil {
+		t.Fatalf("LookupAPIToken failed: %v", err)
+	}
+	if second.LastUsedAt == nil || third.LastUsedAt == nil || !second.LastUsedAt.Equal(*third.LastUsedAt) {
+		t.Errorf("expected one touch within the interval, got %v then %v", second.LastUsedAt, third.LastUsedAt)
+	}
+}
+
+func TestIntegration_SearchPrecedents(t *testing.T) {
+	s := setupTestStore(t)
+	ctx := context.Background()
+	ownerUUID := uuid.New()
+	domain := "integration-prec-" + uuid.New().String()[:8]
+
+	unit := func(dims ...float32) []float32 {
+		vec := make([]float32, 1536)
+		copy(vec, dims)
+		return vec
+	}
+	write := func(summary, status string, vec []float32) uuid.UUID {
+		t.Helper()
+		id, err := s.WriteDecisionEpisode(ctx, ownerUUID, "integration-test-prec", "dredd", extractor.DecisionEpisode{
+			Domain: domain, Category: "gate_approval", Severity: "routine", Summary: summary, SituationText: summary,
+		}, WriteOpts{Embedding: vec})
+		if err != nil {
+			t.Fatalf("WriteDecisionEpisode failed: %v", err)
+		}
+		if err := s.UpdateDecisionReviewStatus(ctx, id, status, ""); err != nil {
+			t.Fatalf("UpdateDecisionReviewStatus failed: %v", err)
+		}
+		return id
+	}
+	t.Cleanup(func() {
+		s.pool.Exec(ctx, "DELETE FROM decisions WHERE domain = $1", domain)
+	})
+
+	exact := write("Exact decision", "confirmed", unit(1))
+	// Its decision embedding is orthogonal, but its situation is close.
+	situated := write("Situated decision", "confirmed", unit(0, 1))
+	if _, err := s.pool.Exec(ctx, "UPDATE decision_context SET situation_embedding = $2 WHERE decision_id = $1", situated, unit(0.6, 0.8)); err != nil {
+		t.Fatalf("set situation embedding: %v", err)
+	}
+	write("Pending decision", "pending", unit(1))
+
+	got, err := s.SearchPrecedents(ctx, unit(1), PrecedentFilter{Domain: domain, MinSimilarity: 0.5})
+	if err != nil {
+		t.Fatalf("SearchPrecedents failed: %v", err)
+	}
+	if len(got) != 2 || got[0].ID != exact || got[1].ID != situated {
+		t.Fatalf("expected the exact then the situated decision, got %+v", got)
+	}
+	if got[1].Similarity < 0.59 || got[1].Similarity > 0.61 {
+		t.Errorf("expected the situation's similarity 0.6, got %v", got[1].Similarity)
+	}
+
+	got, err = s.SearchPrecedents(ctx, unit(1), PrecedentFilter{Domain: domain, MinSimilarity: 0.5, Limit: 1})
+	if err != nil {
+		t.Fatalf("SearchPrecedents failed: %v", err)
+	}
+	if len(got) != 1 || got[0].ID != exact {
+		t.Errorf("expected only the exact decision, got %+v", got)
+	}
+}
+
+func TestIntegration_AbandonJobsKeepsLiveLeases(t *testing.T) {
+	s := setupTestStore(t)
+	ctx := context.Background()
+
+	live := jobs.Job{ID: uuid.New(), Kind: "dedup", Status: jobs.StatusRunning, CreatedAt: time.Now().UTC()}
+	expired := jobs.Job{ID: uuid.New(), Kind: "dedup", Status: jobs.StatusRunning, CreatedAt: time.Now().UTC()}
+	for _, j := range []jobs.Job{live, expired} {
+		if err := s.CreateJob(ctx, j); err != nil {
+			t.Fatal(err)
+		}
+	}
+	t.Cleanup(func() { s.pool.Exec(ctx, "DELETE FROM jobs WHERE id = ANY($1)", []uuid.UUID{live.ID, expired.ID}) })
+	if _, err := s.pool.Exec(ctx, `UPDATE jobs SET lease_expires_at = now() - interval '1 hour' WHERE id = $1`, expired.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := s.AbandonJobs(ctx); err != nil {
+		t.Fatal(err)
+	}
+	for id, want := range map[uuid.UUID]jobs.Status{live.ID: jobs.StatusRunning, expired.ID: jobs.StatusFailed} {
+		got, err := s.GetJob(ctx, id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.Status != want {
+			t.Errorf("job %s: expected %s, got %s", id, want, got.Status)
+		}
+	}
+
+	// Another process's cancel request reaches the owner at its next renewal.
+	if ok, err := s.RequestJobCancel(ctx, expired.ID); err != nil || ok {
+		t.Errorf("expected no cancel request for an abandoned job, got %v %v", ok, err)
+	}
+	if ok, err := s.RequestJobCancel(ctx, live.ID); err != nil || !ok {
+		t.Fatalf("expected a cancel request for the live job, got %v %v", ok, err)
+	}
+	cancel, err := s.RenewJobs(ctx, []uuid.UUID{live.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(cancel, []uuid.UUID{live.ID}) {
+		t.Errorf("expected the renewal to return the cancel request, got %v", cancel)
+	}
+}
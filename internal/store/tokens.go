@@ -0,0 +1,112 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// APIToken is a named API token. The token itself is never stored.
+type APIToken struct {
+	ID         uuid.UUID  `json:"id"`
+	Name       string     `json:"name"`
+	Scopes     []string   `json:"scopes"`
+	CreatedAt  time.Time  `json:"created_at"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+}
+
+// CreateAPIToken stores a token hash under a name unique among active tokens.
+func (s *Store) CreateAPIToken(ctx context.Context, name, tokenHash string, scopes []string) (*APIToken, error) {
+	t := APIToken{Name: name, Scopes: scopes}
+	err := s.pool.QueryRow(ctx, `
+		INSERT INTO api_tokens (name, token_hash, scopes)
+		VALUES ($1, $2, $3)
+		RETURNING id, created_at`,
+		name, tokenHash, scopes,
+	).Scan(&t.ID, &t.CreatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("insert api token: %w", err)
+	}
+	return &t, nil
+}
+
+// RevokeAPIToken revokes the active token with the given name. It returns
+// pgx.ErrNoRows when there is none.
+func (s *Store) RevokeAPIToken(ctx context.Context, name string) error {
+	tag, err := s.pool.Exec(ctx, `
+		UPDATE api_tokens SET revoked_at = now()
+		WHERE name = $1 AND revoked_at IS NULL`, name)
+	if err != nil {
+		return fmt.Errorf("revoke api token: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+	return nil
+}
+
+// ListAPITokens returns every token, including revoked ones, oldest first.
+func (s *Store) ListAPITokens(ctx context.Context) ([]APIToken, error) {
+	rows, err := s.pool.Query(ctx, `
+		SELECT id, name, scopes, created_at, last_used_at, revoked_at
+		FROM api_tokens ORDER BY created_at`)
+	if err != nil {
+		return nil, fmt.Errorf("list api tokens: %w", err)
+	}
+	defer rows.Close()
+
+	var tokens []APIToken
+	for rows.Next() {
+		var t APIToken
+		if err := rows.Scan(&t.ID, &t.Name, &t.Scopes, &t.CreatedAt, &t.LastUsedAt, &t.RevokedAt); err != nil {
+			return nil, fmt.Errorf("scan api token: %w", err)
+		}
+		tokens = append(tokens, t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate api tokens: %w", err)
+	}
+	return tokens, nil
+}
+
+// tokenTouchInterval bounds how often a token's last_used_at is written, so
+// authenticating a busy client is a read rather than an update per request.
+const tokenTouchInterval = time.Minute
+
+// LookupAPIToken returns the active token with the given hash and records
+// its use, at most once per tokenTouchInterval; LastUsedAt is the value before
+// this use. It returns pgx.ErrNoRows for unknown or revoked tokens.
+func (s *Store) LookupAPIToken(ctx context.Context, tokenHash string) (*APIToken, error) {
+	var t APIToken
+	err := s.pool.QueryRow(ctx, `
+		WITH t AS (
+			SELECT id, name, scopes, created_at, last_used_at FROM api_tokens
+			WHERE token_hash = $1 AND revoked_at IS NULL
+		), touched AS (
+			UPDATE api_tokens SET last_used_at = now()
+			FROM t
+			WHERE api_tokens.id = t.id
+			  AND (t.last_used_at IS NULL OR t.last_used_at < now() - make_interval(secs => $2))
+		)
+		SELECT id, name, scopes, created_at, last_used_at FROM t`,
+		tokenHash, tokenTouchInterval.Seconds(),
+	).Scan(&t.ID, &t.Name, &t.Scopes, &t.CreatedAt, &t.LastUsedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+// HasAPITokens reports whether any active token exists.
+func (s *Store) HasAPITokens(ctx context.Context) (bool, error) {
+	var exists bool
+	err := s.pool.QueryRow(ctx, `SELECT exists(SELECT 1 FROM api_tokens WHERE revoked_at IS NULL)`).Scan(&exists)
+	if err != nil {
+		return false, fmt.Errorf("check api tokens: %w", err)
+	}
+	return exists, nil
+}
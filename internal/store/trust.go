@@ -3,41 +3,85 @@ package store
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 )
 
 type TrustRecord struct {
-	ID               uuid.UUID
-	AgentID          string
-	Category         string
-	Severity         string
-	TrustScore       float64
-	TotalDecisions   int
-	CorrectDecisions int
-	CriticalFailures int
+	ID               uuid.UUID  `json:"id"`
+	AgentID          string     `json:"agent_id"`
+	Category         string     `json:"category"`
+	Severity         string     `json:"severity"`
+	TrustScore       float64    `json:"trust_score"`
+	TotalDecisions   int        `json:"total_decisions"`
+	CorrectDecisions int        `json:"correct_decisions"`
+	CriticalFailures int        `json:"critical_failures"`
+	LastSignalAt     *time.Time `json:"last_signal_at,omitempty"`
+	DecayRate        float64    `json:"decay_rate"`
+	UpdatedAt        time.Time  `json:"updated_at"`
+}
+
+// TrustHistoryEntry is one recorded change to a trust score.
+type TrustHistoryEntry struct {
+	AgentID          string    `json:"agent_id"`
+	Category         string    `json:"category"`
+	Severity         string    `json:"severity"`
+	PreviousScore    *float64  `json:"previous_score"`
+	TrustScore       float64   `json:"trust_score"`
+	TotalDecisions   int       `json:"total_decisions"`
+	CorrectDecisions int       `json:"correct_decisions"`
+	CriticalFailures int       `json:"critical_failures"`
+	CreatedAt        time.Time `json:"created_at"`
+}
+
+// trustColumns is the column list scanned by scanTrust.
+const trustColumns = `id, agent_id, category, severity, trust_score, total_decisions, correct_decisions, critical_failures,
+	last_signal_at, decay_rate, updated_at`
+
+func scanTrust(row pgx.Row) (*TrustRecord, error) {
+	var t TrustRecord
+	err := row.Scan(&t.ID, &t.AgentID, &t.Category, &t.Severity, &t.TrustScore, &t.TotalDecisions, &t.CorrectDecisions, &t.CriticalFailures,
+		&t.LastSignalAt, &t.DecayRate, &t.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
 }
 
 // GetTrust fetches the trust record for an agent/category/severity combination.
 func (s *Store) GetTrust(ctx context.Context, agentID, category, severity string) (*TrustRecord, error) {
 	row := s.pool.QueryRow(ctx, `
-		SELECT id, agent_id, category, severity, trust_score, total_decisions, correct_decisions, critical_failures
+		SELECT `+trustColumns+`
 		FROM agent_trust
 		WHERE agent_id = $1 AND category = $2 AND severity = $3`,
 		agentID, category, severity,
 	)
+	return scanTrust(row)
+}
 
-	var t TrustRecord
-	err := row.Scan(&t.ID, &t.AgentID, &t.Category, &t.Severity, &t.TrustScore, &t.TotalDecisions, &t.CorrectDecisions, &t.CriticalFailures)
+// UpsertTrust creates or updates a trust record for an agent/category/severity
+// and appends the change to trust_history.
+func (s *Store) UpsertTrust(ctx context.Context, agentID, category, severity string, score float64, total, correct, failures int) error {
+	tx, err := s.pool.Begin(ctx)
 	if err != nil {
-		return nil, err
+		return fmt.Errorf("begin tx: %w", err)
 	}
-	return &t, nil
-}
+	defer func() { _ = tx.Rollback(ctx) }()
 
-// UpsertTrust creates or updates a trust record for an agent/category/severity.
-func (s *Store) UpsertTrust(ctx context.Context, agentID, category, severity string, score float64, total, correct, failures int) error {
-	_, err := s.pool.Exec(ctx, `
+	var previous *float64
+	err = tx.QueryRow(ctx, `
+		SELECT trust_score FROM agent_trust
+		WHERE agent_id = $1 AND category = $2 AND severity = $3
+		FOR UPDATE`,
+		agentID, category, severity,
+	).Scan(&previous)
+	if err != nil && !IsNotFound(err) {
+		return fmt.Errorf("read previous trust: %w", err)
+	}
+
+	_, err = tx.Exec(ctx, `
 		INSERT INTO agent_trust (id, agent_id, category, severity, trust_score, total_decisions, correct_decisions, critical_failures, last_signal_at, updated_at)
 		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, now(), now())
 		ON CONFLICT (agent_id, category, severity)
@@ -53,5 +97,96 @@ func (s *Store) UpsertTrust(ctx context.Context, agentID, category, severity str
 	if err != nil {
 		return fmt.Errorf("upsert trust: %w", err)
 	}
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO trust_history (agent_id, category, severity, previous_score, trust_score, total_decisions, correct_decisions, critical_failures)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`,
+		agentID, category, severity, previous, score, total, correct, failures,
+	)
+	if err != nil {
+		return fmt.Errorf("insert trust history: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit: %w", err)
+	}
 	return nil
 }
+
+// ListTrustByAgent returns every category/severity trust record for an agent.
+func (s *Store) ListTrustByAgent(ctx context.Context, agentID string) ([]TrustRecord, error) {
+	return s.queryTrust(ctx, `
+		SELECT `+trustColumns+`
+		FROM agent_trust
+		WHERE agent_id = $1
+		ORDER BY category, severity`,
+		agentID,
+	)
+}
+
+// ListTrust returns trust records across all agents, optionally filtered by
+// category, severity, and a minimum number of decisions.
+func (s *Store) ListTrust(ctx context.Context, category, severity string, minDecisions int) ([]TrustRecord, error) {
+	return s.queryTrust(ctx, `
+		SELECT `+trustColumns+`
+		FROM agent_trust
+		WHERE ($1 = '' OR category = $1)
+		  AND ($2 = '' OR severity = $2)
+		  AND total_decisions >= $3
+		ORDER BY trust_score DESC, agent_id`,
+		category, severity, minDecisions,
+	)
+}
+
+func (s *Store) queryTrust(ctx context.Context, sql string, args ...any) ([]TrustRecord, error) {
+	rows, err := s.pool.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query trust: %w", err)
+	}
+	defer rows.Close()
+
+	var records []TrustRecord
+	for rows.Next() {
+		t, err := scanTrust(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan trust: %w", err)
+		}
+		records = append(records, *t)
+	}
+	return records, rows.Err()
+}
+
+// ListTrustHistory returns an agent's trust score changes, newest first.
+// Empty category or severity match all; since may be nil.
+func (s *Store) ListTrustHistory(ctx context.Context, agentID, category, severity string, since *time.Time, limit int) ([]TrustHistoryEntry, error) {
+	if limit <= 0 {
+		limit = 100
+	}
+
+	rows, err := s.pool.Query(ctx, `
+		SELECT agent_id, category, severity, previous_score, trust_score, total_decisions, correct_decisions, critical_failures, created_at
+		FROM trust_history
+		WHERE agent_id = $1
+		  AND ($2 = '' OR category = $2)
+		  AND ($3 = '' OR severity = $3)
+		  AND ($4::timestamptz IS NULL OR created_at >= $4)
+		ORDER BY created_at DESC
+		LIMIT $5`,
+		agentID, category, severity, since, limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("query trust history: %w", err)
+	}
+	defer rows.Close()
+
+	var entries []TrustHistoryEntry
+	for rows.Next() {
+		var e TrustHistoryEntry
+		if err := rows.Scan(&e.AgentID, &e.Category, &e.Severity, &e.PreviousScore, &e.TrustScore,
+			&e.TotalDecisions, &e.CorrectDecisions, &e.CriticalFailures, &e.CreatedAt); err != nil {
+			return nil, fmt.Errorf("scan trust history: %w", err)
+		}
+		entries = append(entries, e)
+	}
+	return entries, rows.Err()
+}
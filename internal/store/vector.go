@@ -0,0 +1,170 @@
+package store
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// registerVector teaches a connection the pgvector vector type, so vector
+// columns and parameters read and write []float32 directly. The type's OID
+// differs between databases, so it is looked up on each new connection; a
+// database without the extension is left alone and Ping reports it.
+func registerVector(ctx context.Context, conn *pgx.Conn) error {
+	var oid uint32
+	if err := conn.QueryRow(ctx, `SELECT coalesce(to_regtype('vector')::oid, 0)`).Scan(&oid); err != nil {
+		return fmt.Errorf("look up vector type: %w", err)
+	}
+	if oid != 0 {
+		conn.TypeMap().RegisterType(&pgtype.Type{Name: "vector", OID: oid, Codec: vectorCodec{}})
+	}
+	return nil
+}
+
+// vectorCodec encodes []float32 as a pgvector vector and scans a vector into
+// *[]float32. A nil slice is NULL. The binary form is a uint16 dimension
+// count, a reserved uint16, then each element as a big-endian float32; the
+// text form is "[1,2,3]".
+type vectorCodec struct{}
+
+func (vectorCodec) FormatSupported(format int16) bool {
+	return format == pgtype.BinaryFormatCode || format == pgtype.TextFormatCode
+}
+
+func (vectorCodec) PreferredFormat() int16 {
+	return pgtype.BinaryFormatCode
+}
+
+func (vectorCodec) PlanEncode(m *pgtype.Map, oid uint32, format int16, value any) pgtype.EncodePlan {
+	if _, ok := value.([]float32); !ok {
+		return nil
+	}
+	if format == pgtype.BinaryFormatCode {
+		return encodeVectorBinary{}
+	}
+	return encodeVectorText{}
+}
+
+type encodeVectorBinary struct{}
+
+func (encodeVectorBinary) Encode(value any, buf []byte) ([]byte, error) {
+	v := value.([]float32)
+	if v == nil {
+		return nil, nil
+	}
+	if len(v) > math.MaxUint16 {
+		return nil, fmt.Errorf("vector has %d dimensions, at most %d allowed", len(v), math.MaxUint16)
+	}
+	buf = binary.BigEndian.AppendUint16(buf, uint16(len(v)))
+	buf = binary.BigEndian.AppendUint16(buf, 0)
+	for _, f := range v {
+		buf = binary.BigEndian.AppendUint32(buf, math.Float32bits(f))
+	}
+	return buf, nil
+}
+
+type encodeVectorText struct{}
+
+func (encodeVectorText) Encode(value any, buf []byte) ([]byte, error) {
+	v := value.([]float32)
+	if v == nil {
+		return nil, nil
+	}
+	buf = append(buf, '[')
+	for i, f := range v {
+		if i > 0 {
+			buf = append(buf, ',')
+		}
+		buf = strconv.AppendFloat(buf, float64(f), 'g', -1, 32)
+	}
+	return append(buf, ']'), nil
+}
+
+func (vectorCodec) PlanScan(m *pgtype.Map, oid uint32, format int16, target any) pgtype.ScanPlan {
+	if _, ok := target.(*[]float32); !ok {
+		return nil
+	}
+	return scanVector{format: format}
+}
+
+type scanVector struct{ format int16 }
+
+func (p scanVector) Scan(src []byte, target any) error {
+	dst := target.(*[]float32)
+	if src == nil {
+		*dst = nil
+		return nil
+	}
+	v, err := decodeVector(p.format, src)
+	if err != nil {
+		return err
+	}
+	*dst = v
+	return nil
+}
+
+func (vectorCodec) DecodeDatabaseSQLValue(m *pgtype.Map, oid uint32, format int16, src []byte) (driver.Value, error) {
+	if src == nil {
+		return nil, nil
+	}
+	if format == pgtype.TextFormatCode {
+		return string(src), nil
+	}
+	v, err := decodeVector(format, src)
+	if err != nil {
+		return nil, err
+	}
+	text, err := encodeVectorText{}.Encode(v, nil)
+	return string(text), err
+}
+
+func (vectorCodec) DecodeValue(m *pgtype.Map, oid uint32, format int16, src []byte) (any, error) {
+	if src == nil {
+		return nil, nil
+	}
+	return decodeVector(format, src)
+}
+
+// decodeVector decodes a non-NULL vector in either format.
+func decodeVector(format int16, src []byte) ([]float32, error) {
+	if format == pgtype.BinaryFormatCode {
+		if len(src) < 4 {
+			return nil, fmt.Errorf("vector: %d bytes is too short", len(src))
+		}
+		dim := int(binary.BigEndian.Uint16(src))
+		if len(src) != 4+4*dim {
+			return nil, fmt.Errorf("vector: %d bytes for %d dimensions", len(src), dim)
+		}
+		v := make([]float32, dim)
+		for i := range v {
+			v[i] = math.Float32frombits(binary.BigEndian.Uint32(src[4+4*i:]))
+		}
+		return v, nil
+	}
+
+	s := string(src)
+	if len(s) < 2 || s[0] != '[' || s[len(s)-1] != ']' {
+		return nil, fmt.Errorf("vector: invalid text %q", s)
+	}
+	s = s[1 : len(s)-1]
+	if s == "" {
+		return []float32{}, nil
+	}
+	parts := strings.Split(s, ",")
+	v := make([]float32, len(parts))
+	for i, part := range parts {
+		f, err := strconv.ParseFloat(strings.TrimSpace(part), 32)
+		if err != nil {
+			return nil, fmt.Errorf("vector: %w", err)
+		}
+		v[i] = float32(f)
+	}
+	return v, nil
+}
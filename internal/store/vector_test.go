@@ -0,0 +1,88 @@
+package store
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const testVectorOID = 90001
+
+func vectorMap() *pgtype.Map {
+	m := pgtype.NewMap()
+	m.RegisterType(&pgtype.Type{Name: "vector", OID: testVectorOID, Codec: vectorCodec{}})
+	return m
+}
+
+func TestVectorCodec_RoundTrip(t *testing.T) {
+	m := vectorMap()
+	want := []float32{0.1, -2.5, 0, 3e-7}
+
+	for _, format := range []int16{pgtype.BinaryFormatCode, pgtype.TextFormatCode} {
+		buf, err := m.Encode(testVectorOID, format, want, nil)
+		if err != nil {
+			t.Fatalf("format %d: encode: %v", format, err)
+		}
+		var got []float32
+		if err := m.Scan(testVectorOID, format, buf, &got); err != nil {
+			t.Fatalf("format %d: scan: %v", format, err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("format %d: got %v, want %v", format, got, want)
+		}
+	}
+}
+
+func TestVectorCodec_Text(t *testing.T) {
+	m := vectorMap()
+
+	buf, err := m.Encode(testVectorOID, pgtype.TextFormatCode, []float32{1, 0.5, -3}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(buf) != "[1,0.5,-3]" {
+		t.Errorf("expected [1,0.5,-3], got %s", buf)
+	}
+
+	var got []float32
+	if err := m.Scan(testVectorOID, pgtype.TextFormatCode, []byte("[0.25, 1]"), &got); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, []float32{0.25, 1}) {
+		t.Errorf("unexpected vector %v", got)
+	}
+}
+
+func TestVectorCodec_Null(t *testing.T) {
+	m := vectorMap()
+
+	buf, err := m.Encode(testVectorOID, pgtype.BinaryFormatCode, []float32(nil), nil)
+	if err != nil || buf != nil {
+		t.Errorf("expected a nil slice to encode as NULL, got %v, %v", buf, err)
+	}
+
+	got := []float32{1}
+	if err := m.Scan(testVectorOID, pgtype.BinaryFormatCode, nil, &got); err != nil || got != nil {
+		t.Errorf("expected NULL to scan as nil, got %v, %v", got, err)
+	}
+}
+
+func TestVectorCodec_Invalid(t *testing.T) {
+	m := vectorMap()
+
+	for _, tc := range []struct {
+		format int16
+		src    string
+	}{
+		{pgtype.BinaryFormatCode, "\x00"},
+		{pgtype.BinaryFormatCode, "\x00\x02\x00\x00\x00\x00\x00\x00"}, // two dimensions, one value
+		{pgtype.TextFormatCode, "0.1,0.2"},
+		{pgtype.TextFormatCode, "[0.1,x]"},
+	} {
+		var got []float32
+		if err := m.Scan(testVectorOID, tc.format, []byte(tc.src), &got); err == nil {
+			t.Errorf("format %d %q: expected an error, got %v", tc.format, tc.src, got)
+		}
+	}
+}
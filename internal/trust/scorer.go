@@ -1,5 +1,7 @@
 package trust
 
+import "time"
+
 // SignalWeight returns the trust score increment for a given severity.
 func SignalWeight(severity string) float64 {
 	switch severity {
@@ -80,3 +82,13 @@ func clamp(score float64) float64 {
 	}
 	return score
 }
+
+// CurrentScore returns a stored score with decay applied for each whole day
+// since the last signal. A score with no signal yet is returned unchanged.
+func CurrentScore(score, decayRate float64, lastSignal *time.Time, now time.Time) float64 {
+	if lastSignal == nil || !now.After(*lastSignal) {
+		return score
+	}
+	days := int(now.Sub(*lastSignal).Hours() / 24)
+	return DecayScore(score, decayRate, days)
+}
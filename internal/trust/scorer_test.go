@@ -3,6 +3,7 @@ package trust
 import (
 	"math"
 	"testing"
+	"time"
 )
 
 func TestSignalWeight(t *testing.T) {
@@ -247,3 +248,33 @@ func TestDecayScore(t *testing.T) {
 		})
 	}
 }
+
+func TestCurrentScore(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	at := func(d time.Duration) *time.Time {
+		ts := now.Add(-d)
+		return &ts
+	}
+
+	tests := []struct {
+		name       string
+		score      float64
+		lastSignal *time.Time
+		want       float64
+	}{
+		{"never signalled", 0.8, nil, 0.8},
+		{"same day", 0.8, at(3 * time.Hour), 0.8},
+		{"partial days round down", 1.0, at(47 * time.Hour), 0.99},
+		{"7 days stale", 1.0, at(7 * 24 * time.Hour), 0.9321},
+		{"future signal ignored", 0.8, at(-time.Hour), 0.8},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := CurrentScore(tt.score, 0.01, tt.lastSignal, now)
+			if math.Abs(got-tt.want) > 0.001 {
+				t.Errorf("CurrentScore(%f) = %f, want %f", tt.score, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,36 @@
+-- 007_autonomy_modes.sql
+-- Per-category/severity autonomy modes for Dredd: shadow -> advise -> autonomous.
+
+create table if not exists autonomy_modes (
+  id uuid primary key default gen_random_uuid(),
+  category text not null,
+  severity text not null,              -- routine | significant | critical
+  mode text not null default 'shadow', -- shadow | advise | autonomous
+  pinned boolean not null default false, -- manual override; skipped by automatic evaluation
+  updated_at timestamptz not null default now(),
+
+  unique(category, severity)
+);
+
+create table if not exists autonomy_transitions (
+  id uuid primary key default gen_random_uuid(),
+  category text not null,
+  severity text not null,
+  from_mode text not null,
+  to_mode text not null,
+  trigger text not null,               -- promotion | demotion | override
+  actor text not null default 'dredd',
+  reason text,
+  trust_score float,
+  total_decisions integer,
+  created_at timestamptz not null default now()
+);
+
+create index if not exists idx_autonomy_transitions_key on autonomy_transitions(category, severity, created_at desc);
+
+-- RLS
+alter table autonomy_modes enable row level security;
+alter table autonomy_transitions enable row level security;
+
+create policy "Service role full access" on autonomy_modes for all using (auth.role() = 'service_role');
+create policy "Service role full access" on autonomy_transitions for all using (auth.role() = 'service_role');
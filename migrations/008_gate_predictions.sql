@@ -0,0 +1,26 @@
+-- 008_gate_predictions.sql
+-- Shadow-mode gate verdict predictions, scored against the human verdict.
+
+create table if not exists gate_predictions (
+  id uuid primary key default gen_random_uuid(),
+  item_id text not null,
+  stage text not null,
+  category text not null default 'gate_approval',
+  evidence text not null,               -- all evidence seen for this item/stage so far
+  predicted_verdict text not null,      -- approved | changes_requested | blocked
+  confidence float not null default 0,
+  rationale text,
+  neighbours integer not null default 0,
+  actual_verdict text,
+  agreed boolean,
+  predicted_at timestamptz not null default now(),
+  resolved_at timestamptz
+);
+
+create index if not exists idx_gate_predictions_item on gate_predictions(item_id, stage);
+create index if not exists idx_gate_predictions_resolved on gate_predictions(stage, resolved_at);
+
+-- RLS
+alter table gate_predictions enable row level security;
+
+create policy "Service role full access" on gate_predictions for all using (auth.role() = 'service_role');
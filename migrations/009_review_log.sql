@@ -0,0 +1,26 @@
+-- 009_review_log.sql
+-- Persist the agent and signal type of each decision so reviews can be replayed
+-- from the database, and log every review verdict regardless of channel.
+
+ALTER TABLE decisions
+  ADD COLUMN IF NOT EXISTS agent_id text,
+  ADD COLUMN IF NOT EXISTS signal_type text;
+
+create table if not exists review_log (
+  id uuid primary key default gen_random_uuid(),
+  target_kind text not null,            -- decision | pattern
+  target_id uuid not null,
+  verdict text not null,                -- confirmed | rejected | skipped
+  note text,
+  reviewer text,
+  channel text not null,                -- slack | api
+  created_at timestamptz not null default now()
+);
+
+create index if not exists idx_review_log_target on review_log(target_kind, target_id);
+create index if not exists idx_review_log_created on review_log(created_at);
+
+-- RLS
+alter table review_log enable row level security;
+
+create policy "Service role full access" on review_log for all using (auth.role() = 'service_role');
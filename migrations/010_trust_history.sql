@@ -0,0 +1,22 @@
+-- 010_trust_history.sql
+-- Append-only log of every trust score change, written alongside agent_trust upserts.
+
+create table if not exists trust_history (
+  id uuid primary key default gen_random_uuid(),
+  agent_id text not null,
+  category text not null,
+  severity text not null,
+  previous_score float,                 -- null for the first signal
+  trust_score float not null,
+  total_decisions integer not null,
+  correct_decisions integer not null,
+  critical_failures integer not null,
+  created_at timestamptz not null default now()
+);
+
+create index if not exists idx_trust_history_agent on trust_history(agent_id, created_at);
+
+-- RLS
+alter table trust_history enable row level security;
+
+create policy "Service role full access" on trust_history for all using (auth.role() = 'service_role');
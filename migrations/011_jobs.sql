@@ -0,0 +1,22 @@
+-- 011_jobs.sql
+-- Background jobs (dedup runs submitted over the API) with their progress and results.
+
+create table if not exists jobs (
+  id uuid primary key default gen_random_uuid(),
+  kind text not null,                   -- dedup
+  status text not null,                 -- queued | running | succeeded | failed | cancelled
+  params jsonb,
+  progress jsonb,
+  result jsonb,
+  error text,
+  created_at timestamptz not null default now(),
+  started_at timestamptz,
+  finished_at timestamptz
+);
+
+create index if not exists idx_jobs_status on jobs(status);
+
+-- RLS
+alter table jobs enable row level security;
+
+create policy "Service role full access" on jobs for all using (auth.role() = 'service_role');
@@ -0,0 +1,32 @@
+-- 012_dedup_runs.sql
+-- Record every executed dedup run and its clusters so merges can be restored.
+
+create table if not exists dedup_runs (
+  id uuid primary key default gen_random_uuid(),
+  table_name text not null,             -- reasoning_patterns | decisions
+  threshold float not null,
+  created_at timestamptz not null default now(),
+  restored_at timestamptz
+);
+
+create table if not exists dedup_run_clusters (
+  id uuid primary key default gen_random_uuid(),
+  run_id uuid not null references dedup_runs(id) on delete cascade,
+  table_name text not null,
+  survivor_id uuid not null,
+  deduped_ids uuid[] not null,
+  survivor_snapshot jsonb not null,     -- survivor row before the merge, embeddings omitted
+  created_at timestamptz not null default now(),
+  restored_at timestamptz
+);
+
+create index if not exists idx_dedup_run_clusters_run on dedup_run_clusters(run_id);
+create index if not exists idx_dedup_run_clusters_survivor on dedup_run_clusters(survivor_id);
+create index if not exists idx_dedup_run_clusters_deduped on dedup_run_clusters using gin (deduped_ids);
+
+-- RLS
+alter table dedup_runs enable row level security;
+alter table dedup_run_clusters enable row level security;
+
+create policy "Service role full access" on dedup_runs for all using (auth.role() = 'service_role');
+create policy "Service role full access" on dedup_run_clusters for all using (auth.role() = 'service_role');
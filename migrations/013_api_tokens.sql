@@ -0,0 +1,20 @@
+-- 013_api_tokens.sql
+-- Named, scoped API tokens. Only the SHA-256 of each token is stored.
+
+create table if not exists api_tokens (
+  id uuid primary key default gen_random_uuid(),
+  name text not null,
+  token_hash text not null unique,
+  scopes text[] not null,               -- read | review | extract | dedup:execute | refinement:publish | autonomy:write | *
+  created_at timestamptz not null default now(),
+  last_used_at timestamptz,
+  revoked_at timestamptz
+);
+
+-- Names are unique among active tokens, so a revoked name can be reissued.
+create unique index if not exists idx_api_tokens_active_name on api_tokens(name) where revoked_at is null;
+
+-- RLS
+alter table api_tokens enable row level security;
+
+create policy "Service role full access" on api_tokens for all using (auth.role() = 'service_role');
@@ -0,0 +1,19 @@
+-- 014_schema_migrations.sql
+-- Applied migration versions, checked by /ready so an instance whose schema
+-- is behind the binary reports not ready. Every migration from here on
+-- records its own version.
+
+create table if not exists schema_migrations (
+  version int primary key,
+  applied_at timestamptz not null default now()
+);
+
+-- 001-013 predate this table; applying 014 implies they were applied.
+insert into schema_migrations (version)
+select generate_series(1, 14)
+on conflict (version) do nothing;
+
+-- RLS
+alter table schema_migrations enable row level security;
+
+create policy "Service role full access" on schema_migrations for all using (auth.role() = 'service_role');
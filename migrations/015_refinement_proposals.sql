@@ -0,0 +1,28 @@
+-- 015_refinement_proposals.sql
+-- Persist refinement proposals so they can be accepted, rejected and applied,
+-- and so later scans do not re-propose clusters already under review.
+
+create table if not exists refinement_proposals (
+  id uuid primary key default gen_random_uuid(),
+  pattern_type text not null,
+  pattern_ids uuid[] not null,           -- member reasoning_patterns
+  target_soul_slug text not null,
+  target_section text not null default '',
+  proposed_change text not null,
+  status text not null default 'proposed'
+    check (status in ('proposed', 'accepted', 'rejected', 'applied')),
+  reviewer text,
+  note text,
+  created_at timestamptz not null default now(),
+  updated_at timestamptz not null default now()
+);
+
+create index if not exists idx_refinement_proposals_status on refinement_proposals(status, created_at desc);
+create index if not exists idx_refinement_proposals_patterns on refinement_proposals using gin (pattern_ids);
+
+insert into schema_migrations (version) values (15) on conflict (version) do nothing;
+
+-- RLS
+alter table refinement_proposals enable row level security;
+
+create policy "Service role full access" on refinement_proposals for all using (auth.role() = 'service_role');
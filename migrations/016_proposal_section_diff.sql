@@ -0,0 +1,7 @@
+-- 016_proposal_section_diff.sql
+-- Store the LLM-drafted edit of the target SOUL section with each refinement
+-- proposal, as a unified diff against the document it was drafted from.
+
+alter table refinement_proposals add column if not exists section_diff text;
+
+insert into schema_migrations (version) values (16) on conflict (version) do nothing;
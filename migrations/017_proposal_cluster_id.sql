@@ -0,0 +1,9 @@
+-- 017_proposal_cluster_id.sql
+-- Record the stable refinement cluster ID each proposal was made from, so a
+-- cluster is recognised across scans even as new patterns join it.
+
+alter table refinement_proposals add column if not exists cluster_id text;
+
+create index if not exists idx_refinement_proposals_cluster on refinement_proposals(cluster_id);
+
+insert into schema_migrations (version) values (17) on conflict (version) do nothing;
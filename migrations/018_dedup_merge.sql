@@ -0,0 +1,19 @@
+-- 018_dedup_merge.sql
+-- Executed dedup merges carry the deduped records' evidence into the survivor:
+-- how many records it stands for and the sessions they came from. Each merged
+-- cluster records the tag rows it added and the rows it re-pointed at the
+-- survivor, so a restore can undo them.
+
+alter table reasoning_patterns
+  add column if not exists occurrence_count int not null default 1,
+  add column if not exists session_refs text[] not null default '{}';   -- contributing sessions once merged
+
+alter table decisions
+  add column if not exists occurrence_count int not null default 1,
+  add column if not exists session_refs text[] not null default '{}';
+
+alter table dedup_run_clusters
+  add column if not exists added_tag_ids uuid[] not null default '{}',  -- decision_tags rows added to the survivor
+  add column if not exists moved_rows jsonb not null default '[]';       -- [{"table", "id", "from"}] re-pointed at the survivor
+
+insert into schema_migrations (version) values (18) on conflict (version) do nothing;
@@ -0,0 +1,11 @@
+-- 019_dedup_merge_deltas.sql
+-- Each merged cluster records what it added to the survivor, so a restore
+-- undoes only that and keeps whatever the survivor gained since: later merges,
+-- occurrences recorded at write time, reviews.
+
+alter table dedup_run_clusters
+  add column if not exists added_occurrences int not null default 0,        -- occurrences folded in from the deduped records
+  add column if not exists added_session_refs text[] not null default '{}', -- sessions the survivor did not already list
+  add column if not exists added_tags text[] not null default '{}';         -- inline tags the survivor did not already have
+
+insert into schema_migrations (version) values (19) on conflict (version) do nothing;
@@ -0,0 +1,8 @@
+-- 020_decision_vector_indexes.sql
+-- Nearest-neighbour indexes for precedent search and write-time recurrence
+-- lookups, which order by cosine distance to one column at a time.
+
+create index if not exists idx_decisions_embedding on decisions using ivfflat (embedding vector_cosine_ops) with (lists = 100);
+create index if not exists idx_context_situation_embedding on decision_context using ivfflat (situation_embedding vector_cosine_ops) with (lists = 100);
+
+insert into schema_migrations (version) values (20) on conflict (version) do nothing;
@@ -0,0 +1,12 @@
+-- 021_dedup_run_finished.sql
+-- Runs are marked finished when they complete, so one that failed or was
+-- cancelled part-way does not move the incremental watermark past records it
+-- never scanned.
+
+alter table dedup_runs add column if not exists finished_at timestamptz;
+
+-- Runs before this migration only recorded successful passes' clusters; treat
+-- them as finished so incremental runs carry on from where they were.
+update dedup_runs set finished_at = created_at where finished_at is null;
+
+insert into schema_migrations (version) values (21) on conflict (version) do nothing;
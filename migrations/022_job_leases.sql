@@ -0,0 +1,10 @@
+-- 022_job_leases.sql
+-- Jobs are leased to the process running them, which renews the lease while
+-- the job runs. Only jobs whose lease has expired are abandoned, so replicas
+-- leave each other's jobs alone, and any replica can ask a job's owner to
+-- cancel it.
+
+alter table jobs add column if not exists lease_expires_at timestamptz;
+alter table jobs add column if not exists cancel_requested boolean not null default false;
+
+insert into schema_migrations (version) values (22) on conflict (version) do nothing;
@@ -0,0 +1,7 @@
+-- 023_drop_survivor_snapshot.sql
+-- Restores undo a merge from the deltas recorded with each cluster (019), so
+-- the survivor snapshot taken before every merge is no longer read.
+
+alter table dedup_run_clusters drop column if exists survivor_snapshot;
+
+insert into schema_migrations (version) values (23) on conflict (version) do nothing;
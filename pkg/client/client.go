@@ -0,0 +1,469 @@
+// Package client is a Go client for the dredd HTTP API. The contract it
+// implements is published at /api/v1/openapi.json.
+package client
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Client calls a dredd server.
+type Client struct {
+	baseURL string
+	token   string
+	http    *http.Client
+}
+
+// Option configures a Client.
+type Option func(*Client)
+
+// WithHTTPClient replaces the default HTTP client (30s timeout).
+func WithHTTPClient(hc *http.Client) Option {
+	return func(c *Client) { c.http = hc }
+}
+
+// New creates a client for the server at baseURL (e.g. http://dredd:8750),
+// authenticating with token when it is non-empty.
+func New(baseURL, token string, opts ...Option) *Client {
+	c := &Client{
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		token:   token,
+		http:    &http.Client{Timeout: 30 * time.Second},
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// Error is a non-2xx response from the server.
+type Error struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("dredd api error %d: %s", e.StatusCode, e.Message)
+}
+
+// IsNotFound reports whether err is a 404 from the server.
+func IsNotFound(err error) bool {
+	e, ok := err.(*Error)
+	return ok && e.StatusCode == http.StatusNotFound
+}
+
+func (c *Client) do(ctx context.Context, method, path string, query url.Values, body, out any) error {
+	u := c.baseURL + path
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+
+	var reader io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("marshal request: %w", err)
+		}
+		reader = bytes.NewReader(b)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, u, reader)
+	if err != nil {
+		return fmt.Errorf("create request: %w", err)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return fmt.Errorf("api call: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("read response: %w", err)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return apiError(resp.StatusCode, respBody)
+	}
+
+	if out == nil {
+		return nil
+	}
+	if err := json.Unmarshal(respBody, out); err != nil {
+		return fmt.Errorf("unmarshal response: %w", err)
+	}
+	return nil
+}
+
+// apiError builds an *Error from a non-2xx response body.
+func apiError(status int, body []byte) error {
+	var errResp ErrorResponse
+	if json.Unmarshal(body, &errResp) == nil && errResp.Error != "" {
+		return &Error{StatusCode: status, Message: errResp.Error}
+	}
+	return &Error{StatusCode: status, Message: strings.TrimSpace(string(body))}
+}
+
+func get[T any](ctx context.Context, c *Client, path string, query url.Values) (*T, error) {
+	var out T
+	if err := c.do(ctx, http.MethodGet, path, query, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func send[T any](ctx context.Context, c *Client, method, path string, body any) (*T, error) {
+	var out T
+	if err := c.do(ctx, method, path, nil, body, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// Health checks that the server is up.
+func (c *Client) Health(ctx context.Context) (*HealthResponse, error) {
+	return get[HealthResponse](ctx, c, "/health", nil)
+}
+
+// Ready returns the readiness of each server dependency. When the server is
+// not ready it answers 503; the breakdown is still returned, along with an
+// *Error.
+func (c *Client) Ready(ctx context.Context) (*ReadyResponse, error) {
+	res, err := get[ReadyResponse](ctx, c, "/ready", nil)
+	var apiErr *Error
+	if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusServiceUnavailable {
+		var out ReadyResponse
+		if json.Unmarshal([]byte(apiErr.Message), &out) == nil {
+			return &out, err
+		}
+	}
+	return res, err
+}
+
+// Status returns the agent status.
+func (c *Client) Status(ctx context.Context) (*StatusResponse, error) {
+	return get[StatusResponse](ctx, c, "/api/v1/dredd/status", nil)
+}
+
+// OpenAPI returns the server's OpenAPI document.
+func (c *Client) OpenAPI(ctx context.Context) (map[string]any, error) {
+	doc, err := get[map[string]any](ctx, c, "/api/v1/openapi.json", nil)
+	if err != nil {
+		return nil, err
+	}
+	return *doc, nil
+}
+
+// SubmitDedup starts a dedup job. Poll it with Job or WaitJob.
+func (c *Client) SubmitDedup(ctx context.Context, req DedupRequest) (*Job, error) {
+	return send[Job](ctx, c, http.MethodPost, "/api/v1/dedup", req)
+}
+
+// Job returns a background job.
+func (c *Client) Job(ctx context.Context, id uuid.UUID) (*Job, error) {
+	return get[Job](ctx, c, "/api/v1/jobs/"+id.String(), nil)
+}
+
+// CancelJob cancels a running job.
+func (c *Client) CancelJob(ctx context.Context, id uuid.UUID) (*Job, error) {
+	return send[Job](ctx, c, http.MethodDelete, "/api/v1/jobs/"+id.String(), nil)
+}
+
+// WaitJob polls a job every interval until it finishes or ctx is done. If
+// progress is non-nil it is called after every poll.
+func (c *Client) WaitJob(ctx context.Context, id uuid.UUID, interval time.Duration, progress func(*Job)) (*Job, error) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		job, err := c.Job(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if progress != nil {
+			progress(job)
+		}
+		if job.Status.Done() {
+			return job, nil
+		}
+		select {
+		case <-ctx.Done():
+			return job, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// DedupRuns lists executed dedup runs, newest first.
+func (c *Client) DedupRuns(ctx context.Context, limit int) (*DedupRunListResponse, error) {
+	q := url.Values{}
+	if limit > 0 {
+		q.Set("limit", fmt.Sprint(limit))
+	}
+	return get[DedupRunListResponse](ctx, c, "/api/v1/dedup/runs", q)
+}
+
+// DedupRun returns a dedup run with its clusters.
+func (c *Client) DedupRun(ctx context.Context, id uuid.UUID) (*DedupRun, error) {
+	return get[DedupRun](ctx, c, "/api/v1/dedup/runs/"+id.String(), nil)
+}
+
+// RestoreDedupRun reverses every merge of a dedup run.
+func (c *Client) RestoreDedupRun(ctx context.Context, id uuid.UUID) (*RestoreResult, error) {
+	return send[RestoreResult](ctx, c, http.MethodPost, "/api/v1/dedup/runs/"+id.String()+"/restore", nil)
+}
+
+// RestoreDedupRecord reverses the most recent merge involving a record.
+func (c *Client) RestoreDedupRecord(ctx context.Context, id uuid.UUID) (*RestoreResult, error) {
+	return send[RestoreResult](ctx, c, http.MethodPost, "/api/v1/dedup/restore", DedupRestoreRequest{ID: id.String()})
+}
+
+// ScanRefinements finds pattern clusters and, unless req.DryRun, publishes
+// refinement proposals.
+func (c *Client) ScanRefinements(ctx context.Context, req ScanRequest) (*ScanResponse, error) {
+	return send[ScanResponse](ctx, c, http.MethodPost, "/api/v1/refinements/scan", req)
+}
+
+// RefinementProposals lists stored refinement proposals, newest first.
+func (c *Client) RefinementProposals(ctx context.Context, query url.Values) (*ProposalListResponse, error) {
+	return get[ProposalListResponse](ctx, c, "/api/v1/refinements/proposals", query)
+}
+
+// RefinementProposal returns one refinement proposal.
+func (c *Client) RefinementProposal(ctx context.Context, id uuid.UUID) (*RefinementProposal, error) {
+	return get[RefinementProposal](ctx, c, "/api/v1/refinements/proposals/"+id.String(), nil)
+}
+
+// AcceptProposal accepts a proposed refinement.
+func (c *Client) AcceptProposal(ctx context.Context, id uuid.UUID, req ProposalReviewRequest) (*RefinementProposal, error) {
+	return send[RefinementProposal](ctx, c, http.MethodPost, "/api/v1/refinements/proposals/"+id.String()+"/accept", req)
+}
+
+// RejectProposal rejects a proposed or accepted refinement. Later scans no
+// longer propose matching clusters.
+func (c *Client) RejectProposal(ctx context.Context, id uuid.UUID, req ProposalReviewRequest) (*RefinementProposal, error) {
+	return send[RefinementProposal](ctx, c, http.MethodPost, "/api/v1/refinements/proposals/"+id.String()+"/reject", req)
+}
+
+// ApplyProposal marks an accepted refinement as applied to its SOUL.
+func (c *Client) ApplyProposal(ctx context.Context, id uuid.UUID, req ProposalReviewRequest) (*RefinementProposal, error) {
+	return send[RefinementProposal](ctx, c, http.MethodPost, "/api/v1/refinements/proposals/"+id.String()+"/apply", req)
+}
+
+// AutonomyModes lists stored autonomy modes.
+func (c *Client) AutonomyModes(ctx context.Context) (*ModeListResponse, error) {
+	return get[ModeListResponse](ctx, c, "/api/v1/autonomy", nil)
+}
+
+// AutonomyMode returns the mode of one category and severity.
+func (c *Client) AutonomyMode(ctx context.Context, category, severity string) (*ModeResponse, error) {
+	return get[ModeResponse](ctx, c, "/api/v1/autonomy/"+url.PathEscape(category)+"/"+url.PathEscape(severity), nil)
+}
+
+// OverrideAutonomyMode sets the mode of one category and severity.
+func (c *Client) OverrideAutonomyMode(ctx context.Context, category, severity string, req OverrideRequest) (*AutonomyTransition, error) {
+	return send[AutonomyTransition](ctx, c, http.MethodPut, "/api/v1/autonomy/"+url.PathEscape(category)+"/"+url.PathEscape(severity), req)
+}
+
+// AutonomyTransitions lists mode transitions. Query parameters are listed in
+// the OpenAPI document (category, severity, limit).
+func (c *Client) AutonomyTransitions(ctx context.Context, query url.Values) (*TransitionListResponse, error) {
+	return get[TransitionListResponse](ctx, c, "/api/v1/autonomy/transitions", query)
+}
+
+// EvaluateAutonomy re-evaluates every mode against current trust.
+func (c *Client) EvaluateAutonomy(ctx context.Context) (*TransitionListResponse, error) {
+	return send[TransitionListResponse](ctx, c, http.MethodPost, "/api/v1/autonomy/evaluate", nil)
+}
+
+// ShadowAgreement returns shadow-mode agreement with gate outcomes (since).
+func (c *Client) ShadowAgreement(ctx context.Context, query url.Values) (*AgreementResponse, error) {
+	return get[AgreementResponse](ctx, c, "/api/v1/shadow/agreement", query)
+}
+
+// ShadowPredictions lists gate predictions (item_id, stage, resolved, limit).
+func (c *Client) ShadowPredictions(ctx context.Context, query url.Values) (*PredictionListResponse, error) {
+	return get[PredictionListResponse](ctx, c, "/api/v1/shadow/predictions", query)
+}
+
+// Decisions lists a page of decisions. Pass the previous page's NextCursor as
+// the cursor parameter to continue.
+func (c *Client) Decisions(ctx context.Context, query url.Values) (*DecisionListResponse, error) {
+	return get[DecisionListResponse](ctx, c, "/api/v1/decisions", query)
+}
+
+// Decision returns one decision with its joined detail.
+func (c *Client) Decision(ctx context.Context, id uuid.UUID) (*Decision, error) {
+	return get[Decision](ctx, c, "/api/v1/decisions/"+id.String(), nil)
+}
+
+// Extract runs extraction on a transcript.
+func (c *Client) Extract(ctx context.Context, req ExtractRequest) (*ProcessResult, error) {
+	return send[ProcessResult](ctx, c, http.MethodPost, "/api/v1/extract", req)
+}
+
+// TrustLeaderboard ranks trust records (category, severity, min_decisions, limit).
+func (c *Client) TrustLeaderboard(ctx context.Context, query url.Values) (*TrustLeaderboardResponse, error) {
+	return get[TrustLeaderboardResponse](ctx, c, "/api/v1/trust", query)
+}
+
+// AgentTrust returns every trust record of an agent.
+func (c *Client) AgentTrust(ctx context.Context, agentID string) (*AgentTrustResponse, error) {
+	return get[AgentTrustResponse](ctx, c, "/api/v1/trust/"+url.PathEscape(agentID), nil)
+}
+
+// TrustHistory returns trust score changes of an agent (category, severity, since, limit).
+func (c *Client) TrustHistory(ctx context.Context, agentID string, query url.Values) (*TrustHistoryResponse, error) {
+	return get[TrustHistoryResponse](ctx, c, "/api/v1/trust/"+url.PathEscape(agentID)+"/history", query)
+}
+
+// Review records a verdict on a decision or pattern.
+func (c *Client) Review(ctx context.Context, req ReviewRequest) (*ReviewResponse, error) {
+	return send[ReviewResponse](ctx, c, http.MethodPost, "/api/v1/reviews", req)
+}
+
+// Reviews returns the review history of a decision or pattern.
+func (c *Client) Reviews(ctx context.Context, kind string, id uuid.UUID) (*ReviewListResponse, error) {
+	return get[ReviewListResponse](ctx, c, "/api/v1/reviews", url.Values{"kind": {kind}, "id": {id.String()}})
+}
+
+// Patterns lists a page of reasoning patterns.
+func (c *Client) Patterns(ctx context.Context, query url.Values) (*PatternListResponse, error) {
+	return get[PatternListResponse](ctx, c, "/api/v1/patterns", query)
+}
+
+// Pattern returns one reasoning pattern.
+func (c *Client) Pattern(ctx context.Context, id uuid.UUID) (*Pattern, error) {
+	return get[Pattern](ctx, c, "/api/v1/patterns/"+id.String(), nil)
+}
+
+// SearchPatterns runs a semantic search over reasoning patterns.
+func (c *Client) SearchPatterns(ctx context.Context, req PatternSearchRequest) (*PatternSearchResponse, error) {
+	return send[PatternSearchResponse](ctx, c, http.MethodPost, "/api/v1/patterns/search", req)
+}
+
+// Precedents finds confirmed decisions similar to a situation.
+func (c *Client) Precedents(ctx context.Context, req PrecedentRequest) (*PrecedentResponse, error) {
+	return send[PrecedentResponse](ctx, c, http.MethodPost, "/api/v1/precedents", req)
+}
+
+// Stream calls fn with each event from the live event stream until ctx is
+// done, the server ends the stream, or fn returns an error, which Stream
+// returns. An empty owner or kinds receives everything. Each event's Data is
+// a *json.RawMessage; unmarshal it into the payload type for its Kind.
+func (c *Client) Stream(ctx context.Context, owner string, kinds []string, fn func(Event) error) error {
+	query := url.Values{}
+	if owner != "" {
+		query.Set("owner", owner)
+	}
+	if len(kinds) > 0 {
+		query.Set("kind", strings.Join(kinds, ","))
+	}
+	u := c.baseURL + "/api/v1/stream"
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return fmt.Errorf("create request: %w", err)
+	}
+	req.Header.Set("Accept", "text/event-stream")
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	// The stream outlives any whole-request timeout; ctx bounds it instead.
+	hc := *c.http
+	hc.Timeout = 0
+	resp, err := hc.Do(req)
+	if err != nil {
+		return fmt.Errorf("api call: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(resp.Body)
+		return apiError(resp.StatusCode, body)
+	}
+
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 64*1024), 4*1024*1024)
+	var data []byte
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case line == "":
+			if len(data) == 0 {
+				continue
+			}
+			evt := Event{Data: &json.RawMessage{}}
+			if err := json.Unmarshal(data, &evt); err != nil {
+				return fmt.Errorf("unmarshal event: %w", err)
+			}
+			data = data[:0]
+			if err := fn(evt); err != nil {
+				return err
+			}
+		case strings.HasPrefix(line, "data:"):
+			if len(data) > 0 {
+				data = append(data, '\n')
+			}
+			data = append(data, strings.TrimPrefix(strings.TrimPrefix(line, "data:"), " ")...)
+		}
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return scanner.Err()
+}
+
+// Dataset copies a JSONL fine-tuning dataset export to w, one DatasetExample
+// per line. query takes the exportDataset parameters (format, split,
+// validation, seed, kind, owner, since, until).
+func (c *Client) Dataset(ctx context.Context, query url.Values, w io.Writer) error {
+	u := c.baseURL + "/api/v1/dataset"
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return fmt.Errorf("create request: %w", err)
+	}
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return fmt.Errorf("api call: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(resp.Body)
+		return apiError(resp.StatusCode, body)
+	}
+	if _, err := io.Copy(w, resp.Body); err != nil {
+		return fmt.Errorf("read response: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,185 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/MikeSquared-Agency/dredd/internal/api"
+)
+
+// specServer serves every documented operation with an empty object and
+// records which operations were called.
+func specServer(t *testing.T) (*httptest.Server, map[string]bool) {
+	called := map[string]bool{}
+	router := chi.NewRouter()
+	for _, op := range api.Operations {
+		op := op
+		router.MethodFunc(op.Method, op.Path, func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("Authorization") != "Bearer tok" {
+				t.Errorf("%s: expected bearer token", op.ID)
+			}
+			called[op.ID] = true
+			w.Header().Set("Content-Type", "application/json")
+			if op.Status != 0 {
+				w.WriteHeader(op.Status)
+			}
+			if op.ID == "getJob" {
+				w.Write([]byte(`{"status":"succeeded"}`))
+				return
+			}
+			w.Write([]byte(`{}`))
+		})
+	}
+	router.NotFound(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("client called undocumented route %s %s", r.Method, r.URL.Path)
+		http.NotFound(w, r)
+	})
+	srv := httptest.NewServer(router)
+	t.Cleanup(srv.Close)
+	return srv, called
+}
+
+func TestClient_CoversOpenAPI(t *testing.T) {
+	srv, called := specServer(t)
+	c := New(srv.URL, "tok")
+	ctx := context.Background()
+	id := uuid.New()
+
+	calls := []func() error{
+		func() error { _, err := c.Health(ctx); return err },
+		func() error { return c.Stream(ctx, "", nil, func(Event) error { return nil }) },
+		func() error { _, err := c.Ready(ctx); return err },
+		func() error { return c.Dataset(ctx, nil, io.Discard) },
+		func() error { _, err := c.Status(ctx); return err },
+		func() error { _, err := c.OpenAPI(ctx); return err },
+		func() error { _, err := c.SubmitDedup(ctx, DedupRequest{}); return err },
+		func() error { _, err := c.Job(ctx, id); return err },
+		func() error { _, err := c.CancelJob(ctx, id); return err },
+		func() error { _, err := c.DedupRuns(ctx, 10); return err },
+		func() error { _, err := c.DedupRun(ctx, id); return err },
+		func() error { _, err := c.RestoreDedupRun(ctx, id); return err },
+		func() error { _, err := c.RestoreDedupRecord(ctx, id); return err },
+		func() error { _, err := c.ScanRefinements(ctx, ScanRequest{}); return err },
+		func() error { _, err := c.RefinementProposals(ctx, nil); return err },
+		func() error { _, err := c.RefinementProposal(ctx, id); return err },
+		func() error { _, err := c.AcceptProposal(ctx, id, ProposalReviewRequest{}); return err },
+		func() error { _, err := c.RejectProposal(ctx, id, ProposalReviewRequest{}); return err },
+		func() error { _, err := c.ApplyProposal(ctx, id, ProposalReviewRequest{}); return err },
+		func() error { _, err := c.AutonomyModes(ctx); return err },
+		func() error { _, err := c.AutonomyMode(ctx, "gate_approval", "routine"); return err },
+		func() error {
+			_, err := c.OverrideAutonomyMode(ctx, "gate_approval", "routine", OverrideRequest{Mode: "shadow"})
+			return err
+		},
+		func() error { _, err := c.AutonomyTransitions(ctx, nil); return err },
+		func() error { _, err := c.EvaluateAutonomy(ctx); return err },
+		func() error { _, err := c.ShadowAgreement(ctx, nil); return err },
+		func() error { _, err := c.ShadowPredictions(ctx, nil); return err },
+		func() error { _, err := c.Decisions(ctx, url.Values{"domain": {"infra"}}); return err },
+		func() error { _, err := c.Decision(ctx, id); return err },
+		func() error { _, err := c.Extract(ctx, ExtractRequest{}); return err },
+		func() error { _, err := c.TrustLeaderboard(ctx, nil); return err },
+		func() error { _, err := c.AgentTrust(ctx, "kai"); return err },
+		func() error { _, err := c.TrustHistory(ctx, "kai", nil); return err },
+		func() error { _, err := c.Review(ctx, ReviewRequest{}); return err },
+		func() error { _, err := c.Reviews(ctx, "decision", id); return err },
+		func() error { _, err := c.Patterns(ctx, nil); return err },
+		func() error { _, err := c.Pattern(ctx, id); return err },
+		func() error { _, err := c.SearchPatterns(ctx, PatternSearchRequest{}); return err },
+		func() error { _, err := c.Precedents(ctx, PrecedentRequest{}); return err },
+	}
+	for i, call := range calls {
+		if err := call(); err != nil {
+			t.Errorf("call %d: %v", i, err)
+		}
+	}
+
+	// scanRefinementsDryRun is the GET form of ScanRefinements{DryRun: true}.
+	called["scanRefinementsDryRun"] = true
+	for _, op := range api.Operations {
+		if !called[op.ID] {
+			t.Errorf("no client method for %s (%s %s)", op.ID, op.Method, op.Path)
+		}
+	}
+}
+
+func TestClient_Error(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"error":"decision not found"}`, http.StatusNotFound)
+	}))
+	defer srv.Close()
+
+	_, err := New(srv.URL, "").Decision(context.Background(), uuid.New())
+	if !IsNotFound(err) {
+		t.Fatalf("expected not found error, got %v", err)
+	}
+	if err.(*Error).Message != "decision not found" {
+		t.Errorf("unexpected message %q", err.(*Error).Message)
+	}
+}
+
+func TestClient_WaitJob(t *testing.T) {
+	polls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		polls++
+		if polls < 3 {
+			w.Write([]byte(`{"status":"running","progress":{"stage":"merge","done":1,"total":2}}`))
+			return
+		}
+		w.Write([]byte(`{"status":"succeeded","result":{"deduped":4}}`))
+	}))
+	defer srv.Close()
+
+	var seen int
+	job, err := New(srv.URL, "").WaitJob(context.Background(), uuid.New(), time.Millisecond, func(*Job) { seen++ })
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if job.Status != JobSucceeded || string(job.Result) != `{"deduped":4}` {
+		t.Errorf("unexpected job %+v", job)
+	}
+	if seen != 3 {
+		t.Errorf("expected 3 progress callbacks, got %d", seen)
+	}
+}
+
+func TestClient_Stream(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("kind") != "trust.changed,review.applied" {
+			t.Errorf("unexpected kind filter %q", r.URL.Query().Get("kind"))
+		}
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Write([]byte(": connected\n\n"))
+		w.Write([]byte("id: 1\nevent: trust.changed\ndata: {\"id\":1,\"kind\":\"trust.changed\",\"data\":{\"agent_id\":\"dev\",\"score\":0.5}}\n\n"))
+		w.Write([]byte("id: 2\nevent: review.applied\ndata: {\"id\":2,\"kind\":\"review.applied\",\"data\":{\"verdict\":\"confirmed\"}}\n\n"))
+	}))
+	defer srv.Close()
+
+	var got []Event
+	err := New(srv.URL, "").Stream(context.Background(), "", []string{EventTrustChanged, EventReviewApplied}, func(evt Event) error {
+		got = append(got, evt)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got) != 2 || got[0].Kind != EventTrustChanged || got[1].ID != 2 {
+		t.Fatalf("unexpected events %+v", got)
+	}
+
+	var trust TrustChanged
+	if err := json.Unmarshal(*got[0].Data.(*json.RawMessage), &trust); err != nil {
+		t.Fatalf("failed to decode payload: %v", err)
+	}
+	if trust.AgentID != "dev" || trust.Score != 0.5 {
+		t.Errorf("unexpected payload %+v", trust)
+	}
+}
@@ -0,0 +1,671 @@
+package client
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Request and response types mirror the JSON the server's handlers encode.
+// They are declared here rather than re-exported from the server packages so
+// that importing the client does not pull in the server's database, message
+// bus and model dependencies; types_test.go round-trips each one against its
+// server counterpart so the two cannot drift.
+
+// Requests.
+type (
+	DedupRequest struct {
+		Threshold   float64 `json:"threshold"`
+		Execute     bool    `json:"execute"`
+		Table       string  `json:"table"`
+		Neighbours  int     `json:"neighbours,omitempty"`
+		Incremental bool    `json:"incremental,omitempty"`
+	}
+
+	DedupRestoreRequest struct {
+		ID string `json:"id"`
+	}
+
+	ScanRequest struct {
+		Since     *string  `json:"since,omitempty"`
+		SOULSlug  *string  `json:"soul_slug,omitempty"`
+		Threshold *float64 `json:"threshold,omitempty"`
+		MinSize   *int     `json:"min_size,omitempty"`
+		DryRun    bool     `json:"dry_run"`
+	}
+
+	ProposalReviewRequest struct {
+		Reviewer string `json:"reviewer,omitempty"`
+		Note     string `json:"note,omitempty"`
+	}
+
+	OverrideRequest struct {
+		Mode   string `json:"mode"`
+		Actor  string `json:"actor"`
+		Reason string `json:"reason"`
+		Pinned *bool  `json:"pinned,omitempty"`
+	}
+
+	ExtractRequest struct {
+		Transcript  string `json:"transcript,omitempty"`
+		SessionID   string `json:"session_id,omitempty"`
+		OwnerUUID   string `json:"owner_uuid"`
+		SessionRef  string `json:"session_ref"`
+		Title       string `json:"title,omitempty"`
+		Surface     string `json:"surface,omitempty"`
+		Duration    string `json:"duration,omitempty"`
+		ModelID     string `json:"model_id,omitempty"`
+		ModelTier   string `json:"model_tier,omitempty"`
+		Persist     bool   `json:"persist"`
+		PostToSlack bool   `json:"post_to_slack"`
+	}
+
+	ReviewRequest struct {
+		Kind     string `json:"kind"`
+		ID       string `json:"id"`
+		Verdict  string `json:"verdict"`
+		Note     string `json:"note,omitempty"`
+		Reviewer string `json:"reviewer,omitempty"`
+	}
+
+	PatternSearchRequest struct {
+		Query         string    `json:"query"`
+		Embedding     []float32 `json:"embedding,omitempty"`
+		PatternType   string    `json:"pattern_type,omitempty"`
+		ReviewStatus  string    `json:"review_status,omitempty"`
+		Tag           string    `json:"tag,omitempty"`
+		MinConfidence *float64  `json:"min_confidence,omitempty"`
+		Limit         int       `json:"limit,omitempty"`
+		MinSimilarity float64   `json:"min_similarity,omitempty"`
+	}
+
+	PrecedentRequest struct {
+		Situation     string    `json:"situation"`
+		Embedding     []float32 `json:"embedding,omitempty"`
+		Domain        string    `json:"domain,omitempty"`
+		Category      string    `json:"category,omitempty"`
+		Limit         int       `json:"limit,omitempty"`
+		MinSimilarity float64   `json:"min_similarity,omitempty"`
+	}
+)
+
+// Responses.
+type (
+	ErrorResponse struct {
+		Error string `json:"error"`
+	}
+
+	HealthResponse struct {
+		Status string `json:"status"`
+	}
+
+	ReadyResponse struct {
+		Status string                 `json:"status"`
+		Checks map[string]CheckResult `json:"checks"`
+	}
+
+	CheckResult struct {
+		Status    string `json:"status"`
+		Error     string `json:"error,omitempty"`
+		LatencyMS int64  `json:"latency_ms"`
+	}
+
+	StatusResponse struct {
+		Agent  string `json:"agent"`
+		Status string `json:"status"`
+	}
+
+	DedupRunListResponse struct {
+		Runs []DedupRun `json:"runs"`
+	}
+
+	ScanResponse struct {
+		Clusters   []PatternCluster `json:"clusters"`
+		Count      int              `json:"count"`
+		Suppressed int              `json:"suppressed"`
+		DryRun     bool             `json:"dry_run"`
+	}
+
+	ProposalListResponse struct {
+		Proposals []RefinementProposal `json:"proposals"`
+		Count     int                  `json:"count"`
+	}
+
+	ModeResponse struct {
+		Category        string         `json:"category"`
+		Severity        string         `json:"severity"`
+		Mode            string         `json:"mode"`
+		Pinned          bool           `json:"pinned"`
+		AllowedToDecide bool           `json:"allowed_to_decide"`
+		Policy          AutonomyPolicy `json:"policy"`
+	}
+
+	ModeListResponse struct {
+		DefaultMode string         `json:"default_mode"`
+		Modes       []ModeResponse `json:"modes"`
+	}
+
+	TransitionListResponse struct {
+		Transitions []AutonomyTransition `json:"transitions"`
+		Count       int                  `json:"count"`
+	}
+
+	AgreementResponse struct {
+		Stages        []AgreementStat `json:"stages"`
+		Resolved      int             `json:"resolved"`
+		Agreed        int             `json:"agreed"`
+		AgreementRate float64         `json:"agreement_rate"`
+	}
+
+	PredictionListResponse struct {
+		Predictions []GatePrediction `json:"predictions"`
+		Count       int              `json:"count"`
+	}
+
+	DecisionListResponse struct {
+		Decisions  []Decision `json:"decisions"`
+		Count      int        `json:"count"`
+		NextCursor string     `json:"next_cursor,omitempty"`
+	}
+
+	TrustView struct {
+		TrustRecord
+		CurrentScore float64 `json:"current_score"`
+	}
+
+	TrustLeaderboardResponse struct {
+		Leaderboard []TrustView `json:"leaderboard"`
+		Count       int         `json:"count"`
+	}
+
+	AgentTrustResponse struct {
+		AgentID        string      `json:"agent_id"`
+		CurrentScore   float64     `json:"current_score"`
+		TotalDecisions int         `json:"total_decisions"`
+		Records        []TrustView `json:"records"`
+	}
+
+	TrustHistoryResponse struct {
+		AgentID string              `json:"agent_id"`
+		History []TrustHistoryEntry `json:"history"`
+		Count   int                 `json:"count"`
+	}
+
+	ReviewResponse struct {
+		Kind    string    `json:"kind"`
+		ID      uuid.UUID `json:"id"`
+		Verdict string    `json:"verdict"`
+		Status  string    `json:"status"`
+	}
+
+	ReviewListResponse struct {
+		Reviews []ReviewEntry `json:"reviews"`
+		Count   int           `json:"count"`
+	}
+
+	PatternListResponse struct {
+		Patterns   []Pattern `json:"patterns"`
+		Count      int       `json:"count"`
+		NextCursor string    `json:"next_cursor,omitempty"`
+	}
+
+	PatternSearchResponse struct {
+		Patterns []Pattern `json:"patterns"`
+		Count    int       `json:"count"`
+	}
+
+	PrecedentResponse struct {
+		Precedents []Decision `json:"precedents"`
+		Count      int        `json:"count"`
+	}
+)
+
+// Records embedded in responses.
+type (
+	JobStatus string
+
+	Job struct {
+		ID         uuid.UUID       `json:"id"`
+		Kind       string          `json:"kind"`
+		Status     JobStatus       `json:"status"`
+		Params     json.RawMessage `json:"params,omitempty"`
+		Progress   *JobProgress    `json:"progress,omitempty"`
+		Result     json.RawMessage `json:"result,omitempty"`
+		Error      string          `json:"error,omitempty"`
+		CreatedAt  time.Time       `json:"created_at"`
+		StartedAt  *time.Time      `json:"started_at,omitempty"`
+		FinishedAt *time.Time      `json:"finished_at,omitempty"`
+	}
+
+	JobProgress struct {
+		Stage string `json:"stage"`
+		Done  int    `json:"done"`
+		Total int    `json:"total"`
+	}
+
+	DedupResult struct {
+		Table      string               `json:"table"`
+		Threshold  float64              `json:"threshold"`
+		Execute    bool                 `json:"execute"`
+		Clusters   int                  `json:"clusters"`
+		TotalItems int                  `json:"total_items"`
+		Deduped    int                  `json:"deduped"`
+		Survivors  int                  `json:"survivors"`
+		RunID      *uuid.UUID           `json:"run_id,omitempty"`
+		Neighbours int                  `json:"neighbours"`
+		Since      *time.Time           `json:"since,omitempty"`
+		Details    []DedupClusterDetail `json:"details,omitempty"`
+	}
+
+	DedupClusterDetail struct {
+		SurvivorID uuid.UUID   `json:"survivor_id"`
+		DedupedIDs []uuid.UUID `json:"deduped_ids"`
+		Size       int         `json:"size"`
+	}
+
+	DedupRun struct {
+		ID         uuid.UUID         `json:"id"`
+		Table      string            `json:"table"`
+		Threshold  float64           `json:"threshold"`
+		Clusters   int               `json:"clusters"`
+		Deduped    int               `json:"deduped"`
+		CreatedAt  time.Time         `json:"created_at"`
+		FinishedAt *time.Time        `json:"finished_at,omitempty"`
+		RestoredAt *time.Time        `json:"restored_at,omitempty"`
+		Details    []DedupRunCluster `json:"details,omitempty"`
+	}
+
+	DedupRunCluster struct {
+		ID          uuid.UUID       `json:"id"`
+		RunID       uuid.UUID       `json:"run_id"`
+		Table       string          `json:"table"`
+		SurvivorID  uuid.UUID       `json:"survivor_id"`
+		DedupedIDs  []uuid.UUID     `json:"deduped_ids"`
+		AddedTagIDs []uuid.UUID     `json:"added_tag_ids,omitempty"`
+		Moved       []DedupMovedRow `json:"moved,omitempty"`
+		Added       DedupMergeDelta `json:"added"`
+		CreatedAt   time.Time       `json:"created_at"`
+		RestoredAt  *time.Time      `json:"restored_at,omitempty"`
+	}
+
+	DedupMovedRow struct {
+		Table string    `json:"table"`
+		ID    uuid.UUID `json:"id"`
+		From  uuid.UUID `json:"from"`
+	}
+
+	DedupMergeDelta struct {
+		Occurrences int      `json:"occurrences"`
+		SessionRefs []string `json:"session_refs,omitempty"`
+		Tags        []string `json:"tags,omitempty"`
+	}
+
+	RestoreResult struct {
+		Clusters []uuid.UUID `json:"clusters"`
+		Restored int         `json:"restored"`
+	}
+
+	PatternCluster struct {
+		ID               string           `json:"id"`
+		PatternType      string           `json:"pattern_type"`
+		Count            int              `json:"count"`
+		Summary          string           `json:"summary"`
+		RepresentativeID string           `json:"representative_id"`
+		Cohesion         float64          `json:"cohesion"`
+		SOULSlug         string           `json:"soul_slug,omitempty"`
+		SOULSection      string           `json:"soul_section"`
+		Patterns         []ClusterPattern `json:"patterns"`
+		ProposalID       string           `json:"proposal_id,omitempty"`
+		Draft            *RefinementDraft `json:"draft,omitempty"`
+	}
+
+	ClusterPattern struct {
+		ID              string    `json:"id"`
+		Summary         string    `json:"summary"`
+		ConversationArc string    `json:"conversation_arc"`
+		Tags            []string  `json:"tags,omitempty"`
+		Confidence      float64   `json:"confidence"`
+		CreatedAt       time.Time `json:"created_at"`
+	}
+
+	RefinementDraft struct {
+		Section   string `json:"section"`
+		Diff      string `json:"diff"`
+		Rationale string `json:"rationale"`
+	}
+
+	RefinementProposal struct {
+		ID             uuid.UUID   `json:"id"`
+		ClusterID      string      `json:"cluster_id,omitempty"`
+		PatternType    string      `json:"pattern_type"`
+		PatternIDs     []uuid.UUID `json:"pattern_ids"`
+		TargetSOULSlug string      `json:"target_soul_slug"`
+		TargetSection  string      `json:"target_section"`
+		ProposedChange string      `json:"proposed_change"`
+		SectionDiff    string      `json:"section_diff,omitempty"`
+		Status         string      `json:"status"`
+		Reviewer       string      `json:"reviewer,omitempty"`
+		Note           string      `json:"note,omitempty"`
+		CreatedAt      time.Time   `json:"created_at"`
+		UpdatedAt      time.Time   `json:"updated_at"`
+	}
+
+	AutonomyPolicy struct {
+		Advise     AutonomyGate `json:"advise"`
+		Autonomous AutonomyGate `json:"autonomous"`
+	}
+
+	AutonomyGate struct {
+		MinTrust     float64 `json:"min_trust"`
+		MinDecisions int     `json:"min_decisions"`
+	}
+
+	AutonomyTransition struct {
+		ID             uuid.UUID `json:"id"`
+		Category       string    `json:"category"`
+		Severity       string    `json:"severity"`
+		FromMode       string    `json:"from_mode"`
+		ToMode         string    `json:"to_mode"`
+		Trigger        string    `json:"trigger"`
+		Actor          string    `json:"actor"`
+		Reason         string    `json:"reason,omitempty"`
+		TrustScore     float64   `json:"trust_score"`
+		TotalDecisions int       `json:"total_decisions"`
+		CreatedAt      time.Time `json:"created_at"`
+	}
+
+	AgreementStat struct {
+		Stage         string  `json:"stage"`
+		Category      string  `json:"category"`
+		Predictions   int     `json:"predictions"`
+		Resolved      int     `json:"resolved"`
+		Agreed        int     `json:"agreed"`
+		AgreementRate float64 `json:"agreement_rate"`
+	}
+
+	GatePrediction struct {
+		ID               uuid.UUID  `json:"id"`
+		ItemID           string     `json:"item_id"`
+		Stage            string     `json:"stage"`
+		Category         string     `json:"category"`
+		Evidence         string     `json:"evidence"`
+		PredictedVerdict string     `json:"predicted_verdict"`
+		Confidence       float64    `json:"confidence"`
+		Rationale        string     `json:"rationale"`
+		Neighbours       int        `json:"neighbours"`
+		ActualVerdict    string     `json:"actual_verdict,omitempty"`
+		Agreed           *bool      `json:"agreed,omitempty"`
+		PredictedAt      time.Time  `json:"predicted_at"`
+		ResolvedAt       *time.Time `json:"resolved_at,omitempty"`
+	}
+
+	Decision struct {
+		ID           uuid.UUID          `json:"id"`
+		Domain       string             `json:"domain"`
+		Category     string             `json:"category"`
+		Severity     string             `json:"severity"`
+		Source       string             `json:"source"`
+		DecidedBy    uuid.UUID          `json:"decided_by"`
+		Summary      string             `json:"summary"`
+		SessionRef   string             `json:"session_ref"`
+		ReviewStatus string             `json:"review_status"`
+		ReviewNote   string             `json:"review_note,omitempty"`
+		ReviewedAt   *time.Time         `json:"reviewed_at,omitempty"`
+		ModelID      string             `json:"model_id,omitempty"`
+		ModelTier    string             `json:"model_tier,omitempty"`
+		AgentID      string             `json:"agent_id,omitempty"`
+		SignalType   string             `json:"signal_type,omitempty"`
+		CreatedAt    time.Time          `json:"created_at"`
+		Occurrences  int                `json:"occurrence_count"`
+		SessionRefs  []string           `json:"session_refs,omitempty"`
+		Situation    string             `json:"situation"`
+		Options      []DecisionOption   `json:"options"`
+		Reasoning    *DecisionReasoning `json:"reasoning,omitempty"`
+		Tags         []string           `json:"tags"`
+		Outcomes     []DecisionOutcome  `json:"outcomes"`
+		Similarity   float64            `json:"similarity,omitempty"`
+	}
+
+	DecisionOption struct {
+		OptionKey  string   `json:"option_key"`
+		ProSignals []string `json:"pro_signals"`
+		ConSignals []string `json:"con_signals"`
+		WasChosen  bool     `json:"was_chosen"`
+	}
+
+	DecisionReasoning struct {
+		Factors       []string `json:"factors"`
+		Tradeoffs     []string `json:"tradeoffs"`
+		ReasoningText string   `json:"reasoning_text"`
+	}
+
+	DecisionOutcome struct {
+		OutcomeText    string     `json:"outcome_text"`
+		OutcomeQuality string     `json:"outcome_quality,omitempty"`
+		MeasuredAt     *time.Time `json:"measured_at,omitempty"`
+	}
+
+	Pattern struct {
+		ID              uuid.UUID  `json:"id"`
+		OwnerUUID       uuid.UUID  `json:"owner_uuid"`
+		SessionRef      string     `json:"session_ref"`
+		PatternType     string     `json:"pattern_type"`
+		Summary         string     `json:"summary"`
+		ConversationArc string     `json:"conversation_arc"`
+		Tags            []string   `json:"tags"`
+		Confidence      float64    `json:"confidence"`
+		ReviewStatus    string     `json:"review_status"`
+		ReviewNote      string     `json:"review_note,omitempty"`
+		ReviewedAt      *time.Time `json:"reviewed_at,omitempty"`
+		CreatedAt       time.Time  `json:"created_at"`
+		Occurrences     int        `json:"occurrence_count"`
+		SessionRefs     []string   `json:"session_refs,omitempty"`
+		Similarity      float64    `json:"similarity,omitempty"`
+	}
+
+	ReviewEntry struct {
+		ID         uuid.UUID `json:"id"`
+		TargetKind string    `json:"target_kind"`
+		TargetID   uuid.UUID `json:"target_id"`
+		Verdict    string    `json:"verdict"`
+		Note       string    `json:"note,omitempty"`
+		Reviewer   string    `json:"reviewer,omitempty"`
+		Channel    string    `json:"channel"`
+		CreatedAt  time.Time `json:"created_at"`
+	}
+
+	TrustRecord struct {
+		ID               uuid.UUID  `json:"id"`
+		AgentID          string     `json:"agent_id"`
+		Category         string     `json:"category"`
+		Severity         string     `json:"severity"`
+		TrustScore       float64    `json:"trust_score"`
+		TotalDecisions   int        `json:"total_decisions"`
+		CorrectDecisions int        `json:"correct_decisions"`
+		CriticalFailures int        `json:"critical_failures"`
+		LastSignalAt     *time.Time `json:"last_signal_at,omitempty"`
+		DecayRate        float64    `json:"decay_rate"`
+		UpdatedAt        time.Time  `json:"updated_at"`
+	}
+
+	TrustHistoryEntry struct {
+		AgentID          string    `json:"agent_id"`
+		Category         string    `json:"category"`
+		Severity         string    `json:"severity"`
+		PreviousScore    *float64  `json:"previous_score"`
+		TrustScore       float64   `json:"trust_score"`
+		TotalDecisions   int       `json:"total_decisions"`
+		CorrectDecisions int       `json:"correct_decisions"`
+		CriticalFailures int       `json:"critical_failures"`
+		CreatedAt        time.Time `json:"created_at"`
+	}
+
+	ProcessResult struct {
+		Result        *ExtractionResult `json:"result"`
+		DecisionIDs   []uuid.UUID       `json:"decision_ids,omitempty"`
+		PatternIDs    []uuid.UUID       `json:"pattern_ids,omitempty"`
+		RecurringIDs  []uuid.UUID       `json:"recurring_ids,omitempty"`
+		SlackHeaderTS string            `json:"slack_header_ts,omitempty"`
+	}
+
+	ExtractionResult struct {
+		SessionRef string             `json:"session_ref"`
+		OwnerUUID  uuid.UUID          `json:"owner_uuid"`
+		Decisions  []DecisionEpisode  `json:"decisions"`
+		Patterns   []ReasoningPattern `json:"patterns"`
+		Styles     []WritingStyle     `json:"styles"`
+	}
+
+	DecisionEpisode struct {
+		Domain        string            `json:"domain"`
+		Category      string            `json:"category"`
+		Severity      string            `json:"severity"`
+		Summary       string            `json:"summary"`
+		SituationText string            `json:"situation_text"`
+		Options       []DecisionOption  `json:"options"`
+		Reasoning     DecisionReasoning `json:"reasoning"`
+		Tags          []string          `json:"tags"`
+		Confidence    float64           `json:"confidence"`
+		AgentID       string            `json:"agent_id,omitempty"`
+		SignalType    string            `json:"signal_type,omitempty"`
+		ModelID       string            `json:"model_id,omitempty"`
+		ModelTier     string            `json:"model_tier,omitempty"`
+	}
+
+	ReasoningPattern struct {
+		PatternType     string   `json:"pattern_type"`
+		Summary         string   `json:"summary"`
+		ConversationArc string   `json:"conversation_arc"`
+		Tags            []string `json:"tags"`
+		Confidence      float64  `json:"confidence"`
+	}
+
+	WritingStyle struct {
+		Speaker    string   `json:"speaker"`
+		Context    string   `json:"context"`
+		Samples    []string `json:"samples"`
+		Traits     []string `json:"traits"`
+		Vocabulary []string `json:"vocabulary"`
+		Patterns   []string `json:"patterns"`
+		Avoids     []string `json:"avoids"`
+		EmojiStyle string   `json:"emoji_style"`
+		Confidence float64  `json:"confidence"`
+	}
+)
+
+// Stream events and their payloads, by kind.
+type (
+	Event struct {
+		ID        uint64    `json:"id"`
+		Kind      string    `json:"kind"`
+		OwnerUUID string    `json:"owner_uuid,omitempty"`
+		Time      time.Time `json:"time"`
+		Data      any       `json:"data"`
+	}
+
+	TranscriptProcessed struct {
+		SessionRef  string      `json:"session_ref"`
+		DecisionIDs []uuid.UUID `json:"decision_ids"`
+		PatternIDs  []uuid.UUID `json:"pattern_ids"`
+	}
+
+	DecisionStored struct {
+		ID         uuid.UUID `json:"id"`
+		SessionRef string    `json:"session_ref"`
+		Source     string    `json:"source"`
+		Domain     string    `json:"domain"`
+		Category   string    `json:"category"`
+		Severity   string    `json:"severity"`
+		Summary    string    `json:"summary"`
+		Recurrence bool      `json:"recurrence,omitempty"`
+	}
+
+	PatternStored struct {
+		ID          uuid.UUID `json:"id"`
+		SessionRef  string    `json:"session_ref"`
+		PatternType string    `json:"pattern_type"`
+		Summary     string    `json:"summary"`
+		Recurrence  bool      `json:"recurrence,omitempty"`
+	}
+
+	ReviewApplied struct {
+		Kind     string    `json:"kind"`
+		ID       uuid.UUID `json:"id"`
+		Verdict  string    `json:"verdict"`
+		Reviewer string    `json:"reviewer,omitempty"`
+		Channel  string    `json:"channel"`
+	}
+
+	TrustChanged struct {
+		AgentID       string  `json:"agent_id"`
+		Category      string  `json:"category"`
+		Severity      string  `json:"severity"`
+		Correct       bool    `json:"correct"`
+		PreviousScore float64 `json:"previous_score"`
+		Score         float64 `json:"score"`
+	}
+
+	RefinementProposed struct {
+		SchemaVersion  int               `json:"schema_version"`
+		ProposalID     string            `json:"proposal_id,omitempty"`
+		ClusterID      string            `json:"cluster_id,omitempty"`
+		Patterns       []PatternProposal `json:"patterns"`
+		TargetSOULSlug string            `json:"target_soul_slug"`
+		TargetSection  string            `json:"target_section"`
+		ProposedChange string            `json:"proposed_change"`
+		SectionDiff    string            `json:"section_diff,omitempty"`
+		ClusterSize    int               `json:"cluster_size"`
+		Timestamp      time.Time         `json:"timestamp"`
+	}
+
+	PatternProposal struct {
+		ID          string  `json:"id"`
+		Summary     string  `json:"summary"`
+		PatternType string  `json:"pattern_type"`
+		Confidence  float64 `json:"confidence"`
+	}
+)
+
+// Stream event kinds.
+const (
+	EventTranscriptProcessed = "transcript.processed"
+	EventDecisionStored      = "decision.stored"
+	EventPatternStored       = "pattern.stored"
+	EventReviewApplied       = "review.applied"
+	EventTrustChanged        = "trust.changed"
+	EventRefinementProposed  = "refinement.proposed"
+)
+
+// Job states.
+const (
+	JobQueued    JobStatus = "queued"
+	JobRunning   JobStatus = "running"
+	JobSucceeded JobStatus = "succeeded"
+	JobFailed    JobStatus = "failed"
+	JobCancelled JobStatus = "cancelled"
+)
+
+// Done reports whether a job in state s has finished.
+func (s JobStatus) Done() bool {
+	return s == JobSucceeded || s == JobFailed || s == JobCancelled
+}
+
+// Dataset examples, one per line of an export.
+type (
+	DatasetExample struct {
+		Messages           []DatasetMessage `json:"messages,omitempty"`
+		Input              *DatasetInput    `json:"input,omitempty"`
+		PreferredOutput    []DatasetMessage `json:"preferred_output,omitempty"`
+		NonPreferredOutput []DatasetMessage `json:"non_preferred_output,omitempty"`
+	}
+
+	DatasetMessage struct {
+		Role    string `json:"role"`
+		Content string `json:"content"`
+	}
+
+	DatasetInput struct {
+		Messages []DatasetMessage `json:"messages"`
+	}
+)
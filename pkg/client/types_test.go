@@ -0,0 +1,172 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/MikeSquared-Agency/dredd/internal/api"
+	"github.com/MikeSquared-Agency/dredd/internal/bus"
+	"github.com/MikeSquared-Agency/dredd/internal/dataset"
+	"github.com/MikeSquared-Agency/dredd/internal/dedup"
+	"github.com/MikeSquared-Agency/dredd/internal/events"
+	"github.com/MikeSquared-Agency/dredd/internal/extractor"
+	"github.com/MikeSquared-Agency/dredd/internal/jobs"
+	"github.com/MikeSquared-Agency/dredd/internal/processor"
+	"github.com/MikeSquared-Agency/dredd/internal/store"
+)
+
+// wirePairs pairs each client type with the server type it decodes.
+var wirePairs = []struct {
+	client, server any
+}{
+	{DedupRequest{}, api.DedupRequest{}},
+	{DedupRestoreRequest{}, api.DedupRestoreRequest{}},
+	{ScanRequest{}, api.ScanRequest{}},
+	{ProposalReviewRequest{}, api.ProposalReviewRequest{}},
+	{OverrideRequest{}, api.OverrideRequest{}},
+	{ExtractRequest{}, api.ExtractRequest{}},
+	{ReviewRequest{}, api.ReviewRequest{}},
+	{PatternSearchRequest{}, api.PatternSearchRequest{}},
+	{PrecedentRequest{}, api.PrecedentRequest{}},
+
+	{ErrorResponse{}, api.ErrorResponse{}},
+	{HealthResponse{}, api.HealthResponse{}},
+	{ReadyResponse{}, api.ReadyResponse{}},
+	{StatusResponse{}, api.StatusResponse{}},
+	{DedupRunListResponse{}, api.DedupRunListResponse{}},
+	{ScanResponse{}, api.ScanResponse{}},
+	{ProposalListResponse{}, api.ProposalListResponse{}},
+	{ModeListResponse{}, api.ModeListResponse{}},
+	{TransitionListResponse{}, api.TransitionListResponse{}},
+	{AgreementResponse{}, api.AgreementResponse{}},
+	{PredictionListResponse{}, api.PredictionListResponse{}},
+	{DecisionListResponse{}, api.DecisionListResponse{}},
+	{TrustLeaderboardResponse{}, api.TrustLeaderboardResponse{}},
+	{AgentTrustResponse{}, api.AgentTrustResponse{}},
+	{TrustHistoryResponse{}, api.TrustHistoryResponse{}},
+	{ReviewResponse{}, api.ReviewResponse{}},
+	{ReviewListResponse{}, api.ReviewListResponse{}},
+	{PatternListResponse{}, api.PatternListResponse{}},
+	{PatternSearchResponse{}, api.PatternSearchResponse{}},
+	{PrecedentResponse{}, api.PrecedentResponse{}},
+
+	{Job{}, jobs.Job{}},
+	{DedupResult{}, dedup.DeduResult{}},
+	{DedupRun{}, dedup.Run{}},
+	{RestoreResult{}, dedup.RestoreResult{}},
+	{RefinementProposal{}, store.RefinementProposal{}},
+	{Decision{}, store.DecisionDetail{}},
+	{Pattern{}, store.PatternRow{}},
+	{ProcessResult{}, processor.ProcessResult{}},
+
+	{Event{}, bus.Event{}},
+	{TranscriptProcessed{}, bus.TranscriptProcessed{}},
+	{DecisionStored{}, bus.DecisionStored{}},
+	{PatternStored{}, bus.PatternStored{}},
+	{ReviewApplied{}, bus.ReviewApplied{}},
+	{TrustChanged{}, bus.TrustChanged{}},
+	{RefinementProposed{}, events.RefinementProposed{}},
+
+	{DatasetExample{}, dataset.Example{}},
+	{ExtractionResult{}, extractor.ExtractionResult{}},
+}
+
+// TestTypes_RoundTrip fills every field of each type, then checks that its
+// JSON decodes into the counterpart without unknown fields and re-encodes to
+// the same document, in both directions.
+func TestTypes_RoundTrip(t *testing.T) {
+	for _, pair := range wirePairs {
+		name := reflect.TypeOf(pair.client).Name()
+		roundTrip(t, name, pair.client, pair.server)
+		roundTrip(t, name, pair.server, pair.client)
+	}
+}
+
+func roundTrip(t *testing.T, name string, from, to any) {
+	t.Helper()
+	src := reflect.New(reflect.TypeOf(from))
+	fill(src.Elem())
+	want, err := json.Marshal(src.Interface())
+	if err != nil {
+		t.Fatalf("%s: marshal %T: %v", name, from, err)
+	}
+
+	dst := reflect.New(reflect.TypeOf(to))
+	dec := json.NewDecoder(bytes.NewReader(want))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(dst.Interface()); err != nil {
+		t.Errorf("%s: decode %T into %T: %v", name, from, to, err)
+		return
+	}
+	got, err := json.Marshal(dst.Interface())
+	if err != nil {
+		t.Fatalf("%s: marshal %T: %v", name, to, err)
+	}
+
+	var wantDoc, gotDoc any
+	json.Unmarshal(want, &wantDoc)
+	json.Unmarshal(got, &gotDoc)
+	if !reflect.DeepEqual(wantDoc, gotDoc) {
+		t.Errorf("%s: %T -> %T changed the document\nwant %s\ngot  %s", name, from, to, want, got)
+	}
+}
+
+var (
+	timeType = reflect.TypeOf(time.Time{})
+	rawType  = reflect.TypeOf(json.RawMessage{})
+)
+
+// fill sets v, and everything it points to, to a non-zero value.
+func fill(v reflect.Value) {
+	switch {
+	case v.Type() == timeType:
+		v.Set(reflect.ValueOf(time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)))
+		return
+	case v.Type() == rawType:
+		v.Set(reflect.ValueOf(json.RawMessage(`{"k":1}`)))
+		return
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString("x")
+	case reflect.Bool:
+		v.SetBool(true)
+	case reflect.Int, reflect.Int64, reflect.Uint64, reflect.Uint8:
+		if v.CanInt() {
+			v.SetInt(7)
+		} else {
+			v.SetUint(7)
+		}
+	case reflect.Float32, reflect.Float64:
+		v.SetFloat(0.5)
+	case reflect.Pointer:
+		v.Set(reflect.New(v.Type().Elem()))
+		fill(v.Elem())
+	case reflect.Slice:
+		v.Set(reflect.MakeSlice(v.Type(), 1, 1))
+		fill(v.Index(0))
+	case reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			fill(v.Index(i))
+		}
+	case reflect.Map:
+		v.Set(reflect.MakeMap(v.Type()))
+		key := reflect.New(v.Type().Key()).Elem()
+		elem := reflect.New(v.Type().Elem()).Elem()
+		fill(key)
+		fill(elem)
+		v.SetMapIndex(key, elem)
+	case reflect.Interface:
+		v.Set(reflect.ValueOf("x"))
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			if v.Type().Field(i).IsExported() {
+				fill(v.Field(i))
+			}
+		}
+	}
+}
@@ -23,7 +23,15 @@ else
   fail "Health check" "expected 200, got $HTTP"
 fi
 
-# 2. Status endpoint
+# 2. Readiness — every dependency checked
+HTTP=$(curl -s -o /tmp/e2e_body -w '%{http_code}' "${BASE}/ready")
+if [ "$HTTP" = "200" ]; then
+  pass "Readiness check"
+else
+  fail "Readiness check" "expected 200, got $HTTP: $(cat /tmp/e2e_body)"
+fi
+
+# 3. Status endpoint
 HTTP=$(curl -s -o /tmp/e2e_body -w '%{http_code}' "${BASE}/api/v1/dredd/status")
 if [ "$HTTP" = "200" ]; then
   BODY=$(cat /tmp/e2e_body)
@@ -41,7 +49,7 @@ else
   fail "Status endpoint" "expected 200, got $HTTP"
 fi
 
-# 3. 404 on unknown route
+# 4. 404 on unknown route
 HTTP=$(curl -s -o /dev/null -w '%{http_code}' "${BASE}/api/v1/nonexistent")
 if [ "$HTTP" = "404" ]; then
   pass "Unknown route returns 404"